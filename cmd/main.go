@@ -1,17 +1,43 @@
 package main
 
 import (
+	"book-store/internal/book"
 	"book-store/internal/config"
 	"book-store/internal/db"
+	"book-store/internal/health"
 	appHttp "book-store/internal/http"
+	"book-store/internal/loan"
+	"book-store/internal/migration"
+	"context"
+	"errors"
+	"flag"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	configPath         = "config.json"
+	defaultHealthAddr  = ":8080"
+	healthProbeTimeout = 2 * time.Second
+	holdExpiryInterval = time.Hour
+)
+
 func main(){
-	cfg, err := config.LoadConfig("config.json")
+	healthFlag := flag.Bool("health", false, "probe the liveness endpoint of a running instance and exit 0 when healthy, 1 otherwise")
+	flag.Parse()
+	if *healthFlag {
+		os.Exit(probe())
+	}
+
+	cfg, err := config.LoadConfig(configPath)
 	if err != nil {
 		logrus.Fatalf("config load: %v", err)
 	}
@@ -20,12 +46,108 @@ func main(){
 	if err != nil {
 		logrus.Fatalf("db init: %v", err)
 	}
-	defer db.Close()
+
+	if flag.Arg(0) == "migrate" {
+		err := runMigrate(context.Background(), db, flag.Args()[1:])
+		db.Close()
+		if err != nil {
+			logrus.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		logrus.Fatalf("migrations init: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		logrus.Fatalf("migrations: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	var jobs sync.WaitGroup
 
 	r := mux.NewRouter()
-	appHttp.RegisterRoutes(r, db)
-	err = http.ListenAndServe(":8080", r)
+	services := appHttp.RegisterRoutes(ctx, &jobs, r, db, cfg)
+	srv := &http.Server{
+		Addr:           cfg.GetServerAddress(),
+		Handler:        r,
+		ReadTimeout:    cfg.GetReadTimeout(),
+		WriteTimeout:   cfg.GetWriteTimeout(),
+		IdleTimeout:    cfg.GetIdleTimeout(),
+		MaxHeaderBytes: cfg.GetMaxHeaderBytes(),
+	}
+
+	// The server is not listening yet, so every import still running was
+	// left behind by the last shutdown.
+	if n, err := services.Imports.FailInterrupted(ctx); err != nil {
+		logrus.Error("error while failing the imports left running. error is ", err)
+	} else if n > 0 {
+		logrus.Info("failed ", n, " imports left running by the last shutdown")
+	}
+
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		loan.RunHoldExpiry(ctx, services.Holds, holdExpiryInterval)
+	}()
+	trash := cfg.GetTrash()
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		book.RunTrashPurge(ctx, services.Books, time.Duration(trash.Retention), time.Duration(trash.PurgeInterval))
+	}()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		logrus.Info("starting the server on ", srv.Addr)
+		serverErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			logrus.Fatalf("error while starting the server. error: %s", err.Error())
+		}
+	case <-ctx.Done():
+		logrus.Info("shutdown signal received, draining in-flight requests")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.GetShutdownTimeout())
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logrus.Error("error while shutting down the server. error is ", err)
+	}
+	stop()
+	jobs.Wait()
+	// Shutdown returns once every handler has finished and the background jobs
+	// have stopped, so nothing is using the pool anymore.
+	if err := db.Close(); err != nil {
+		logrus.Error("error while closing the db pool. error is ", err)
+	}
+	logrus.Info("server stopped")
+}
+
+// probe checks the liveness endpoint of the instance listening on the
+// configured address and returns the process exit code.
+func probe() int {
+	addr := defaultHealthAddr
+	if cfg, err := config.LoadConfig(configPath); err == nil {
+		addr = cfg.GetServerAddress()
+	}
+	host, port, err := net.SplitHostPort(addr)
 	if err != nil {
-		logrus.Fatalf("error while starting the server. error: %s", err.Error())
+		logrus.Errorf("health check failed: invalid server address %s: %v", addr, err)
+		return 1
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	url := "http://" + net.JoinHostPort(host, port) + "/healthz"
+	if err := health.Probe(url, healthProbeTimeout); err != nil {
+		logrus.Errorf("health check failed: %v", err)
+		return 1
 	}
+	return 0
 }
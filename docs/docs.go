@@ -15,9 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/books": {
+        "/authors": {
             "get": {
-                "description": "Returns a paginated list of books",
+                "description": "Returns a paginated list of authors, optionally filtered by name",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,10 +25,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "authors"
                 ],
-                "summary": "List books with pagination",
+                "summary": "List authors with pagination",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive part of the author name",
+                        "name": "name",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -48,7 +54,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/book.PaginatedBookListResponse"
+                            "$ref": "#/definitions/author.PaginatedAuthorListResponse"
                         }
                     },
                     "400": {
@@ -60,7 +66,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Add a new book record",
+                "description": "Add a new author record",
                 "consumes": [
                     "application/json"
                 ],
@@ -68,17 +74,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "authors"
                 ],
-                "summary": "Create a new book",
+                "summary": "Create a new author",
                 "parameters": [
                     {
-                        "description": "Book data",
-                        "name": "book",
+                        "description": "Author data",
+                        "name": "author",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/book.CreateOrUpdateBookRequest"
+                            "$ref": "#/definitions/author.CreateOrUpdateAuthorRequest"
                         }
                     }
                 ],
@@ -88,7 +94,7 @@ const docTemplate = `{
                         "headers": {
                             "Location": {
                                 "type": "string",
-                                "description": "URL of created book"
+                                "description": "URL of created author"
                             }
                         }
                     },
@@ -101,9 +107,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/books/{id}": {
+        "/authors/{id}": {
             "get": {
-                "description": "Retrieve a single book by its ID",
+                "description": "Retrieve a single author by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -111,13 +117,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "authors"
                 ],
-                "summary": "Get book by ID",
+                "summary": "Get author by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Book ID",
+                        "description": "Author ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -127,7 +133,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/book.BookResponse"
+                            "$ref": "#/definitions/author.AuthorResponse"
                         }
                     },
                     "400": {
@@ -145,7 +151,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update existing book or create if not exists",
+                "description": "Replace the name and bio of an existing author. Books whose byline is made of linked author names get the new name too",
                 "consumes": [
                     "application/json"
                 ],
@@ -153,36 +159,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "authors"
                 ],
-                "summary": "Update or create book by ID",
+                "summary": "Update author by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Book ID",
+                        "description": "Author ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Book data",
-                        "name": "book",
+                        "description": "Author data",
+                        "name": "author",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/book.CreateOrUpdateBookRequest"
+                            "$ref": "#/definitions/author.CreateOrUpdateAuthorRequest"
                         }
                     }
                 ],
                 "responses": {
                     "204": {
-                        "description": "No Content",
-                        "headers": {
-                            "Location": {
-                                "type": "string",
-                                "description": "Optional new resource URL"
-                            }
-                        }
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -199,7 +199,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Remove a book record",
+                "description": "Remove an author record. Authors still linked to books cannot be deleted",
                 "consumes": [
                     "application/json"
                 ],
@@ -207,13 +207,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "authors"
                 ],
-                "summary": "Delete book by ID",
+                "summary": "Delete author by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Book ID",
+                        "description": "Author ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -234,100 +234,3998 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/book.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "book.BookResponse": {
-            "type": "object",
-            "properties": {
-                "author": {
-                    "type": "string",
-                    "example": "JK Rolling"
-                },
-                "description": {
-                    "type": "string",
-                    "example": "harry potter and his friends"
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "title": {
-                    "type": "string",
-                    "example": "Harry Potter"
-                }
-            }
         },
-        "book.CreateOrUpdateBookRequest": {
-            "type": "object",
-            "required": [
-                "author",
-                "title"
-            ],
-            "properties": {
-                "author": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
-                },
-                "description": {
-                    "type": "string",
-                    "maxLength": 500
-                },
-                "title": {
-                    "type": "string",
-                    "maxLength": 200,
-                    "minLength": 1
+        "/books": {
+            "get": {
+                "description": "Returns a paginated list of books, optionally filtered and sorted. Dates are RFC 3339 timestamps or YYYY-MM-DD days. Passing cursor (empty for the first page) switches to keyset paging: the response is a CursorBookListResponse, page is ignored and the nextCursor of each response fetches the following page",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "List books with pagination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive part of the title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive part of the byline or of a linked author's name",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only books linked to this author",
+                        "name": "authorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only books with (true) or without (false) a copy on the shelf",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books added at or after this time",
+                        "name": "createdAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books added before this time",
+                        "name": "createdBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books of this genre, as listed in the genre facet",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books in this language, an ISO 639 code such as en",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only books published in the decade starting with this year, e.g. 1990",
+                        "name": "decade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also count the matching books per author, genre, language and decade (default false)",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields out of id, title, author and createdAt; prefix with - to sort descending, e.g. title,-createdAt",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from nextCursor; send it empty to start keyset paging",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "In cursor mode, also count the matching books (default false)",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size (1–100, default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.PaginatedBookListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
                 }
-            }
-        },
-        "book.ErrorCode": {
-            "type": "string",
-            "enum": [
-                "BOOK_NOT_FOUND",
-                "INTERNAL_SERVER_ERROR",
-                "BAD_REQUEST"
-            ],
-            "x-enum-varnames": [
-                "BookNotFound",
-                "InternalServerError",
-                "BadRequest"
-            ]
-        },
-        "book.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "errorCode": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/book.ErrorCode"
+            },
+            "post": {
+                "description": "Add a new book record",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Create a new book",
+                "parameters": [
+                    {
+                        "description": "Book data",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/book.CreateOrUpdateBookRequest"
                         }
-                    ],
-                    "example": "BAD_REQUEST"
-                },
-                "errorMessage": {
-                    "type": "string",
-                    "example": "limit must be \u003e=1"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of created book"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "book.PaginatedBookListResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/book.BookResponse"
+        "/books/citations": {
+            "get": {
+                "description": "Renders the books with the given ids, in that order, as BibTeX, RIS or CSL-JSON for reference managers. The format is taken from the format parameter or else from the Accept header, and is BibTeX when neither names one. Citation keys are made of the family name of the first author, the year, the first word of the title and the book id, so a book has the same key in every export",
+                "produces": [
+                    "application/x-bibtex",
+                    "application/x-research-info-systems",
+                    "application/vnd.citationstyles.csl+json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Export citations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma separated book ids, at most 100",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "bibtex (default), ris or csl-json",
+                        "name": "format",
+                        "in": "query"
                     }
-                },
-                "limit": {
-                    "type": "integer",
-                    "example": 10
-                },
-                "page": {
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/export": {
+            "get": {
+                "description": "Streams every book matching the filters, as accepted by the book list, as CSV or as newline-delimited JSON with one BookResponse per line. All books come from one consistent snapshot of the catalog, however long the download takes. A failure after the download has started breaks off the connection, so a cut-off export never looks complete",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Export books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (default) or ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive part of the title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive part of the byline or of a linked author's name",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only books linked to this author",
+                        "name": "authorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only books with (true) or without (false) a copy on the shelf",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books added at or after this time",
+                        "name": "createdAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books added before this time",
+                        "name": "createdBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books of this genre, as listed in the genre facet",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books in this language, an ISO 639 code such as en",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only books published in the decade starting with this year, e.g. 1990",
+                        "name": "decade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields out of id, title, author and createdAt; prefix with - to sort descending (default id)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/export/marc": {
+            "get": {
+                "description": "Streams every book in the catalog as MARC 21 records, concatenated in ISO 2709 (format marc) or in one MARCXML collection (format marcxml). Like the book export, all records come from one consistent snapshot and a failure after the download has started breaks off the connection",
+                "produces": [
+                    "application/marc",
+                    "application/marcxml+xml"
+                ],
+                "tags": [
+                    "marc"
+                ],
+                "summary": "Export catalog as MARC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "marc (default) or marcxml",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/isbn/{isbn}": {
+            "get": {
+                "description": "Retrieve a single book by its ISBN-10 or ISBN-13, hyphens allowed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get book by ISBN",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISBN-10 or ISBN-13",
+                        "name": "isbn",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.BookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/search": {
+            "get": {
+                "description": "Full-text search over title, author and description, most relevant first, narrowed by the same filters as the book list. All words must match; \"quoted words\" must appear as a phrase and a word ending in * matches as a prefix",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Search books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search terms, e.g. wizard pott*",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive part of the title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive part of the byline or of a linked author's name",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only books linked to this author",
+                        "name": "authorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only books with (true) or without (false) a copy on the shelf",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books added at or after this time",
+                        "name": "createdAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books added before this time",
+                        "name": "createdBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books of this genre, as listed in the genre facet",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books in this language, an ISO 639 code such as en",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only books published in the decade starting with this year, e.g. 1990",
+                        "name": "decade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also count the matching books per author, genre, language and decade (default false)",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size (1–100, default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.PaginatedBookSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/suggest": {
+            "get": {
+                "description": "Fuzzy matches partial or misspelled input against titles and authors, best match first, for autocomplete",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Suggest books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Typed input, at least 3 characters, e.g. hary poter",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of suggestions (1–20, default 5)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.BookSuggestionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}": {
+            "get": {
+                "description": "Retrieve a single book by its ID. The ETag header carries the version of the book; sending it back in If-None-Match answers 304 while the book is unchanged. The book is rendered as a BibTeX, RIS or CSL-JSON citation instead when the format parameter or the Accept header asks for one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-bibtex",
+                    "application/x-research-info-systems",
+                    "application/vnd.citationstyles.csl+json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get book by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default), bibtex, ris or csl-json; overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a copy the client already holds",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.BookResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the book as a quoted number"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace existing book or create it at the given id if it does not exist. The body replaces the whole book, so fields left out are cleared; use PATCH to change some fields only. Replacing requires the ETag of the book in If-Match, so edits based on an outdated copy are refused. A book in the trash is refused with 409 until it is restored. A replaced book is returned only when asked for with Prefer: return=representation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Replace or create book by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the book being updated; required unless the book is created",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "return=representation to get the replaced book back",
+                        "name": "Prefer",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Book data",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/book.CreateOrUpdateBookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.BookResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the book"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/book.BookResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the book"
+                            },
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of created book"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "No Content",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the book"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move a book to the trash, from where it can be restored until it is purged. Requires the ETag of the book in If-Match. Books that do not exist or are already in the trash give 404. A book with copies on loan or with members waiting for it gives 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Delete book by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the book being deleted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change some fields of a book with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902), chosen by Content-Type. The patch applies to the book as a CreateOrUpdateBookRequest and the result must be valid as one. Requires the ETag of the book in If-Match",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Patch book by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the book being patched",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch object or array of JSON Patch operations",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the book"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/copies": {
+            "get": {
+                "description": "Returns every physical copy of the book",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "List copies of a book",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/book.CopyResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register a new physical copy. Condition defaults to good, status to available and the acquisition date to today. An available copy goes straight to the first member waiting for the book, if any, and is then on_hold",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Add a copy of a book",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Copy data",
+                        "name": "copy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/book.CreateOrUpdateCopyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of created copy"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/copies/{copyId}": {
+            "get": {
+                "description": "Retrieve a single physical copy by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Get a copy of a book",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Copy ID",
+                        "name": "copyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.CopyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Change the barcode, condition, status or acquisition date of a copy. Empty optional fields are left unchanged. The status of a copy on loan or set aside for a hold only changes through checkout, return and holds, so it cannot be changed here. A copy made available again goes to the first member waiting for the book, if any",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Update a copy of a book",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Copy ID",
+                        "name": "copyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Copy data",
+                        "name": "copy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/book.CreateOrUpdateCopyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a physical copy from the inventory. A copy on loan or set aside for a hold cannot be deleted, and neither can one that was ever lent, as its loans are kept; mark it lost instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Delete a copy of a book",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Copy ID",
+                        "name": "copyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/holds": {
+            "post": {
+                "description": "Queue a member for the next copy of a book that has no copy available. Holds are served first come, first served; a returned copy is set aside for the first member in the queue, who has 7 days to collect it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Place a hold on a book",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member placing the hold",
+                        "name": "hold",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/loan.PlaceHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/loan.HoldResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of created hold"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/marc": {
+            "get": {
+                "description": "Returns the book as a MARC 21 bibliographic record, in ISO 2709 (format marc) or as a MARCXML document (format marcxml)",
+                "produces": [
+                    "application/marc",
+                    "application/marcxml+xml"
+                ],
+                "tags": [
+                    "marc"
+                ],
+                "summary": "Get book as MARC",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "marc (default) or marcxml",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/restore": {
+            "post": {
+                "description": "Takes a deleted book out of the trash. Fails with 409 when another book has taken its isbn in the meantime",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Restore book from the trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.BookResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the book"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/revisions": {
+            "get": {
+                "description": "Returns a paginated list of the revisions of a book, newest first. A revision is recorded for every create, update, delete and restore, numbered by the version it produced, with the X-User of the request that made it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "List book revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size (1–100, default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.PaginatedRevisionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/revisions/diff": {
+            "get": {
+                "description": "Lists the fields whose values differ between two revisions of a book, in the order of the PUT body",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Diff book revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare to",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.RevisionDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/revisions/{rev}": {
+            "get": {
+                "description": "Returns one revision of a book with a snapshot of the book in the shape of a PUT body",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get book revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.RevisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/revisions/{rev}/revert": {
+            "post": {
+                "description": "Replaces the book with the snapshot of one of its revisions, as a PUT of that snapshot would, and records the result as a new revision. Requires the ETag of the book in If-Match",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Revert book to a revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the book being reverted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the book"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books:batch": {
+            "post": {
+                "description": "Applies a list of create, update and delete operations, each checked like the single-book endpoint would check it; updates and deletes need the ETag of the book in ifMatch. In atomic mode, the default, the operations run in one transaction and nothing is kept unless all of them succeed, the others then being reported as BATCH_ABORTED. In bestEffort mode every operation that succeeds is kept. The response lists the status of each operation in order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Create, update and delete books in bulk",
+                "parameters": [
+                    {
+                        "description": "Operations (at most 500)",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/book.BatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.BatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Reports that the process is up and serving requests",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.StatusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/holds/{id}": {
+            "get": {
+                "description": "Retrieve a single hold with its current queue position",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Get hold by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hold ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/loan.HoldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Leave the queue. A copy already set aside for the hold passes on to the next member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Cancel a hold",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hold ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports": {
+            "post": {
+                "description": "Starts importing the rows of a CSV file as books and returns the import job at once. The file needs a header row; columns are matched to the fields of CreateOrUpdateBookRequest by name unless the mapping, a JSON object from field name to column name, names them. Each row is validated like a POST body and skipped as a duplicate when its isbn, or else its title and author, is already in the catalog or earlier in the file. With dryRun every row is checked but no book is created",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Import books from a CSV file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file (at most 256 MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object from field name to CSV column name",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Check the rows without creating books",
+                        "name": "dryRun",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/book.ImportJobResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the import job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/marc": {
+            "post": {
+                "description": "Creates a book from every record of a MARC 21 file, sent as the request body in ISO 2709 (Content-Type application/marc) or as MARCXML (application/marcxml+xml). The file may hold up to 1000 records and 32 MB. Each record is validated like a POST body. The response reports the outcome of every record together with the fields and subfields that had no place in a book and were dropped. With dryRun the records are checked but no book is created",
+                "consumes": [
+                    "application/marc",
+                    "application/marcxml+xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marc"
+                ],
+                "summary": "Import books from MARC",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Check the records without creating books",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/marc.ImportReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/{id}": {
+            "get": {
+                "description": "Returns the status and progress of an import. Progress is the share of the file read so far, from 0 to 1. Once rows have been skipped, errorReport links to the list of them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Get import job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Import ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.ImportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/{id}/errors": {
+            "get": {
+                "description": "Returns the rows of an import that were not imported as CSV with the columns row, reason and message. Row 1 is the header of the file. The reason is invalid, duplicate or failed",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Download import error report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Import ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/loans": {
+            "post": {
+                "description": "Lend the copy with the given barcode to a member. The due date and the number of loans a member may hold depend on the membership type. Members owing more than the fine threshold may not borrow",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Check out a copy",
+                "parameters": [
+                    {
+                        "description": "Member and copy barcode",
+                        "name": "loan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/loan.CheckoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/loan.LoanResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of created loan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/loans/{id}": {
+            "get": {
+                "description": "Retrieve a single loan by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Get loan by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/loan.LoanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/loans/{id}/renew": {
+            "post": {
+                "description": "Extend the due date by another loan period counted from today. The number of renewals depends on the membership type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Renew a loan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/loan.LoanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/loans/{id}/return": {
+            "post": {
+                "description": "Close the loan and put the copy back on the shelf. A late return is fined according to the membership type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Return a loan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/loan.LoanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/members": {
+            "get": {
+                "description": "Returns a paginated list of members ordered by name. q matches part of the name or email, or the exact card number",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Search members with pagination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of the name or email, or a card number",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "standard",
+                            "student",
+                            "senior",
+                            "staff"
+                        ],
+                        "type": "string",
+                        "description": "Only members of this membership type",
+                        "name": "membershipType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only suspended (true) or not suspended (false) members",
+                        "name": "suspended",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size (1–100, default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/member.PaginatedMemberListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register a patron and issue a library card. Membership type defaults to standard and the membership runs for a year unless expiresOn is given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Register a new member",
+                "parameters": [
+                    {
+                        "description": "Member data",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/member.CreateOrUpdateMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of registered member"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/members/{id}": {
+            "get": {
+                "description": "Retrieve a single member by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Get member by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/member.MemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the contact details and membership of a member. An empty membershipType or expiresOn keeps the stored value",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Update member by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member data",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/member.CreateOrUpdateMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a member record. Members who have borrowed anything cannot be deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Delete member by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/members/{id}/holds": {
+            "get": {
+                "description": "Returns the waiting and ready holds of a member, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "List a member's holds",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/loan.HoldResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/members/{id}/ledger": {
+            "get": {
+                "description": "Returns the outstanding balance and a page of fines, payments and waivers, newest first. Amounts are in cents",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fines"
+                ],
+                "summary": "Get a member's ledger",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size (1–100, default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/fine.LedgerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/members/{id}/payments": {
+            "post": {
+                "description": "Record a payment towards the member's outstanding fines. The amount, in cents, may not exceed the balance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fines"
+                ],
+                "summary": "Record a payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount paid",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/fine.PaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/fine.EntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/members/{id}/reinstate": {
+            "post": {
+                "description": "Restore the borrowing privileges of a suspended member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Lift a member's suspension",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/members/{id}/suspend": {
+            "post": {
+                "description": "Suspend the member's borrowing privileges. Suspending an already suspended member only updates the reason",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Suspend a member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Suspension reason",
+                        "name": "suspension",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/member.SuspendMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/members/{id}/waivers": {
+            "post": {
+                "description": "Forgive part or all of the member's outstanding fines. The amount, in cents, may not exceed the balance and a reason is required",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fines"
+                ],
+                "summary": "Waive fines",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount waived and reason",
+                        "name": "waiver",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/fine.WaiverRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/fine.EntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Reports whether the instance can serve traffic, i.e. every dependency check passes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.StatusResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/health.StatusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/books": {
+            "get": {
+                "description": "Returns a paginated list of the deleted books that have not been purged yet, most recently deleted first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "List trashed books",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size (1–100, default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/book.PaginatedBookListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/book.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "author.AuthorResponse": {
+            "type": "object",
+            "properties": {
+                "bio": {
+                    "type": "string",
+                    "example": "British author, best known for Harry Potter"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "J.K. Rowling"
+                }
+            }
+        },
+        "author.CreateOrUpdateAuthorRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "bio": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "British author, best known for Harry Potter"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "J.K. Rowling"
+                }
+            }
+        },
+        "author.PaginatedAuthorListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/author.AuthorResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "book.BatchItemResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/book.ErrorResponse"
+                },
+                "etag": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 201
+                }
+            }
+        },
+        "book.BatchOperationRequest": {
+            "type": "object",
+            "required": [
+                "op"
+            ],
+            "properties": {
+                "book": {
+                    "$ref": "#/definitions/book.CreateOrUpdateBookRequest"
+                },
+                "id": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 12
+                },
+                "ifMatch": {
+                    "type": "string"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete"
+                    ],
+                    "example": "update"
+                }
+            }
+        },
+        "book.BatchRequest": {
+            "type": "object",
+            "required": [
+                "operations"
+            ],
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "bestEffort"
+                    ],
+                    "example": "atomic"
+                },
+                "operations": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/book.BatchOperationRequest"
+                    }
+                }
+            }
+        },
+        "book.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/book.BatchItemResponse"
+                    }
+                }
+            }
+        },
+        "book.BookAuthorRequest": {
+            "type": "object",
+            "required": [
+                "authorId"
+            ],
+            "properties": {
+                "authorId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "author",
+                        "editor",
+                        "translator",
+                        "illustrator"
+                    ],
+                    "example": "author"
+                }
+            }
+        },
+        "book.BookAuthorResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "J.K. Rowling"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "role": {
+                    "type": "string",
+                    "example": "author"
+                }
+            }
+        },
+        "book.BookResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "JK Rolling"
+                },
+                "authors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/book.BookAuthorResponse"
+                    }
+                },
+                "available": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "availableCopies": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2026-10-17T09:30:00Z"
+                },
+                "deletedAt": {
+                    "description": "DeletedAt is only set on books in the trash.",
+                    "type": "string",
+                    "example": "2026-10-17T09:30:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "harry potter and his friends"
+                },
+                "genre": {
+                    "type": "string",
+                    "example": "fantasy"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "isbn10": {
+                    "type": "string",
+                    "example": "0747532699"
+                },
+                "isbn13": {
+                    "type": "string",
+                    "example": "9780747532699"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "nextDueOn": {
+                    "description": "NextDueOn is the earliest due date of the copies currently on loan.",
+                    "type": "string",
+                    "example": "2026-11-07"
+                },
+                "publishedYear": {
+                    "type": "integer",
+                    "example": 1998
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Harry Potter"
+                },
+                "totalCopies": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "book.BookSearchResult": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "JK Rolling"
+                },
+                "authors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/book.BookAuthorResponse"
+                    }
+                },
+                "available": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "availableCopies": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2026-10-17T09:30:00Z"
+                },
+                "deletedAt": {
+                    "description": "DeletedAt is only set on books in the trash.",
+                    "type": "string",
+                    "example": "2026-10-17T09:30:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "harry potter and his friends"
+                },
+                "genre": {
+                    "type": "string",
+                    "example": "fantasy"
+                },
+                "highlights": {
+                    "$ref": "#/definitions/book.SearchHighlights"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "isbn10": {
+                    "type": "string",
+                    "example": "0747532699"
+                },
+                "isbn13": {
+                    "type": "string",
+                    "example": "9780747532699"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "nextDueOn": {
+                    "description": "NextDueOn is the earliest due date of the copies currently on loan.",
+                    "type": "string",
+                    "example": "2026-11-07"
+                },
+                "publishedYear": {
+                    "type": "integer",
+                    "example": 1998
+                },
+                "rank": {
+                    "type": "number",
+                    "example": 0.6079271
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Harry Potter"
+                },
+                "totalCopies": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "book.BookSuggestion": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "J.K. Rowling"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.82
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Harry Potter and the Chamber of Secrets"
+                }
+            }
+        },
+        "book.BookSuggestionsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/book.BookSuggestion"
+                    }
+                }
+            }
+        },
+        "book.CopyResponse": {
+            "type": "object",
+            "properties": {
+                "acquiredOn": {
+                    "type": "string",
+                    "example": "2024-03-01"
+                },
+                "barcode": {
+                    "type": "string",
+                    "example": "LIB-000123"
+                },
+                "bookId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "condition": {
+                    "type": "string",
+                    "example": "good"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "example": "available"
+                }
+            }
+        },
+        "book.CreateOrUpdateBookRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "author": {
+                    "description": "Author is the byline, which may be left out when authors are linked\nand is then made of their names.",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "authors": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/book.BookAuthorRequest"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "genre": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "fantasy"
+                },
+                "isbn": {
+                    "type": "string",
+                    "example": "978-0-7475-3269-9"
+                },
+                "language": {
+                    "type": "string",
+                    "maxLength": 3,
+                    "minLength": 2,
+                    "example": "en"
+                },
+                "publishedYear": {
+                    "type": "integer",
+                    "maximum": 9999,
+                    "minimum": 1,
+                    "example": 1998
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1
+                }
+            }
+        },
+        "book.CreateOrUpdateCopyRequest": {
+            "type": "object",
+            "required": [
+                "barcode"
+            ],
+            "properties": {
+                "acquiredOn": {
+                    "type": "string",
+                    "example": "2024-03-01"
+                },
+                "barcode": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 1,
+                    "example": "LIB-000123"
+                },
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "new",
+                        "good",
+                        "fair",
+                        "poor",
+                        "damaged"
+                    ],
+                    "example": "good"
+                },
+                "status": {
+                    "description": "Status cannot be on_loan, which only checkout sets.",
+                    "type": "string",
+                    "enum": [
+                        "available",
+                        "lost",
+                        "in_repair"
+                    ],
+                    "example": "available"
+                }
+            }
+        },
+        "book.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "BOOK_NOT_FOUND",
+                "INTERNAL_SERVER_ERROR",
+                "BAD_REQUEST",
+                "ISBN_ALREADY_EXISTS",
+                "COPY_NOT_FOUND",
+                "BARCODE_ALREADY_EXISTS",
+                "COPY_IN_CIRCULATION",
+                "COPY_HAS_LOANS",
+                "BOOK_IN_CIRCULATION",
+                "BOOK_IN_TRASH",
+                "SUGGEST_TIMEOUT",
+                "PRECONDITION_FAILED",
+                "PRECONDITION_REQUIRED",
+                "PATCH_TEST_FAILED",
+                "UNSUPPORTED_MEDIA_TYPE",
+                "REVISION_NOT_FOUND",
+                "BATCH_ABORTED",
+                "IMPORT_NOT_FOUND"
+            ],
+            "x-enum-varnames": [
+                "BookNotFound",
+                "InternalServerError",
+                "BadRequest",
+                "IsbnAlreadyExists",
+                "CopyNotFound",
+                "BarcodeAlreadyExists",
+                "CopyInCirculation",
+                "CopyHasLoans",
+                "BookInCirculation",
+                "BookInTrash",
+                "SuggestTimeout",
+                "PreconditionFailed",
+                "PreconditionRequired",
+                "PatchTestFailed",
+                "UnsupportedMediaType",
+                "RevisionNotFound",
+                "BatchAborted",
+                "ImportNotFound"
+            ]
+        },
+        "book.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "errorCode": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/book.ErrorCode"
+                        }
+                    ],
+                    "example": "BAD_REQUEST"
+                },
+                "errorMessage": {
+                    "type": "string",
+                    "example": "limit must be \u003e=1"
+                }
+            }
+        },
+        "book.FacetValueResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "label": {
+                    "type": "string",
+                    "example": "J.K. Rowling"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "3"
+                }
+            }
+        },
+        "book.FacetsResponse": {
+            "type": "object",
+            "properties": {
+                "authorId": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/book.FacetValueResponse"
+                    }
+                },
+                "decade": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/book.FacetValueResponse"
+                    }
+                },
+                "genre": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/book.FacetValueResponse"
+                    }
+                },
+                "language": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/book.FacetValueResponse"
+                    }
+                }
+            }
+        },
+        "book.FieldChangeResponse": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "title"
+                },
+                "from": {},
+                "to": {}
+            }
+        },
+        "book.ImportJobResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-03-01T10:00:00Z"
+                },
+                "createdBy": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "dryRun": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "duplicates": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "error": {
+                    "type": "string"
+                },
+                "errorReport": {
+                    "type": "string",
+                    "example": "/imports/7/errors"
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "fileName": {
+                    "type": "string",
+                    "example": "catalog.csv"
+                },
+                "finishedAt": {
+                    "type": "string",
+                    "example": "2024-03-01T10:02:13Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "imported": {
+                    "type": "integer",
+                    "example": 1180
+                },
+                "progress": {
+                    "type": "number",
+                    "example": 0.42
+                },
+                "rows": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "status": {
+                    "type": "string",
+                    "example": "running"
+                }
+            }
+        },
+        "book.PaginatedBookListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/book.BookResponse"
+                    }
+                },
+                "facets": {
+                    "$ref": "#/definitions/book.FacetsResponse"
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "book.PaginatedBookSearchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/book.BookSearchResult"
+                    }
+                },
+                "facets": {
+                    "$ref": "#/definitions/book.FacetsResponse"
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "book.PaginatedRevisionListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/book.RevisionResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "book.RevisionDiffResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/book.FieldChangeResponse"
+                    }
+                },
+                "from": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "to": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "book.RevisionResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "changedAt": {
+                    "type": "string",
+                    "example": "2024-03-01T10:00:00Z"
+                },
+                "changedBy": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "revision": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "snapshot": {
+                    "$ref": "#/definitions/book.CreateOrUpdateBookRequest"
+                }
+            }
+        },
+        "book.SearchHighlights": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "\u003cmark\u003eharry\u003c/mark\u003e \u003cmark\u003epotter\u003c/mark\u003e and his friends"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Harry \u003cmark\u003ePotter\u003c/mark\u003e"
+                }
+            }
+        },
+        "fine.EntryResponse": {
+            "type": "object",
+            "properties": {
+                "amountCents": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "balanceCents": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2026-10-17T09:30:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "charge"
+                },
+                "loanId": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "note": {
+                    "type": "string",
+                    "example": "overdue fine for loan 9"
+                }
+            }
+        },
+        "fine.LedgerResponse": {
+            "type": "object",
+            "properties": {
+                "balanceCents": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fine.EntryResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "fine.PaymentRequest": {
+            "type": "object",
+            "required": [
+                "amountCents"
+            ],
+            "properties": {
+                "amountCents": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Paid at the front desk"
+                }
+            }
+        },
+        "fine.WaiverRequest": {
+            "type": "object",
+            "required": [
+                "amountCents",
+                "note"
+            ],
+            "properties": {
+                "amountCents": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 1,
+                    "example": "Book was returned late due to hospital stay"
+                }
+            }
+        },
+        "health.StatusResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "loan.CheckoutRequest": {
+            "type": "object",
+            "required": [
+                "barcode",
+                "memberId"
+            ],
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 1,
+                    "example": "LIB-000123"
+                },
+                "memberId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "loan.HoldResponse": {
+            "type": "object",
+            "properties": {
+                "bookId": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "closedAt": {
+                    "type": "string",
+                    "example": "2026-10-20T11:00:00Z"
+                },
+                "copyId": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "memberId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pickupBy": {
+                    "type": "string",
+                    "example": "2026-10-24"
+                },
+                "placedAt": {
+                    "type": "string",
+                    "example": "2026-10-17T09:30:00Z"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "status": {
+                    "type": "string",
+                    "example": "waiting"
+                }
+            }
+        },
+        "loan.LoanResponse": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "example": "LIB-000123"
+                },
+                "bookId": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "copyId": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "dueOn": {
+                    "type": "string",
+                    "example": "2026-11-07"
+                },
+                "fineCents": {
+                    "type": "integer",
+                    "example": 75
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "loanedAt": {
+                    "type": "string",
+                    "example": "2026-10-17T09:30:00Z"
+                },
+                "memberId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "overdue": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "renewals": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "returnedAt": {
+                    "type": "string",
+                    "example": "2026-11-01T16:05:00Z"
+                }
+            }
+        },
+        "loan.PlaceHoldRequest": {
+            "type": "object",
+            "required": [
+                "memberId"
+            ],
+            "properties": {
+                "memberId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "marc.ImportReportResponse": {
+            "type": "object",
+            "properties": {
+                "droppedFields": {
+                    "description": "DroppedFields counts the records each field or subfield was dropped\nfrom, e.g. {\"650\": 3, \"245$c\": 2}.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "dryRun": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "imported": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "records": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/marc.ImportResultResponse"
+                    }
+                }
+            }
+        },
+        "marc.ImportResultResponse": {
+            "type": "object",
+            "properties": {
+                "dropped": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "$ref": "#/definitions/book.ErrorResponse"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Dune"
+                }
+            }
+        },
+        "member.CreateOrUpdateMemberRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "firstName",
+                "lastName"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "12 Grimmauld Place, London"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "hermione@example.com"
+                },
+                "expiresOn": {
+                    "type": "string",
+                    "example": "2027-09-01"
+                },
+                "firstName": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Hermione"
+                },
+                "lastName": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Granger"
+                },
+                "membershipType": {
+                    "type": "string",
+                    "enum": [
+                        "standard",
+                        "student",
+                        "senior",
+                        "staff"
+                    ],
+                    "example": "student"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "+44 20 7946 0958"
+                }
+            }
+        },
+        "member.MemberResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "12 Grimmauld Place, London"
+                },
+                "cardNumber": {
+                    "type": "string",
+                    "example": "29000012345674"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "hermione@example.com"
+                },
+                "expiresOn": {
+                    "type": "string",
+                    "example": "2027-09-01"
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "Hermione"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Granger"
+                },
+                "memberSince": {
+                    "type": "string",
+                    "example": "2026-09-01"
+                },
+                "membershipType": {
+                    "type": "string",
+                    "example": "student"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+44 20 7946 0958"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "suspendedAt": {
+                    "type": "string",
+                    "example": "2026-10-01T09:30:00Z"
+                },
+                "suspensionReason": {
+                    "type": "string",
+                    "example": "Repeatedly damaged borrowed books"
+                }
+            }
+        },
+        "member.PaginatedMemberListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/member.MemberResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "page": {
                     "type": "integer",
                     "example": 1
                 },
@@ -340,6 +4238,20 @@ const docTemplate = `{
                     "example": 5
                 }
             }
+        },
+        "member.SuspendMemberRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 1,
+                    "example": "Repeatedly damaged borrowed books"
+                }
+            }
         }
     }
 }`
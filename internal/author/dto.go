@@ -0,0 +1,20 @@
+package author
+
+type CreateOrUpdateAuthorRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=255" example:"J.K. Rowling"`
+	Bio  string `json:"bio" validate:"omitempty,max=2000" example:"British author, best known for Harry Potter"`
+}
+
+type AuthorResponse struct {
+	ID   int    `json:"id" example:"1"`
+	Name string `json:"name" example:"J.K. Rowling"`
+	Bio  string `json:"bio" example:"British author, best known for Harry Potter"`
+}
+
+type PaginatedAuthorListResponse struct {
+	Page       int              `json:"page" example:"1"`
+	Limit      int              `json:"limit" example:"10"`
+	Total      int              `json:"total" example:"42"`
+	TotalPages int              `json:"totalPages" example:"5"`
+	Data       []AuthorResponse `json:"data"`
+}
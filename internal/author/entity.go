@@ -0,0 +1,7 @@
+package author
+
+type Author struct {
+	ID   int    `sql:"id"`
+	Name string `sql:"name"`
+	Bio  string `sql:"bio"`
+}
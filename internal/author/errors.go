@@ -0,0 +1,33 @@
+package author
+
+import (
+	"book-store/internal/book"
+	"net/http"
+)
+
+const (
+	AuthorNotFound book.ErrorCode = "AUTHOR_NOT_FOUND"
+	AuthorInUse    book.ErrorCode = "AUTHOR_IN_USE"
+)
+
+var errorResponseMap = map[book.ErrorCode]*book.ErrorResponse{
+	AuthorNotFound: {
+		HttpStatusCode: http.StatusNotFound,
+		ErrorCode:      AuthorNotFound,
+		ErrorMessage:   "author not found",
+	},
+	AuthorInUse: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      AuthorInUse,
+		ErrorMessage:   "author is still linked to one or more books",
+	},
+}
+
+// GetErrorResponseByCode resolves author specific codes and falls back to the
+// codes shared with the book package.
+func GetErrorResponseByCode(errCode book.ErrorCode) *book.ErrorResponse {
+	if errResponse, ok := errorResponseMap[errCode]; ok {
+		return errResponse
+	}
+	return book.GetErrorResponseByCode(errCode)
+}
@@ -0,0 +1,216 @@
+package author
+
+import (
+	"book-store/internal/book"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+var maxLimit int = 100
+
+type AuthorHandler struct {
+	svc AuthorService
+	val validator.Validate
+}
+
+func NewAuthorHandler(s AuthorService) *AuthorHandler {
+	return &AuthorHandler{svc: s, val: *validator.New()}
+}
+
+// List godoc
+// @Summary      List authors with pagination
+// @Description  Returns a paginated list of authors, optionally filtered by name
+// @Tags         authors
+// @Accept       json
+// @Produce      json
+// @Param        name   query     string  false  "Case-insensitive part of the author name"
+// @Param        page   query     int     false  "Page number (default 1)"    default(1)
+// @Param        limit  query     int     false  "Page size (1–100, default 10)" default(10)
+// @Success      200    {object}  PaginatedAuthorListResponse
+// @Failure      400    {object}  book.ErrorResponse
+// @Router       /authors [get]
+func (h *AuthorHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, limit := 1, 10
+	if v := q.Get("page"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil {
+			logrus.Error("invalid page number provided ", v)
+			sendError(w, *GetErrorResponseByCode(book.BadRequest))
+			return
+		}
+		page = max(p, 1)
+	}
+	if v := q.Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil {
+			logrus.Error("invalid limit number provided ", v)
+			sendError(w, *GetErrorResponseByCode(book.BadRequest))
+			return
+		}
+		if l >= 1 {
+			limit = min(l, maxLimit)
+		}
+	}
+	offset := (page - 1) * limit
+
+	authors, totalCount, err := h.svc.List(r.Context(), strings.TrimSpace(q.Get("name")), limit, offset)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	out := make([]AuthorResponse, len(authors))
+	for i, a := range authors {
+		out[i] = toAuthorResponse(a)
+	}
+	json.NewEncoder(w).Encode(PaginatedAuthorListResponse{
+		Page:       page,
+		Limit:      limit,
+		Total:      totalCount,
+		TotalPages: int(math.Ceil(float64(totalCount) / float64(limit))),
+		Data:       out,
+	})
+}
+
+// Get godoc
+// @Summary      Get author by ID
+// @Description  Retrieve a single author by its ID
+// @Tags         authors
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int   true   "Author ID"
+// @Success      200    {object}  AuthorResponse
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Router       /authors/{id} [get]
+func (h *AuthorHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, convErr := strconv.Atoi(mux.Vars(r)["id"])
+	if convErr != nil {
+		logrus.Error("invalid author id provided ", mux.Vars(r)["id"])
+		sendError(w, *GetErrorResponseByCode(book.BadRequest))
+		return
+	}
+	a, err := h.svc.Get(r.Context(), id)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	json.NewEncoder(w).Encode(toAuthorResponse(a))
+}
+
+// Create godoc
+// @Summary      Create a new author
+// @Description  Add a new author record
+// @Tags         authors
+// @Accept       json
+// @Produce      json
+// @Param        author  body      CreateOrUpdateAuthorRequest  true  "Author data"
+// @Success      201    {object}  nil
+// @Header       201    {string}  Location  "URL of created author"
+// @Failure      400    {object}  book.ErrorResponse
+// @Router       /authors [post]
+func (h *AuthorHandler) Create(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.decode(w, r)
+	if !ok {
+		return
+	}
+	aId, err := h.svc.Create(r.Context(), req)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.Header().Set("location", fmt.Sprintf("%s/%d", "/authors", aId))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// Update godoc
+// @Summary      Update author by ID
+// @Description  Replace the name and bio of an existing author. Books whose byline is made of linked author names get the new name too
+// @Tags         authors
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                          true  "Author ID"
+// @Param        author  body      CreateOrUpdateAuthorRequest  true  "Author data"
+// @Success      204    {object}  nil
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Router       /authors/{id} [put]
+func (h *AuthorHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, convErr := strconv.Atoi(mux.Vars(r)["id"])
+	if convErr != nil {
+		logrus.Error("invalid author id provided ", mux.Vars(r)["id"])
+		sendError(w, *GetErrorResponseByCode(book.BadRequest))
+		return
+	}
+	req, ok := h.decode(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.Update(r.Context(), id, req); err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Delete godoc
+// @Summary      Delete author by ID
+// @Description  Remove an author record. Authors still linked to books cannot be deleted
+// @Tags         authors
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int   true   "Author ID"
+// @Success      204    {object}  nil
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Failure      409    {object}  book.ErrorResponse
+// @Router       /authors/{id} [delete]
+func (h *AuthorHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, convErr := strconv.Atoi(mux.Vars(r)["id"])
+	if convErr != nil {
+		logrus.Error("invalid author id provided ", mux.Vars(r)["id"])
+		sendError(w, *GetErrorResponseByCode(book.BadRequest))
+		return
+	}
+	if err := h.svc.Delete(r.Context(), id); err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AuthorHandler) decode(w http.ResponseWriter, r *http.Request) (CreateOrUpdateAuthorRequest, bool) {
+	var req CreateOrUpdateAuthorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, *GetErrorResponseByCode(book.BadRequest))
+		return req, false
+	}
+	if err := h.val.Struct(&req); err != nil {
+		var errs []string
+		for _, fe := range err.(validator.ValidationErrors) {
+			errs = append(errs, fmt.Sprintf("%s failed on '%s'", fe.Field(), fe.Tag()))
+		}
+		logrus.Error("error while validating the request. error is ", errs)
+		sendError(w, *book.GetErrorResponse(book.BadRequest, strings.Join(errs, "; "), http.StatusBadRequest))
+		return req, false
+	}
+	return req, true
+}
+
+func toAuthorResponse(a Author) AuthorResponse {
+	return AuthorResponse{ID: a.ID, Name: a.Name, Bio: a.Bio}
+}
+
+func sendError(w http.ResponseWriter, errResponse book.ErrorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errResponse.HttpStatusCode)
+	json.NewEncoder(w).Encode(errResponse)
+}
@@ -0,0 +1,101 @@
+package author_test
+
+import (
+	"book-store/internal/author"
+	"book-store/internal/book"
+	mock_book "book-store/internal/mocks"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthorHandlerTestSuite struct {
+	suite.Suite
+	authorHandler *author.AuthorHandler
+	mockService   *mock_book.MockAuthorService
+	ctrl          *gomock.Controller
+}
+
+func TestAuthorHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthorHandlerTestSuite))
+}
+
+func (m *AuthorHandlerTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockService = mock_book.NewMockAuthorService(m.ctrl)
+	m.authorHandler = author.NewAuthorHandler(m.mockService)
+}
+
+func (m *AuthorHandlerTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func (m *AuthorHandlerTestSuite) TestList_ShouldDefaultPaginationAndPassNameFilter() {
+	r, _ := http.NewRequest("GET", "/authors?name=rowling", nil)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().List(r.Context(), "rowling", 10, 0).
+		Return([]author.Author{{ID: 3, Name: "J.K. Rowling"}}, 1, nil)
+
+	m.authorHandler.List(w, r)
+	m.Suite.Equal(200, w.Result().StatusCode)
+
+	var body author.PaginatedAuthorListResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal(author.PaginatedAuthorListResponse{
+		Page: 1, Limit: 10, Total: 1, TotalPages: 1,
+		Data: []author.AuthorResponse{{ID: 3, Name: "J.K. Rowling"}},
+	}, body)
+}
+
+func (m *AuthorHandlerTestSuite) TestCreate() {
+	req := author.CreateOrUpdateAuthorRequest{Name: "J.K. Rowling"}
+	requestBytes, _ := json.Marshal(req)
+	r, _ := http.NewRequest("POST", "/authors", bytes.NewReader(requestBytes))
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Create(r.Context(), req).Return(int64(3), nil)
+
+	m.authorHandler.Create(w, r)
+	m.Suite.Equal(201, w.Result().StatusCode)
+	m.Suite.Equal("/authors/3", w.Result().Header.Get("Location"))
+}
+
+func (m *AuthorHandlerTestSuite) TestCreate_ShouldReturnBadRequestWhenNameIsMissing() {
+	r, _ := http.NewRequest("POST", "/authors", bytes.NewReader([]byte(`{"bio": "no name"}`)))
+	w := httptest.NewRecorder()
+
+	m.authorHandler.Create(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+
+	var actualErr book.ErrorResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&actualErr))
+	m.Suite.Equal("Name failed on 'required'", actualErr.Error())
+}
+
+func (m *AuthorHandlerTestSuite) TestGet_ShouldReturnNotFoundWhenServiceReturnsNotFound() {
+	r, _ := http.NewRequest("GET", "/authors/3", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "3"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Get(r.Context(), 3).Return(author.Author{}, author.GetErrorResponseByCode(author.AuthorNotFound))
+
+	m.authorHandler.Get(w, r)
+	m.Suite.Equal(404, w.Result().StatusCode)
+
+	var actualErr book.ErrorResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&actualErr))
+	m.Suite.Equal(author.AuthorNotFound, actualErr.ErrorCode)
+}
+
+func (m *AuthorHandlerTestSuite) TestDelete_ShouldThrowErrorWhenAuthorIdIsInvalid() {
+	r, _ := http.NewRequest("DELETE", "/authors/abc", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "abc"})
+	w := httptest.NewRecorder()
+
+	m.authorHandler.Delete(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+}
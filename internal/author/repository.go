@@ -0,0 +1,126 @@
+package author
+
+import (
+	"book-store/internal/book"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrNotFound = errors.New("author not found")
+	ErrInUse    = errors.New("author is linked to books")
+)
+
+const foreignKeyViolation = "23503"
+
+type AuthorRepository interface {
+	Create(ctx context.Context, a Author) (int64, error)
+	GetByID(ctx context.Context, id int) (Author, error)
+	List(ctx context.Context, name string, limit, offset int) ([]Author, int, error)
+	Update(ctx context.Context, a Author) error
+	Delete(ctx context.Context, id int) error
+}
+
+type sqlAuthorRepo struct {
+	db *sql.DB
+}
+
+func NewAuthorRepository(db *sql.DB) AuthorRepository {
+	return &sqlAuthorRepo{db: db}
+}
+
+func (r *sqlAuthorRepo) Create(ctx context.Context, a Author) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO authors (name, bio) VALUES ($1, $2) RETURNING id`,
+		a.Name, a.Bio).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *sqlAuthorRepo) GetByID(ctx context.Context, id int) (Author, error) {
+	a := Author{}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, name, bio FROM authors WHERE id = $1`, id).
+		Scan(&a.ID, &a.Name, &a.Bio)
+	if err == sql.ErrNoRows {
+		return Author{}, ErrNotFound
+	}
+	return a, err
+}
+
+// List pages through authors ordered by id. A non-empty name restricts the
+// result to authors whose name contains it, ignoring case.
+func (r *sqlAuthorRepo) List(ctx context.Context, name string, limit, offset int) ([]Author, int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, name, bio,
+               COUNT(*) OVER() AS total_count
+        FROM authors
+        WHERE $1 = '' OR name ILIKE '%' || $1 || '%'
+        ORDER BY id
+        LIMIT $2 OFFSET $3`, name, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	var authors []Author
+	var total int
+	for rows.Next() {
+		a := Author{}
+		if err := rows.Scan(&a.ID, &a.Name, &a.Bio, &total); err != nil {
+			return nil, 0, err
+		}
+		authors = append(authors, a)
+	}
+	return authors, total, rows.Err()
+}
+
+// Update renames the author on the bylines made of its name as well.
+func (r *sqlAuthorRepo) Update(ctx context.Context, a Author) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	res, err := tx.ExecContext(ctx,
+		`UPDATE authors SET name=$1, bio=$2 WHERE id=$3`,
+		a.Name, a.Bio, a.ID)
+	if err != nil {
+		return err
+	}
+	if err := expectOneRow(res); err != nil {
+		return err
+	}
+	if err := book.RefreshBylines(ctx, tx, a.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *sqlAuthorRepo) Delete(ctx context.Context, id int) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM authors WHERE id=$1`, id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return ErrInUse
+		}
+		return err
+	}
+	return expectOneRow(res)
+}
+
+func expectOneRow(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
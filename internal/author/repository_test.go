@@ -0,0 +1,96 @@
+package author_test
+
+import (
+	"book-store/internal/author"
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthorRepositoryTestSuite struct {
+	suite.Suite
+	authorRepository author.AuthorRepository
+	sqlMock          sqlmock.Sqlmock
+	db               *sql.DB
+}
+
+func TestAuthorRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthorRepositoryTestSuite))
+}
+
+func (m *AuthorRepositoryTestSuite) SetupTest() {
+	m.db, m.sqlMock, _ = sqlmock.New()
+	m.authorRepository = author.NewAuthorRepository(m.db)
+}
+
+func (m *AuthorRepositoryTestSuite) TestCreate_ShouldInsertAuthorInDatabase() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO authors (name, bio) VALUES ($1, $2) RETURNING id")).
+		WithArgs("J.K. Rowling", "British author").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	id, err := m.authorRepository.Create(context.Background(), author.Author{Name: "J.K. Rowling", Bio: "British author"})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(3), id)
+}
+
+func (m *AuthorRepositoryTestSuite) TestGetById_ShouldReturnNotFoundErrorIfNoAuthorPresentForGivenId() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, bio FROM authors WHERE id = $1")).WithArgs(3).
+		WillReturnError(sql.ErrNoRows)
+	_, err := m.authorRepository.GetByID(context.Background(), 3)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(author.ErrNotFound, err)
+}
+
+func (m *AuthorRepositoryTestSuite) TestList_ShouldFilterByName() {
+	m.sqlMock.ExpectQuery("FROM authors").WithArgs("rowling", 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "bio", "total_count"}).
+			AddRow(3, "J.K. Rowling", "", 1))
+	authors, total, err := m.authorRepository.List(context.Background(), "rowling", 10, 0)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(1, total)
+	m.Suite.Equal([]author.Author{{ID: 3, Name: "J.K. Rowling"}}, authors)
+}
+
+func (m *AuthorRepositoryTestSuite) TestUpdate_ShouldRenameTheAuthorOnDerivedBylines() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE authors SET name=$1, bio=$2 WHERE id=$3")).WithArgs("J.K. Rowling", "", 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("WHERE b.byline_derived AND EXISTS (SELECT 1 FROM book_authors ba WHERE ba.book_id = b.id AND ba.author_id = $1)")).
+		WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 2))
+	m.sqlMock.ExpectCommit()
+	err := m.authorRepository.Update(context.Background(), author.Author{ID: 3, Name: "J.K. Rowling"})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+}
+
+func (m *AuthorRepositoryTestSuite) TestUpdate_ShouldReturnNotFoundWhenNoRowIsUpdated() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec("UPDATE authors").WithArgs("J.K. Rowling", "", 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectRollback()
+	err := m.authorRepository.Update(context.Background(), author.Author{ID: 3, Name: "J.K. Rowling"})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(author.ErrNotFound, err)
+}
+
+func (m *AuthorRepositoryTestSuite) TestDelete_ShouldReturnInUseWhenAuthorIsLinkedToBooks() {
+	m.sqlMock.ExpectExec("DELETE FROM authors").WithArgs(3).
+		WillReturnError(&pq.Error{Code: "23503", Constraint: "book_authors_author_id_fkey"})
+	err := m.authorRepository.Delete(context.Background(), 3)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(author.ErrInUse, err)
+}
+
+func (m *AuthorRepositoryTestSuite) TestDelete_ShouldReturnErrorWhenQueryFails() {
+	m.sqlMock.ExpectExec("DELETE FROM authors").WillReturnError(errors.New("unable to connect"))
+	err := m.authorRepository.Delete(context.Background(), 3)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.EqualError(err, "unable to connect")
+}
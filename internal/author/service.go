@@ -0,0 +1,85 @@
+package author
+
+import (
+	"book-store/internal/book"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type AuthorService interface {
+	Create(ctx context.Context, req CreateOrUpdateAuthorRequest) (int64, *book.ErrorResponse)
+	Get(ctx context.Context, id int) (Author, *book.ErrorResponse)
+	List(ctx context.Context, name string, limit, offset int) ([]Author, int, *book.ErrorResponse)
+	Update(ctx context.Context, id int, req CreateOrUpdateAuthorRequest) *book.ErrorResponse
+	Delete(ctx context.Context, id int) *book.ErrorResponse
+}
+
+type authorService struct {
+	repository AuthorRepository
+}
+
+func NewAuthorService(r AuthorRepository) AuthorService {
+	return &authorService{repository: r}
+}
+
+func (s *authorService) Create(ctx context.Context, req CreateOrUpdateAuthorRequest) (int64, *book.ErrorResponse) {
+	id, err := s.repository.Create(ctx, Author{Name: req.Name, Bio: req.Bio})
+	if err != nil {
+		logrus.Error("error while creating author. error is ", err)
+		return 0, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return id, nil
+}
+
+func (s *authorService) Get(ctx context.Context, id int) (Author, *book.ErrorResponse) {
+	a, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		if err == ErrNotFound {
+			logrus.Error("no author found for given id ", id)
+			return Author{}, GetErrorResponseByCode(AuthorNotFound)
+		}
+		logrus.Error("error while fetching the author for id ", id, " error is ", err)
+		return Author{}, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return a, nil
+}
+
+func (s *authorService) List(ctx context.Context, name string, limit, offset int) ([]Author, int, *book.ErrorResponse) {
+	authors, totalCount, err := s.repository.List(ctx, name, limit, offset)
+	if err != nil {
+		logrus.Error("error while fetching the authors. error is ", err)
+		return nil, 0, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return authors, totalCount, nil
+}
+
+func (s *authorService) Update(ctx context.Context, id int, req CreateOrUpdateAuthorRequest) *book.ErrorResponse {
+	err := s.repository.Update(ctx, Author{ID: id, Name: req.Name, Bio: req.Bio})
+	if err != nil {
+		if err == ErrNotFound {
+			logrus.Error("no author found for given id ", id)
+			return GetErrorResponseByCode(AuthorNotFound)
+		}
+		logrus.Error("error while updating the author. error is ", err)
+		return GetErrorResponseByCode(book.InternalServerError)
+	}
+	return nil
+}
+
+func (s *authorService) Delete(ctx context.Context, id int) *book.ErrorResponse {
+	err := s.repository.Delete(ctx, id)
+	if err != nil {
+		switch err {
+		case ErrNotFound:
+			logrus.Error("no author found for given id ", id)
+			return GetErrorResponseByCode(AuthorNotFound)
+		case ErrInUse:
+			logrus.Error("author ", id, " is still linked to books")
+			return GetErrorResponseByCode(AuthorInUse)
+		}
+		logrus.Error("error while deleting the author. error is ", err)
+		return GetErrorResponseByCode(book.InternalServerError)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package author_test
+
+import (
+	"book-store/internal/author"
+	"book-store/internal/book"
+	mock_book "book-store/internal/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthorServiceTestSuite struct {
+	suite.Suite
+	authorService author.AuthorService
+	mockRepo      *mock_book.MockAuthorRepository
+	ctrl          *gomock.Controller
+}
+
+func TestAuthorServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthorServiceTestSuite))
+}
+
+func (m *AuthorServiceTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockRepo = mock_book.NewMockAuthorRepository(m.ctrl)
+	m.authorService = author.NewAuthorService(m.mockRepo)
+}
+
+func (m *AuthorServiceTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func (m *AuthorServiceTestSuite) TestCreate() {
+	m.mockRepo.EXPECT().Create(context.Background(), author.Author{Name: "J.K. Rowling"}).Return(int64(3), nil)
+	id, err := m.authorService.Create(context.Background(), author.CreateOrUpdateAuthorRequest{Name: "J.K. Rowling"})
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(3), id)
+}
+
+func (m *AuthorServiceTestSuite) TestGet_ShouldReturnNotFoundIfAuthorWithGivenIDDoesNotExist() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 3).Return(author.Author{}, author.ErrNotFound)
+	_, err := m.authorService.Get(context.Background(), 3)
+	m.Suite.Equal(author.GetErrorResponseByCode(author.AuthorNotFound), err)
+}
+
+func (m *AuthorServiceTestSuite) TestList_ShouldReturnInternalServerErrorIfRepositoryFails() {
+	m.mockRepo.EXPECT().List(context.Background(), "", 10, 0).Return(nil, 0, errors.New("unable to connect"))
+	_, _, err := m.authorService.List(context.Background(), "", 10, 0)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.InternalServerError), err)
+}
+
+func (m *AuthorServiceTestSuite) TestUpdate_ShouldReturnNotFoundIfAuthorWithGivenIDDoesNotExist() {
+	m.mockRepo.EXPECT().Update(context.Background(), author.Author{ID: 3, Name: "J.K. Rowling"}).Return(author.ErrNotFound)
+	err := m.authorService.Update(context.Background(), 3, author.CreateOrUpdateAuthorRequest{Name: "J.K. Rowling"})
+	m.Suite.Equal(author.GetErrorResponseByCode(author.AuthorNotFound), err)
+}
+
+func (m *AuthorServiceTestSuite) TestDelete_ShouldReturnConflictWhenAuthorIsLinkedToBooks() {
+	m.mockRepo.EXPECT().Delete(context.Background(), 3).Return(author.ErrInUse)
+	err := m.authorService.Delete(context.Background(), 3)
+	m.Suite.Equal(author.GetErrorResponseByCode(author.AuthorInUse), err)
+	m.Suite.Equal(409, err.HttpStatusCode)
+}
@@ -0,0 +1,157 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	BatchCreate = "create"
+	BatchUpdate = "update"
+	BatchDelete = "delete"
+
+	BatchModeAtomic     = "atomic"
+	BatchModeBestEffort = "bestEffort"
+
+	maxBatchSize = 500
+)
+
+// errBatchFailed rolls back an atomic batch once one of its writes failed.
+var errBatchFailed = errors.New("batch write failed")
+
+// BatchOperation is one item of a batch as the handler read it. Rejected is
+// set when the handler refused the item, which then is not run.
+type BatchOperation struct {
+	Action   string
+	ID       int
+	IfMatch  *Precondition
+	Book     CreateOrUpdateBookRequest
+	Rejected *ErrorResponse
+}
+
+// BatchResult reports what became of one item of a batch. ID and Version
+// are set for the items that were applied, Err for the others.
+type BatchResult struct {
+	Status  int
+	ID      int
+	Version int
+	Err     *ErrorResponse
+}
+
+// BatchWrite is a checked batch item ready for the repository. Updates and
+// deletes carry the version they were checked against in Book.Version.
+type BatchWrite struct {
+	Action string
+	Book   Book
+}
+
+type BatchOutcome struct {
+	ID      int
+	Version int
+	Err     error
+}
+
+// Batch checks every operation the way the single-book endpoints would and
+// applies the ones that pass. An atomic batch is applied only when all of
+// them pass and succeed; otherwise the rest are reported as aborted.
+func (s *bookService) Batch(ctx context.Context, ops []BatchOperation, atomic bool) []BatchResult {
+	results := make([]BatchResult, len(ops))
+	writes := make([]BatchWrite, 0, len(ops))
+	index := make([]int, 0, len(ops))
+	failed := false
+	for i, op := range ops {
+		w, errResp := s.prepareBatchWrite(ctx, op)
+		if errResp != nil {
+			results[i] = BatchResult{Status: errResp.HttpStatusCode, Err: errResp}
+			failed = true
+			continue
+		}
+		writes = append(writes, w)
+		index = append(index, i)
+	}
+	if atomic && failed {
+		return abortBatch(results)
+	}
+	outcomes, err := s.repository.ApplyBatch(ctx, writes, atomic)
+	if err != nil {
+		logrus.Error("error while applying a batch of ", len(writes), " writes. error is ", err)
+		for _, i := range index {
+			results[i] = BatchResult{Status: http.StatusInternalServerError, Err: GetErrorResponseByCode(InternalServerError)}
+		}
+		return results
+	}
+	for j, o := range outcomes {
+		i := index[j]
+		if o.Err == nil {
+			results[i] = BatchResult{Status: batchStatus(writes[j].Action), ID: o.ID, Version: o.Version}
+			continue
+		}
+		failed = true
+		errResp := writeErrorResponse(o.Err)
+		if errResp == nil {
+			logrus.Error("error while applying batch item ", i, ". error is ", o.Err)
+			errResp = GetErrorResponseByCode(InternalServerError)
+		}
+		results[i] = BatchResult{Status: errResp.HttpStatusCode, Err: errResp}
+	}
+	if atomic && failed {
+		return abortBatch(results)
+	}
+	return results
+}
+
+// prepareBatchWrite turns an operation into a write, checking the
+// precondition of updates and deletes against the stored book.
+func (s *bookService) prepareBatchWrite(ctx context.Context, op BatchOperation) (BatchWrite, *ErrorResponse) {
+	if op.Rejected != nil {
+		return BatchWrite{}, op.Rejected
+	}
+	if op.Action == BatchCreate {
+		var b Book
+		if errResp := applyRequest(&b, op.Book); errResp != nil {
+			return BatchWrite{}, errResp
+		}
+		return BatchWrite{Action: BatchCreate, Book: b}, nil
+	}
+	b, errResp := s.Get(ctx, op.ID)
+	if errResp != nil {
+		return BatchWrite{}, errResp
+	}
+	if errResp := checkPrecondition(op.ID, b.Version, op.IfMatch); errResp != nil {
+		return BatchWrite{}, errResp
+	}
+	if op.Action == BatchDelete {
+		return BatchWrite{Action: BatchDelete, Book: Book{ID: b.ID, Version: b.Version}}, nil
+	}
+	if errResp := applyRequest(&b, op.Book); errResp != nil {
+		return BatchWrite{}, errResp
+	}
+	if b.Authors == nil {
+		b.Authors = []BookAuthor{}
+	}
+	return BatchWrite{Action: BatchUpdate, Book: b}, nil
+}
+
+// abortBatch marks every item of a failed atomic batch that did not fail
+// itself as aborted, since none of them was kept.
+func abortBatch(results []BatchResult) []BatchResult {
+	for i, r := range results {
+		if r.Err == nil {
+			results[i] = BatchResult{Status: http.StatusFailedDependency, Err: GetErrorResponseByCode(BatchAborted)}
+		}
+	}
+	return results
+}
+
+func batchStatus(action string) int {
+	switch action {
+	case BatchCreate:
+		return http.StatusCreated
+	case BatchDelete:
+		return http.StatusNoContent
+	}
+	return http.StatusOK
+}
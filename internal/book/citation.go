@@ -0,0 +1,312 @@
+package book
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	CitationBibTeX  = "bibtex"
+	CitationRIS     = "ris"
+	CitationCSLJSON = "csl-json"
+)
+
+// citationTypes maps every citation format to its media type.
+var citationTypes = map[string]string{
+	CitationBibTeX:  "application/x-bibtex",
+	CitationRIS:     "application/x-research-info-systems",
+	CitationCSLJSON: "application/vnd.citationstyles.csl+json",
+}
+
+// citationExtensions names the file extension of every citation format.
+var citationExtensions = map[string]string{
+	CitationBibTeX:  "bib",
+	CitationRIS:     "ris",
+	CitationCSLJSON: "json",
+}
+
+// keyStopWords are the leading articles CitationKey skips in a title.
+var keyStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "der": true, "die": true, "das": true, "ein": true, "eine": true,
+	"le": true, "la": true, "les": true, "l": true, "un": true, "une": true, "el": true, "los": true, "las": true,
+	"il": true, "lo": true, "gli": true, "de": true, "het": true, "een": true,
+}
+
+// asciiFolds spells the accented Latin letters in ASCII for citation keys.
+var asciiFolds = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "æ", "ae", "ç", "c", "č", "c", "ć", "c",
+	"è", "e", "é", "e", "ê", "e", "ë", "e", "ě", "e", "ì", "i", "í", "i", "î", "i", "ï", "i", "ð", "d",
+	"đ", "d", "ł", "l", "ñ", "n", "ń", "n", "ň", "n", "ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ø", "o", "œ", "oe", "ř", "r", "š", "s", "ś", "s", "ß", "ss", "þ", "th", "ù", "u", "ú", "u", "û", "u",
+	"ü", "u", "ů", "u", "ý", "y", "ÿ", "y", "ž", "z", "ź", "z", "ż", "z",
+)
+
+// bibtexEscapes escapes the characters BibTeX gives a meaning to. A single
+// pass keeps the braces of the replacements from being escaped again.
+var bibtexEscapes = strings.NewReplacer(
+	`\`, `\textbackslash{}`, "{", `\{`, "}", `\}`, "&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`,
+	"~", `\textasciitilde{}`, "^", `\textasciicircum{}`, "\r\n", " ", "\n", " ", "\r", " ",
+)
+
+// risLineBreaks flattens a value onto its RIS line, which a line break
+// would end early.
+var risLineBreaks = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// CitationKey derives the citation key of a book from the family name of its
+// first author, its year, the first word of its title that is not an
+// article and its id, e.g. herbert1965dune-12. The id tells apart books that
+// would otherwise share a key, whatever else is exported with them. A book
+// without an author is keyed as anon, and one whose title has no such word
+// by its id alone, e.g. book12.
+func CitationKey(b Book) string {
+	family := ""
+	if names := citationNames(b, RoleAuthor); len(names) > 0 {
+		family, _ = splitName(names[0])
+	}
+	word := ""
+	for _, w := range strings.FieldsFunc(keyText(b.Title), func(r rune) bool { return r == ' ' }) {
+		if !keyStopWords[w] {
+			word = w
+			break
+		}
+	}
+	if word == "" {
+		return "book" + strconv.Itoa(b.ID)
+	}
+	key := strings.ReplaceAll(keyText(family), " ", "")
+	if key == "" {
+		key = "anon"
+	}
+	if b.PublishedYear != 0 {
+		key += strconv.Itoa(b.PublishedYear)
+	}
+	return key + word + "-" + strconv.Itoa(b.ID)
+}
+
+// keyText lowercases s and spells it in ASCII letters and digits, words
+// separated by single spaces.
+func keyText(s string) string {
+	s = asciiFolds.Replace(strings.ToLower(s))
+	var sb strings.Builder
+	word := 0
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			sb.WriteRune(r)
+			word++
+		case r == '\'' || r == '’':
+			// l'étranger keys as l etranger, o'brien as obrien.
+			if word == 1 {
+				sb.WriteByte(' ')
+				word = 0
+			}
+		default:
+			sb.WriteByte(' ')
+			word = 0
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// citationNames returns the names of the linked authors with the role in
+// credit order. Authors fall back to the byline when none are linked.
+func citationNames(b Book, role string) []string {
+	var names []string
+	for _, a := range b.Authors {
+		if a.Role == role {
+			names = append(names, a.Name)
+		}
+	}
+	if len(names) == 0 && role == RoleAuthor {
+		names = bylineNames(b.Author)
+	}
+	return names
+}
+
+// bylineNames splits a byline such as "Terry Pratchett & Neil Gaiman" into
+// names.
+func bylineNames(byline string) []string {
+	var names []string
+	for _, part := range strings.FieldsFunc(strings.NewReplacer(" and ", ";", " & ", ";").Replace(byline), func(r rune) bool { return r == ';' }) {
+		if part = strings.TrimSpace(part); part != "" {
+			names = append(names, part)
+		}
+	}
+	return names
+}
+
+// splitName splits a name into family and given names. Names are stored in
+// direct order, so the last word is taken as the family name unless the
+// name is inverted, as in "Herbert, Frank".
+func splitName(name string) (family, given string) {
+	name = strings.TrimSpace(name)
+	if f, g, ok := strings.Cut(name, ","); ok {
+		return strings.TrimSpace(f), strings.TrimSpace(g)
+	}
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		return name[i+1:], strings.TrimSpace(name[:i])
+	}
+	return name, ""
+}
+
+// WriteCitations writes the books to w in the citation format, which must
+// be one of CitationBibTeX, CitationRIS and CitationCSLJSON.
+func WriteCitations(w io.Writer, format string, books []Book) error {
+	switch format {
+	case CitationBibTeX:
+		return writeBibTeX(w, books)
+	case CitationRIS:
+		return writeRIS(w, books)
+	case CitationCSLJSON:
+		return writeCSLJSON(w, books)
+	}
+	return fmt.Errorf("unknown citation format %q", format)
+}
+
+func writeBibTeX(w io.Writer, books []Book) error {
+	var sb strings.Builder
+	for i, b := range books {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "@book{%s,\n", CitationKey(b))
+		field := func(name, value string) {
+			if value != "" {
+				fmt.Fprintf(&sb, "  %s = {%s},\n", name, value)
+			}
+		}
+		field("author", bibtexNames(citationNames(b, RoleAuthor)))
+		field("editor", bibtexNames(citationNames(b, RoleEditor)))
+		field("translator", bibtexNames(citationNames(b, RoleTranslator)))
+		field("illustrator", bibtexNames(citationNames(b, RoleIllustrator)))
+		field("title", bibtexEscapes.Replace(b.Title))
+		if b.PublishedYear != 0 {
+			field("year", strconv.Itoa(b.PublishedYear))
+		}
+		field("isbn", b.ISBN)
+		field("language", bibtexEscapes.Replace(b.Language))
+		field("keywords", bibtexEscapes.Replace(b.Genre))
+		field("abstract", bibtexEscapes.Replace(b.Description))
+		sb.WriteString("}\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// bibtexNames joins names with "and" as "Family, Given". A part that holds
+// the word "and" itself is braced so BibTeX does not split the name there.
+func bibtexNames(names []string) string {
+	out := make([]string, len(names))
+	for i, name := range names {
+		family, given := splitName(name)
+		part := func(s string) string {
+			s = bibtexEscapes.Replace(s)
+			if strings.Contains(" "+strings.ToLower(s)+" ", " and ") {
+				return "{" + s + "}"
+			}
+			return s
+		}
+		out[i] = part(family)
+		if given != "" {
+			out[i] += ", " + part(given)
+		}
+	}
+	return strings.Join(out, " and ")
+}
+
+func writeRIS(w io.Writer, books []Book) error {
+	var sb strings.Builder
+	for i, b := range books {
+		if i > 0 {
+			sb.WriteString("\r\n")
+		}
+		tag := func(name, value string) {
+			if value = strings.TrimSpace(risLineBreaks.Replace(value)); value != "" {
+				fmt.Fprintf(&sb, "%s  - %s\r\n", name, value)
+			}
+		}
+		tag("TY", "BOOK")
+		tag("ID", CitationKey(b))
+		for _, role := range []struct{ tag, role string }{
+			{"AU", RoleAuthor}, {"ED", RoleEditor}, {"A4", RoleTranslator}, {"A4", RoleIllustrator},
+		} {
+			for _, name := range citationNames(b, role.role) {
+				family, given := splitName(name)
+				if given != "" {
+					family += ", " + given
+				}
+				tag(role.tag, family)
+			}
+		}
+		tag("TI", b.Title)
+		if b.PublishedYear != 0 {
+			tag("PY", strconv.Itoa(b.PublishedYear))
+		}
+		tag("SN", b.ISBN)
+		tag("LA", b.Language)
+		tag("KW", b.Genre)
+		tag("AB", b.Description)
+		sb.WriteString("ER  - \r\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+type cslItem struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	Title       string    `json:"title,omitempty"`
+	Author      []cslName `json:"author,omitempty"`
+	Editor      []cslName `json:"editor,omitempty"`
+	Translator  []cslName `json:"translator,omitempty"`
+	Illustrator []cslName `json:"illustrator,omitempty"`
+	Issued      *cslDate  `json:"issued,omitempty"`
+	ISBN        string    `json:"ISBN,omitempty"`
+	Language    string    `json:"language,omitempty"`
+	Abstract    string    `json:"abstract,omitempty"`
+}
+
+type cslName struct {
+	Family string `json:"family"`
+	Given  string `json:"given,omitempty"`
+}
+
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// writeCSLJSON writes the books as a CSL-JSON array, whatever their number.
+func writeCSLJSON(w io.Writer, books []Book) error {
+	names := func(b Book, role string) []cslName {
+		var out []cslName
+		for _, name := range citationNames(b, role) {
+			family, given := splitName(name)
+			out = append(out, cslName{Family: family, Given: given})
+		}
+		return out
+	}
+	items := make([]cslItem, len(books))
+	for i, b := range books {
+		items[i] = cslItem{
+			ID:          CitationKey(b),
+			Type:        "book",
+			Title:       b.Title,
+			Author:      names(b, RoleAuthor),
+			Editor:      names(b, RoleEditor),
+			Translator:  names(b, RoleTranslator),
+			Illustrator: names(b, RoleIllustrator),
+			ISBN:        b.ISBN,
+			Language:    b.Language,
+			Abstract:    b.Description,
+		}
+		if b.PublishedYear != 0 {
+			items[i].Issued = &cslDate{DateParts: [][]int{{b.PublishedYear}}}
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(items)
+}
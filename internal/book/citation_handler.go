@@ -0,0 +1,141 @@
+package book
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// formatJSON asks GET /books/{id} for the BookResponse rather than a citation.
+const formatJSON = "json"
+
+// Citations godoc
+// @Summary      Export citations
+// @Description  Renders the books with the given ids, in that order, as BibTeX, RIS or CSL-JSON for reference managers. The format is taken from the format parameter or else from the Accept header, and is BibTeX when neither names one. Citation keys are made of the family name of the first author, the year, the first word of the title and the book id, so a book has the same key in every export
+// @Tags         books
+// @Produce      application/x-bibtex
+// @Produce      application/x-research-info-systems
+// @Produce      application/vnd.citationstyles.csl+json
+// @Param        ids     query     string  true   "Comma separated book ids, at most 100"
+// @Param        format  query     string  false  "bibtex (default), ris or csl-json"
+// @Success      200     {string}  string
+// @Failure      400     {object}  ErrorResponse
+// @Failure      404     {object}  ErrorResponse
+// @Router       /books/citations [get]
+func (h *BookHandler) Citations(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format := q.Get("format")
+	if format == "" {
+		format = acceptedFormat(r.Header.Get("Accept"))
+		if _, ok := citationTypes[format]; !ok {
+			format = CitationBibTeX
+		}
+	}
+	if _, ok := citationTypes[format]; !ok {
+		logrus.Error("invalid citation format provided ", format)
+		sendError(w, *GetErrorResponse(BadRequest, "format must be bibtex, ris or csl-json", http.StatusBadRequest))
+		return
+	}
+	ids, errResp := parseIDs(q.Get("ids"))
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	books, errResp := h.svc.GetMany(r.Context(), ids)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="books.%s"`, citationExtensions[format]))
+	writeCitations(w, format, books)
+}
+
+// parseIDs reads a comma separated list of at most maxLimit book ids.
+func parseIDs(s string) ([]int, *ErrorResponse) {
+	fail := func(msg string) ([]int, *ErrorResponse) {
+		logrus.Error("invalid ids provided ", s)
+		return nil, GetErrorResponse(BadRequest, msg, http.StatusBadRequest)
+	}
+	if strings.TrimSpace(s) == "" {
+		return fail("ids is required")
+	}
+	parts := strings.Split(s, ",")
+	if len(parts) > maxLimit {
+		return fail(fmt.Sprintf("at most %d ids can be exported at a time", maxLimit))
+	}
+	ids := make([]int, len(parts))
+	for i, part := range parts {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || id <= 0 {
+			return fail(fmt.Sprintf("ids must be comma separated book ids, got '%s'", part))
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// bookFormat picks the representation of GET /books/{id}: the format
+// parameter when given, else the best match of the Accept header and JSON
+// failing that. ok is false for an unknown format parameter.
+func bookFormat(r *http.Request) (format string, ok bool) {
+	if format = r.URL.Query().Get("format"); format != "" {
+		_, ok = citationTypes[format]
+		return format, ok || format == formatJSON
+	}
+	if format = acceptedFormat(r.Header.Get("Accept")); format == "" {
+		format = formatJSON
+	}
+	return format, true
+}
+
+// acceptedFormat returns the format of the media range the Accept header
+// prefers most, formatJSON for JSON and wildcards, or "" when it names
+// none of them. Ties go to the range listed first.
+func acceptedFormat(accept string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		format := ""
+		switch mediaType {
+		case "application/json", "application/*", "*/*":
+			format = formatJSON
+		default:
+			for f, t := range citationTypes {
+				if t == mediaType {
+					format = f
+				}
+			}
+		}
+		if format != "" && q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+	return best
+}
+
+// writeCitations renders the citations before sending any of them, so a
+// failure can still be answered with an error.
+func writeCitations(w http.ResponseWriter, format string, books []Book) {
+	var buf bytes.Buffer
+	if err := WriteCitations(&buf, format, books); err != nil {
+		logrus.Error("error while rendering citations. error is ", err)
+		sendError(w, *GetErrorResponseByCode(InternalServerError))
+		return
+	}
+	w.Header().Set("Content-Type", citationTypes[format]+"; charset=utf-8")
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,98 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCitationKey(t *testing.T) {
+	keys := map[string]book.Book{
+		"herbert1965dune-1":         {ID: 1, Title: "Dune", Author: "Frank Herbert", PublishedYear: 1965},
+		"tolkien1954fellowship-2":   {ID: 2, Title: "The Fellowship of the Ring", Author: "J. R. R. Tolkien", PublishedYear: 1954},
+		"pratchett1990good-3":       {ID: 3, Title: "Good Omens", Author: "Terry Pratchett & Neil Gaiman", PublishedYear: 1990},
+		"garciamarquez1967cien-4":   {ID: 4, Title: "Cien años de soledad", Author: "Márquez, Gabriel García", PublishedYear: 1967, Authors: []book.BookAuthor{{Name: "García Márquez, Gabriel", Role: book.RoleAuthor}}},
+		"camus1942etranger-5":       {ID: 5, Title: "L'Étranger", Author: "Albert Camus", PublishedYear: 1942},
+		"obrien1990things-6":        {ID: 6, Title: "The Things They Carried", Author: "Tim O'Brien", PublishedYear: 1990},
+		"anonbeowulf-7":             {ID: 7, Title: "Beowulf"},
+		"book8":                     {ID: 8, Title: "The", PublishedYear: 2001},
+		"grossman2011hitchhikers-9": {ID: 9, Title: "The Hitchhiker's Guide", Author: "Douglas Adams", PublishedYear: 2011, Authors: []book.BookAuthor{{Name: "Edith Grossman", Role: book.RoleTranslator}, {Name: "Lev Grossman", Role: book.RoleAuthor}}},
+	}
+	for want, b := range keys {
+		require.Equal(t, want, book.CitationKey(b), b.Title)
+	}
+}
+
+func TestWriteCitations_ShouldKeyABookTheSameInEveryExport(t *testing.T) {
+	mort := book.Book{ID: 9, Title: "Mort", Author: "Terry Pratchett", PublishedYear: 1987}
+	for _, books := range [][]book.Book{
+		{mort},
+		{mort, {ID: 4, Title: "Mort", Author: "Terry Pratchett", PublishedYear: 1987}},
+	} {
+		var buf bytes.Buffer
+		require.NoError(t, book.WriteCitations(&buf, book.CitationCSLJSON, books))
+		var items []map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &items))
+		require.Equal(t, "pratchett1987mort-9", items[0]["id"])
+	}
+}
+
+func TestWriteCitations_BibTeX(t *testing.T) {
+	books := []book.Book{
+		{ID: 1, Title: "Rock & Roll: 100% {Greatest} Hits_#1 ~ $5^2 \\o/", Author: "Ann & Bob", PublishedYear: 1999,
+			ISBN: "9780747532699", Language: "en", Genre: "music", Description: "Line one\nline two",
+			Authors: []book.BookAuthor{
+				{Name: "Anne Brontë", Role: book.RoleAuthor},
+				{Name: "Barnes and Noble", Role: book.RoleAuthor},
+				{Name: "Ed Itor", Role: book.RoleEditor},
+			}},
+		{ID: 2, Title: "Plain"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, book.WriteCitations(&buf, book.CitationBibTeX, books))
+	require.Equal(t, `@book{bronte1999rock-1,
+  author = {Brontë, Anne and Noble, {Barnes and}},
+  editor = {Itor, Ed},
+  title = {Rock \& Roll: 100\% \{Greatest\} Hits\_\#1 \textasciitilde{} \$5\textasciicircum{}2 \textbackslash{}o/},
+  year = {1999},
+  isbn = {9780747532699},
+  language = {en},
+  keywords = {music},
+  abstract = {Line one line two},
+}
+
+@book{anonplain-2,
+  title = {Plain},
+}
+`, buf.String())
+}
+
+func TestWriteCitations_RIS(t *testing.T) {
+	books := []book.Book{{ID: 1, Title: "Dune\nMessiah", Author: "Frank Herbert", PublishedYear: 1969, ISBN: "9780441172696",
+		Authors: []book.BookAuthor{{Name: "Frank Herbert", Role: book.RoleAuthor}, {Name: "Jane Doe", Role: book.RoleTranslator}}}}
+	var buf bytes.Buffer
+	require.NoError(t, book.WriteCitations(&buf, book.CitationRIS, books))
+	require.Equal(t, "TY  - BOOK\r\nID  - herbert1969dune-1\r\nAU  - Herbert, Frank\r\nA4  - Doe, Jane\r\nTI  - Dune Messiah\r\n"+
+		"PY  - 1969\r\nSN  - 9780441172696\r\nER  - \r\n", buf.String())
+}
+
+func TestWriteCitations_CSLJSON(t *testing.T) {
+	books := []book.Book{{ID: 1, Title: `Quotes "and" \ slashes`, Author: "Mary Shelley; Plato", PublishedYear: 1818, Language: "en"}}
+	var buf bytes.Buffer
+	require.NoError(t, book.WriteCitations(&buf, book.CitationCSLJSON, books))
+	require.JSONEq(t, `[{
+		"id": "shelley1818quotes-1",
+		"type": "book",
+		"title": "Quotes \"and\" \\ slashes",
+		"author": [{"family": "Shelley", "given": "Mary"}, {"family": "Plato"}],
+		"issued": {"date-parts": [[1818]]},
+		"language": "en"
+	}]`, buf.String())
+}
+
+func TestWriteCitations_ShouldRejectUnknownFormat(t *testing.T) {
+	require.Error(t, book.WriteCitations(&bytes.Buffer{}, "mla", nil))
+}
@@ -0,0 +1,209 @@
+package book
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type CopyHandler struct {
+	svc CopyService
+	val validator.Validate
+}
+
+func NewCopyHandler(s CopyService) *CopyHandler {
+	return &CopyHandler{svc: s, val: *validator.New()}
+}
+
+// List godoc
+// @Summary      List copies of a book
+// @Description  Returns every physical copy of the book
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int   true   "Book ID"
+// @Success      200    {array}   CopyResponse
+// @Failure      400    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Router       /books/{id}/copies [get]
+func (h *CopyHandler) List(w http.ResponseWriter, r *http.Request) {
+	bookID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	copies, err := h.svc.List(r.Context(), bookID)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	out := make([]CopyResponse, len(copies))
+	for i, c := range copies {
+		out[i] = toCopyResponse(c)
+	}
+	json.NewEncoder(w).Encode(out)
+}
+
+// Get godoc
+// @Summary      Get a copy of a book
+// @Description  Retrieve a single physical copy by its ID
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int   true   "Book ID"
+// @Param        copyId  path      int   true   "Copy ID"
+// @Success      200    {object}  CopyResponse
+// @Failure      400    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Router       /books/{id}/copies/{copyId} [get]
+func (h *CopyHandler) Get(w http.ResponseWriter, r *http.Request) {
+	bookID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	copyID, ok := pathID(w, r, "copyId")
+	if !ok {
+		return
+	}
+	c, err := h.svc.Get(r.Context(), bookID, copyID)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	json.NewEncoder(w).Encode(toCopyResponse(c))
+}
+
+// Create godoc
+// @Summary      Add a copy of a book
+// @Description  Register a new physical copy. Condition defaults to good, status to available and the acquisition date to today. An available copy goes straight to the first member waiting for the book, if any, and is then on_hold
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                        true  "Book ID"
+// @Param        copy  body      CreateOrUpdateCopyRequest  true  "Copy data"
+// @Success      201    {object}  nil
+// @Header       201    {string}  Location  "URL of created copy"
+// @Failure      400    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Failure      409    {object}  ErrorResponse
+// @Router       /books/{id}/copies [post]
+func (h *CopyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	bookID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	req, ok := h.decode(w, r)
+	if !ok {
+		return
+	}
+	cId, err := h.svc.Create(r.Context(), bookID, req)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.Header().Set("location", fmt.Sprintf("/books/%d/copies/%d", bookID, cId))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// Update godoc
+// @Summary      Update a copy of a book
+// @Description  Change the barcode, condition, status or acquisition date of a copy. Empty optional fields are left unchanged. The status of a copy on loan or set aside for a hold only changes through checkout, return and holds, so it cannot be changed here. A copy made available again goes to the first member waiting for the book, if any
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                        true  "Book ID"
+// @Param        copyId  path      int                        true  "Copy ID"
+// @Param        copy    body      CreateOrUpdateCopyRequest  true  "Copy data"
+// @Success      204    {object}  nil
+// @Failure      400    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Failure      409    {object}  ErrorResponse
+// @Router       /books/{id}/copies/{copyId} [put]
+func (h *CopyHandler) Update(w http.ResponseWriter, r *http.Request) {
+	bookID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	copyID, ok := pathID(w, r, "copyId")
+	if !ok {
+		return
+	}
+	req, ok := h.decode(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.Update(r.Context(), bookID, copyID, req); err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Delete godoc
+// @Summary      Delete a copy of a book
+// @Description  Remove a physical copy from the inventory. A copy on loan or set aside for a hold cannot be deleted, and neither can one that was ever lent, as its loans are kept; mark it lost instead
+// @Tags         copies
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int   true   "Book ID"
+// @Param        copyId  path      int   true   "Copy ID"
+// @Success      204    {object}  nil
+// @Failure      400    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Failure      409    {object}  ErrorResponse
+// @Router       /books/{id}/copies/{copyId} [delete]
+func (h *CopyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	bookID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	copyID, ok := pathID(w, r, "copyId")
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(r.Context(), bookID, copyID); err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CopyHandler) decode(w http.ResponseWriter, r *http.Request) (CreateOrUpdateCopyRequest, bool) {
+	var req CreateOrUpdateCopyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, *GetErrorResponseByCode(BadRequest))
+		return req, false
+	}
+	if err := h.val.Struct(&req); err != nil {
+		sendError(w, *validationErrorResponse(err))
+		return req, false
+	}
+	return req, true
+}
+
+// pathID parses the named path variable as an id, answering 400 when it is not one.
+func pathID(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)[name])
+	if err != nil {
+		logrus.Error("invalid ", name, " provided ", mux.Vars(r)[name])
+		sendError(w, *GetErrorResponseByCode(BadRequest))
+		return 0, false
+	}
+	return id, true
+}
+
+func toCopyResponse(c Copy) CopyResponse {
+	return CopyResponse{
+		ID:         c.ID,
+		BookID:     c.BookID,
+		Barcode:    c.Barcode,
+		AcquiredOn: c.AcquiredOn.Format(time.DateOnly),
+		Condition:  c.Condition,
+		Status:     c.Status,
+	}
+}
@@ -0,0 +1,107 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	mock_book "book-store/internal/mocks"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type CopyHandlerTestSuite struct {
+	suite.Suite
+	copyHandler *book.CopyHandler
+	mockService *mock_book.MockCopyService
+	ctrl        *gomock.Controller
+}
+
+func TestCopyHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(CopyHandlerTestSuite))
+}
+
+func (m *CopyHandlerTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockService = mock_book.NewMockCopyService(m.ctrl)
+	m.copyHandler = book.NewCopyHandler(m.mockService)
+}
+
+func (m *CopyHandlerTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func (m *CopyHandlerTestSuite) TestList_ShouldReturnCopiesOfTheBook() {
+	r, _ := http.NewRequest("GET", "/books/12/copies", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().List(r.Context(), 12).Return([]book.Copy{{
+		ID: 4, BookID: 12, Barcode: "BC-001", AcquiredOn: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Condition: "good", Status: "available",
+	}}, nil)
+
+	m.copyHandler.List(w, r)
+	m.Suite.Equal(200, w.Result().StatusCode)
+
+	var body []book.CopyResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal([]book.CopyResponse{{ID: 4, BookID: 12, Barcode: "BC-001", AcquiredOn: "2024-03-01", Condition: "good", Status: "available"}}, body)
+}
+
+func (m *CopyHandlerTestSuite) TestCreate_ShouldReturnLocationOfCreatedCopy() {
+	req := book.CreateOrUpdateCopyRequest{Barcode: "BC-001", AcquiredOn: "2024-03-01"}
+	b, _ := json.Marshal(req)
+	r, _ := http.NewRequest("POST", "/books/12/copies", bytes.NewReader(b))
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Create(r.Context(), 12, req).Return(int64(4), nil)
+
+	m.copyHandler.Create(w, r)
+	m.Suite.Equal(201, w.Result().StatusCode)
+	m.Suite.Equal("/books/12/copies/4", w.Result().Header.Get("Location"))
+}
+
+func (m *CopyHandlerTestSuite) TestCreate_ShouldReturnBadRequestForUnknownStatus() {
+	r, _ := http.NewRequest("POST", "/books/12/copies", bytes.NewReader([]byte(`{"barcode": "BC-001", "status": "stolen"}`)))
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+
+	m.copyHandler.Create(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+
+	var actualErr book.ErrorResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&actualErr))
+	m.Suite.Equal("Status failed on 'oneof'", actualErr.Error())
+}
+
+func (m *CopyHandlerTestSuite) TestGet_ShouldReturnNotFoundWhenServiceReturnsNotFound() {
+	r, _ := http.NewRequest("GET", "/books/12/copies/4", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "12", "copyId": "4"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Get(r.Context(), 12, 4).Return(book.Copy{}, book.GetErrorResponseByCode(book.CopyNotFound))
+
+	m.copyHandler.Get(w, r)
+	m.Suite.Equal(404, w.Result().StatusCode)
+}
+
+func (m *CopyHandlerTestSuite) TestDelete_ShouldThrowErrorWhenCopyIdIsInvalid() {
+	r, _ := http.NewRequest("DELETE", "/books/12/copies/abc", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "12", "copyId": "abc"})
+	w := httptest.NewRecorder()
+
+	m.copyHandler.Delete(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+}
+
+func (m *CopyHandlerTestSuite) TestUpdate_ShouldReturnBadRequestForStatusOnLoan() {
+	r, _ := http.NewRequest("PUT", "/books/12/copies/4", bytes.NewReader([]byte(`{"barcode": "BC-001", "status": "on_loan"}`)))
+	r = mux.SetURLVars(r, map[string]string{"id": "12", "copyId": "4"})
+	w := httptest.NewRecorder()
+
+	m.copyHandler.Update(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+}
@@ -0,0 +1,242 @@
+package book
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrCopyNotFound      = errors.New("copy not found")
+	ErrDuplicateBarcode  = errors.New("barcode already exists")
+	ErrCopyInCirculation = errors.New("copy is on loan or on hold")
+	ErrCopyHasLoans      = errors.New("copy has loans")
+)
+
+// ShelveFunc hands a copy that became available to the first member waiting
+// for its book, or leaves it available when nobody is. It runs in tx, which
+// holds the lock on the hold queue of the book.
+type ShelveFunc func(ctx context.Context, tx *sql.Tx, bookID, copyID int, at time.Time) error
+
+type CopyRepository interface {
+	// Create shelves a copy created available, so it may go straight to a
+	// waiting hold.
+	Create(ctx context.Context, c Copy) (int64, error)
+	GetByID(ctx context.Context, bookID, id int) (Copy, error)
+	ListByBook(ctx context.Context, bookID int) ([]Copy, error)
+	// Update refuses to change the status of a copy on loan or set aside for
+	// a hold, or to give a copy one of those statuses. A copy made available
+	// again is shelved like a new one.
+	Update(ctx context.Context, c Copy) error
+	// Delete refuses a copy that is out on loan or set aside for a hold, and
+	// one that was ever lent, whose loans are kept. Create, Update and Delete
+	// report a book in the trash as not found.
+	Delete(ctx context.Context, bookID, id int) error
+}
+
+type sqlCopyRepo struct {
+	db     *sql.DB
+	shelve ShelveFunc
+}
+
+func NewCopyRepository(db *sql.DB, shelve ShelveFunc) CopyRepository {
+	return &sqlCopyRepo{db: db, shelve: shelve}
+}
+
+func (r *sqlCopyRepo) Create(ctx context.Context, c Copy) (int64, error) {
+	var id int64
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := lockHoldQueue(ctx, tx, c.BookID); err != nil {
+			return err
+		}
+		err := tx.QueryRowContext(ctx,
+			`INSERT INTO copies (book_id, barcode, acquired_on, condition, status) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+			c.BookID, c.Barcode, c.AcquiredOn, c.Condition, c.Status).Scan(&id)
+		if err != nil || c.Status != CopyStatusAvailable {
+			return err
+		}
+		return r.shelve(ctx, tx, c.BookID, int(id), time.Now())
+	})
+	if err != nil {
+		return 0, translateCopyErr(err)
+	}
+	return id, nil
+}
+
+func (r *sqlCopyRepo) GetByID(ctx context.Context, bookID, id int) (Copy, error) {
+	c := Copy{}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, book_id, barcode, acquired_on, condition, status FROM copies WHERE book_id = $1 AND id = $2`, bookID, id).
+		Scan(&c.ID, &c.BookID, &c.Barcode, &c.AcquiredOn, &c.Condition, &c.Status)
+	if err == sql.ErrNoRows {
+		return Copy{}, ErrCopyNotFound
+	}
+	return c, err
+}
+
+func (r *sqlCopyRepo) ListByBook(ctx context.Context, bookID int) ([]Copy, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, book_id, barcode, acquired_on, condition, status FROM copies WHERE book_id = $1 ORDER BY id`, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	copies := []Copy{}
+	for rows.Next() {
+		c := Copy{}
+		if err := rows.Scan(&c.ID, &c.BookID, &c.Barcode, &c.AcquiredOn, &c.Condition, &c.Status); err != nil {
+			return nil, err
+		}
+		copies = append(copies, c)
+	}
+	return copies, rows.Err()
+}
+
+func (r *sqlCopyRepo) Update(ctx context.Context, c Copy) error {
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		// The queue is locked before the copy, in the order circulation
+		// takes them.
+		if err := lockHoldQueue(ctx, tx, c.BookID); err != nil {
+			return err
+		}
+		var status string
+		err := tx.QueryRowContext(ctx,
+			`SELECT status FROM copies WHERE book_id=$1 AND id=$2 FOR UPDATE`, c.BookID, c.ID).Scan(&status)
+		if err == sql.ErrNoRows {
+			return ErrCopyNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if status != c.Status && (inCirculation(status) || inCirculation(c.Status)) {
+			return ErrCopyInCirculation
+		}
+		_, err = tx.ExecContext(ctx,
+			`UPDATE copies SET barcode=$1, acquired_on=$2, condition=$3, status=$4 WHERE book_id=$5 AND id=$6`,
+			c.Barcode, c.AcquiredOn, c.Condition, c.Status, c.BookID, c.ID)
+		if err != nil || c.Status != CopyStatusAvailable || status == CopyStatusAvailable {
+			return err
+		}
+		return r.shelve(ctx, tx, c.BookID, c.ID, time.Now())
+	})
+	if err != nil {
+		return translateCopyErr(err)
+	}
+	return nil
+}
+
+// lockHoldQueue locks the row of the book, which checkout, return and the
+// hold queue lock before changing its copies. The copies of a book in the
+// trash are gone with it, so it is not found.
+func lockHoldQueue(ctx context.Context, tx *sql.Tx, bookID int) error {
+	var locked int
+	err := tx.QueryRowContext(ctx,
+		`SELECT id FROM books WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, bookID).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	}
+	return err
+}
+
+// inCirculation reports whether a copy with the status is lent or held,
+// which only checkout, return and the hold queue change.
+func inCirculation(status string) bool {
+	return status == CopyStatusOnLoan || status == CopyStatusOnHold
+}
+
+func (r *sqlCopyRepo) Delete(ctx context.Context, bookID, id int) error {
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := lockHoldQueue(ctx, tx, bookID); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx,
+			`DELETE FROM copies WHERE book_id=$1 AND id=$2 AND status NOT IN ('on_loan', 'on_hold')`, bookID, id)
+		if err != nil {
+			return err
+		}
+		if err := expectCopyRow(res); err != ErrCopyNotFound {
+			return err
+		}
+		var exists bool
+		if err := tx.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM copies WHERE book_id=$1 AND id=$2)`, bookID, id).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return ErrCopyNotFound
+		}
+		return ErrCopyInCirculation
+	})
+	return translateCopyErr(err)
+}
+
+// loadCopyCounts fills in the copy aggregates of every book with a single query.
+// A copy has at most one open loan, so the join does not inflate the counts.
+func loadCopyCounts(ctx context.Context, q queryer, books []Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(books))
+	index := make(map[int]int, len(books))
+	for i, b := range books {
+		ids[i] = int64(b.ID)
+		index[b.ID] = i
+	}
+	rows, err := q.QueryContext(ctx, `
+        SELECT c.book_id,
+               COUNT(*) FILTER (WHERE c.status <> 'lost'),
+               COUNT(*) FILTER (WHERE c.status = 'available'),
+               MIN(l.due_on)
+        FROM copies c
+        LEFT JOIN loans l ON l.copy_id = c.id AND l.returned_at IS NULL
+        WHERE c.book_id = ANY($1)
+        GROUP BY c.book_id`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var bookID, total, available int
+		var nextDue sql.NullTime
+		if err := rows.Scan(&bookID, &total, &available, &nextDue); err != nil {
+			return err
+		}
+		i := index[bookID]
+		books[i].TotalCopies = total
+		books[i].AvailableCopies = available
+		if nextDue.Valid {
+			books[i].NextDueOn = &nextDue.Time
+		}
+	}
+	return rows.Err()
+}
+
+func expectCopyRow(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrCopyNotFound
+	}
+	return nil
+}
+
+func translateCopyErr(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch {
+	case pqErr.Code == uniqueViolation && pqErr.Constraint == "copies_barcode_key":
+		return ErrDuplicateBarcode
+	case pqErr.Code == foreignKeyViolation && pqErr.Constraint == "copies_book_id_fkey":
+		return ErrNotFound
+	case pqErr.Code == foreignKeyViolation && pqErr.Constraint == "loans_copy_id_fkey":
+		return ErrCopyHasLoans
+	}
+	return err
+}
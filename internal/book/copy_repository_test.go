@@ -0,0 +1,199 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+)
+
+type CopyRepositoryTestSuite struct {
+	suite.Suite
+	copyRepository book.CopyRepository
+	sqlMock        sqlmock.Sqlmock
+	db             *sql.DB
+	// shelved has the copies handed to the shelve func, by book.
+	shelved map[int][]int
+}
+
+func TestCopyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(CopyRepositoryTestSuite))
+}
+
+func (m *CopyRepositoryTestSuite) SetupTest() {
+	m.db, m.sqlMock, _ = sqlmock.New()
+	m.shelved = map[int][]int{}
+	m.copyRepository = book.NewCopyRepository(m.db, m.shelve)
+}
+
+func (m *CopyRepositoryTestSuite) shelve(_ context.Context, _ *sql.Tx, bookID, copyID int, _ time.Time) error {
+	m.shelved[bookID] = append(m.shelved[bookID], copyID)
+	return nil
+}
+
+func (m *CopyRepositoryTestSuite) expectQueueLocked(bookID int) {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM books WHERE id = $1 AND deleted_at IS NULL FOR UPDATE")).WithArgs(bookID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(bookID))
+}
+
+func (m *CopyRepositoryTestSuite) TestCreate_ShouldInsertCopyInDatabaseAndShelveIt() {
+	acquired := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO copies (book_id, barcode, acquired_on, condition, status) VALUES ($1, $2, $3, $4, $5) RETURNING id")).
+		WithArgs(12, "BC-001", acquired, "good", "available").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	m.sqlMock.ExpectCommit()
+	id, err := m.copyRepository.Create(context.Background(), book.Copy{BookID: 12, Barcode: "BC-001", AcquiredOn: acquired, Condition: "good", Status: "available"})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(4), id)
+	m.Suite.Equal(map[int][]int{12: {4}}, m.shelved)
+}
+
+func (m *CopyRepositoryTestSuite) TestCreate_ShouldNotShelveACopyInRepair() {
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectQuery("INSERT INTO copies").WithArgs(12, "BC-001", sqlmock.AnyArg(), "", "in_repair").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	m.sqlMock.ExpectCommit()
+	_, err := m.copyRepository.Create(context.Background(), book.Copy{BookID: 12, Barcode: "BC-001", Status: "in_repair"})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Empty(m.shelved)
+}
+
+func (m *CopyRepositoryTestSuite) TestCreate_ShouldReturnDuplicateBarcodeErrorOnUniqueViolation() {
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectQuery("INSERT INTO copies").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "copies_barcode_key"})
+	m.sqlMock.ExpectRollback()
+	_, err := m.copyRepository.Create(context.Background(), book.Copy{BookID: 12, Barcode: "BC-001"})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrDuplicateBarcode, err)
+}
+
+func (m *CopyRepositoryTestSuite) TestCreate_ShouldReturnNotFoundErrorWhenBookDoesNotExist() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM books WHERE id = $1 AND deleted_at IS NULL FOR UPDATE")).WithArgs(12).
+		WillReturnError(sql.ErrNoRows)
+	m.sqlMock.ExpectRollback()
+	_, err := m.copyRepository.Create(context.Background(), book.Copy{BookID: 12, Barcode: "BC-001"})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrNotFound, err)
+}
+
+func (m *CopyRepositoryTestSuite) TestGetById_ShouldReturnCopyNotFoundErrorIfNoCopyPresent() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM copies WHERE book_id = $1 AND id = $2")).WithArgs(12, 4).
+		WillReturnError(sql.ErrNoRows)
+	_, err := m.copyRepository.GetByID(context.Background(), 12, 4)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrCopyNotFound, err)
+}
+
+func (m *CopyRepositoryTestSuite) TestListByBook_ShouldReturnCopiesOfTheBook() {
+	acquired := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM copies WHERE book_id = $1 ORDER BY id")).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "book_id", "barcode", "acquired_on", "condition", "status"}).
+			AddRow(4, 12, "BC-001", acquired, "good", "available").
+			AddRow(5, 12, "BC-002", acquired, "poor", "lost"))
+	copies, err := m.copyRepository.ListByBook(context.Background(), 12)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal([]book.Copy{
+		{ID: 4, BookID: 12, Barcode: "BC-001", AcquiredOn: acquired, Condition: "good", Status: "available"},
+		{ID: 5, BookID: 12, Barcode: "BC-002", AcquiredOn: acquired, Condition: "poor", Status: "lost"},
+	}, copies)
+}
+
+func (m *CopyRepositoryTestSuite) TestDelete_ShouldReturnCopyNotFoundErrorWhenNothingWasDeleted() {
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM copies WHERE book_id=$1 AND id=$2 AND status NOT IN ('on_loan', 'on_hold')")).WithArgs(12, 4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM copies WHERE book_id=$1 AND id=$2)")).WithArgs(12, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	m.sqlMock.ExpectRollback()
+	err := m.copyRepository.Delete(context.Background(), 12, 4)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrCopyNotFound, err)
+}
+
+func (m *CopyRepositoryTestSuite) TestDelete_ShouldRefuseACopyOnLoan() {
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectExec("DELETE FROM copies").WithArgs(12, 4).WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS")).WithArgs(12, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	m.sqlMock.ExpectRollback()
+	err := m.copyRepository.Delete(context.Background(), 12, 4)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrCopyInCirculation, err)
+}
+
+func (m *CopyRepositoryTestSuite) TestDelete_ShouldRefuseACopyThatWasLent() {
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectExec("DELETE FROM copies").WithArgs(12, 4).
+		WillReturnError(&pq.Error{Code: "23503", Constraint: "loans_copy_id_fkey"})
+	m.sqlMock.ExpectRollback()
+	err := m.copyRepository.Delete(context.Background(), 12, 4)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrCopyHasLoans, err)
+}
+
+func (m *CopyRepositoryTestSuite) TestDelete_ShouldReturnNotFoundErrorForABookInTheTrash() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM books WHERE id = $1 AND deleted_at IS NULL FOR UPDATE")).WithArgs(12).
+		WillReturnError(sql.ErrNoRows)
+	m.sqlMock.ExpectRollback()
+	err := m.copyRepository.Delete(context.Background(), 12, 4)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrNotFound, err)
+}
+
+func (m *CopyRepositoryTestSuite) TestUpdate_ShouldUpdateTheCopyUnderItsLock() {
+	acquired := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT status FROM copies WHERE book_id=$1 AND id=$2 FOR UPDATE")).WithArgs(12, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("in_repair"))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE copies SET barcode=$1, acquired_on=$2, condition=$3, status=$4 WHERE book_id=$5 AND id=$6")).
+		WithArgs("BC-001", acquired, "good", "lost", 12, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	err := m.copyRepository.Update(context.Background(), book.Copy{ID: 4, BookID: 12, Barcode: "BC-001", AcquiredOn: acquired, Condition: "good", Status: "lost"})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Empty(m.shelved)
+}
+
+func (m *CopyRepositoryTestSuite) TestUpdate_ShouldShelveACopyBackFromRepair() {
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT status FROM copies")).WithArgs(12, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("in_repair"))
+	m.sqlMock.ExpectExec("UPDATE copies SET barcode").WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	err := m.copyRepository.Update(context.Background(), book.Copy{ID: 4, BookID: 12, Barcode: "BC-001", Status: "available"})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(map[int][]int{12: {4}}, m.shelved)
+}
+
+func (m *CopyRepositoryTestSuite) TestUpdate_ShouldRefuseToTakeACopyOffLoan() {
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT status FROM copies")).WithArgs(12, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("on_loan"))
+	m.sqlMock.ExpectRollback()
+	err := m.copyRepository.Update(context.Background(), book.Copy{ID: 4, BookID: 12, Barcode: "BC-001", Status: "available"})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrCopyInCirculation, err)
+}
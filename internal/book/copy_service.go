@@ -0,0 +1,161 @@
+package book
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type CopyService interface {
+	Create(ctx context.Context, bookID int, req CreateOrUpdateCopyRequest) (int64, *ErrorResponse)
+	Get(ctx context.Context, bookID, id int) (Copy, *ErrorResponse)
+	List(ctx context.Context, bookID int) ([]Copy, *ErrorResponse)
+	Update(ctx context.Context, bookID, id int, req CreateOrUpdateCopyRequest) *ErrorResponse
+	Delete(ctx context.Context, bookID, id int) *ErrorResponse
+}
+
+type copyService struct {
+	repository     CopyRepository
+	bookRepository BookRepository
+}
+
+func NewCopyService(r CopyRepository, br BookRepository) CopyService {
+	return &copyService{repository: r, bookRepository: br}
+}
+
+func (s *copyService) Create(ctx context.Context, bookID int, req CreateOrUpdateCopyRequest) (int64, *ErrorResponse) {
+	c := toCopy(bookID, req)
+	id, err := s.repository.Create(ctx, c)
+	if err != nil {
+		if errResp := copyWriteErrorResponse(err); errResp != nil {
+			return 0, errResp
+		}
+		logrus.Error("error while creating copy. error is ", err)
+		return 0, GetErrorResponseByCode(InternalServerError)
+	}
+	return id, nil
+}
+
+func (s *copyService) Get(ctx context.Context, bookID, id int) (Copy, *ErrorResponse) {
+	if errResp := s.requireBook(ctx, bookID); errResp != nil {
+		return Copy{}, errResp
+	}
+	c, err := s.repository.GetByID(ctx, bookID, id)
+	if err != nil {
+		if err == ErrCopyNotFound {
+			logrus.Error("no copy ", id, " found for book ", bookID)
+			return Copy{}, GetErrorResponseByCode(CopyNotFound)
+		}
+		logrus.Error("error while fetching copy ", id, " error is ", err)
+		return Copy{}, GetErrorResponseByCode(InternalServerError)
+	}
+	return c, nil
+}
+
+func (s *copyService) List(ctx context.Context, bookID int) ([]Copy, *ErrorResponse) {
+	if errResp := s.requireBook(ctx, bookID); errResp != nil {
+		return nil, errResp
+	}
+	copies, err := s.repository.ListByBook(ctx, bookID)
+	if err != nil {
+		logrus.Error("error while fetching copies of book ", bookID, " error is ", err)
+		return nil, GetErrorResponseByCode(InternalServerError)
+	}
+	return copies, nil
+}
+
+// requireBook answers for the copies of a book that does not exist or is in
+// the trash with BookNotFound.
+func (s *copyService) requireBook(ctx context.Context, bookID int) *ErrorResponse {
+	if _, err := s.bookRepository.GetByID(ctx, bookID); err != nil {
+		if err == ErrNotFound {
+			logrus.Error("no record found for given id ", bookID)
+			return GetErrorResponseByCode(BookNotFound)
+		}
+		logrus.Error("error while fetching the record for id ", bookID, " error is ", err)
+		return GetErrorResponseByCode(InternalServerError)
+	}
+	return nil
+}
+
+// Update replaces the barcode of the copy. Optional fields left empty in the
+// request keep their stored value.
+func (s *copyService) Update(ctx context.Context, bookID, id int, req CreateOrUpdateCopyRequest) *ErrorResponse {
+	c, errResp := s.Get(ctx, bookID, id)
+	if errResp != nil {
+		return errResp
+	}
+	c.Barcode = req.Barcode
+	if req.AcquiredOn != "" {
+		c.AcquiredOn, _ = time.Parse(time.DateOnly, req.AcquiredOn)
+	}
+	if req.Condition != "" {
+		c.Condition = req.Condition
+	}
+	if req.Status != "" {
+		c.Status = req.Status
+	}
+	if err := s.repository.Update(ctx, c); err != nil {
+		if errResp := copyWriteErrorResponse(err); errResp != nil {
+			return errResp
+		}
+		logrus.Error("error while updating copy ", id, " error is ", err)
+		return GetErrorResponseByCode(InternalServerError)
+	}
+	return nil
+}
+
+func (s *copyService) Delete(ctx context.Context, bookID, id int) *ErrorResponse {
+	if err := s.repository.Delete(ctx, bookID, id); err != nil {
+		if errResp := copyWriteErrorResponse(err); errResp != nil {
+			return errResp
+		}
+		logrus.Error("error while deleting copy ", id, " error is ", err)
+		return GetErrorResponseByCode(InternalServerError)
+	}
+	return nil
+}
+
+// toCopy applies the defaults for the optional fields of the request. The
+// acquisition date has already been validated by the handler.
+func toCopy(bookID int, req CreateOrUpdateCopyRequest) Copy {
+	c := Copy{
+		BookID:    bookID,
+		Barcode:   req.Barcode,
+		Condition: req.Condition,
+		Status:    req.Status,
+	}
+	c.AcquiredOn, _ = time.Parse(time.DateOnly, req.AcquiredOn)
+	if req.AcquiredOn == "" {
+		c.AcquiredOn = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	if c.Condition == "" {
+		c.Condition = CopyConditionGood
+	}
+	if c.Status == "" {
+		c.Status = CopyStatusAvailable
+	}
+	return c
+}
+
+func copyWriteErrorResponse(err error) *ErrorResponse {
+	switch err {
+	case ErrNotFound:
+		logrus.Error("copy references a book that does not exist")
+		return GetErrorResponseByCode(BookNotFound)
+	case ErrCopyNotFound:
+		logrus.Error("copy does not exist")
+		return GetErrorResponseByCode(CopyNotFound)
+	case ErrDuplicateBarcode:
+		logrus.Error("copy with the given barcode already exists")
+		return GetErrorResponseByCode(BarcodeAlreadyExists)
+	case ErrCopyInCirculation:
+		logrus.Error("copy is on loan or on hold")
+		return GetErrorResponseByCode(CopyInCirculation)
+	case ErrCopyHasLoans:
+		logrus.Error("copy has loans")
+		return GetErrorResponseByCode(CopyHasLoans)
+	}
+	return nil
+}
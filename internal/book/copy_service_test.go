@@ -0,0 +1,119 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	mock_book "book-store/internal/mocks"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CopyServiceTestSuite struct {
+	suite.Suite
+	copyService  book.CopyService
+	mockRepo     *mock_book.MockCopyRepository
+	mockBookRepo *mock_book.MockBookRepository
+	ctrl         *gomock.Controller
+}
+
+func TestCopyServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CopyServiceTestSuite))
+}
+
+func (m *CopyServiceTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockRepo = mock_book.NewMockCopyRepository(m.ctrl)
+	m.mockBookRepo = mock_book.NewMockBookRepository(m.ctrl)
+	m.copyService = book.NewCopyService(m.mockRepo, m.mockBookRepo)
+}
+
+func (m *CopyServiceTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func (m *CopyServiceTestSuite) TestCreate_ShouldApplyDefaultsForOptionalFields() {
+	m.mockRepo.EXPECT().Create(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, c book.Copy) (int64, error) {
+		m.Suite.Equal(12, c.BookID)
+		m.Suite.Equal("BC-001", c.Barcode)
+		m.Suite.Equal(book.CopyConditionGood, c.Condition)
+		m.Suite.Equal(book.CopyStatusAvailable, c.Status)
+		m.Suite.Equal(time.Now().UTC().Format(time.DateOnly), c.AcquiredOn.Format(time.DateOnly))
+		return 4, nil
+	})
+	id, err := m.copyService.Create(context.Background(), 12, book.CreateOrUpdateCopyRequest{Barcode: "BC-001"})
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(4), id)
+}
+
+func (m *CopyServiceTestSuite) TestCreate_ShouldReturnConflictWhenBarcodeExists() {
+	m.mockRepo.EXPECT().Create(context.Background(), gomock.Any()).Return(int64(0), book.ErrDuplicateBarcode)
+	_, err := m.copyService.Create(context.Background(), 12, book.CreateOrUpdateCopyRequest{Barcode: "BC-001"})
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BarcodeAlreadyExists), err)
+}
+
+func (m *CopyServiceTestSuite) TestCreate_ShouldReturnBookNotFoundWhenBookDoesNotExist() {
+	m.mockRepo.EXPECT().Create(context.Background(), gomock.Any()).Return(int64(0), book.ErrNotFound)
+	_, err := m.copyService.Create(context.Background(), 12, book.CreateOrUpdateCopyRequest{Barcode: "BC-001"})
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BookNotFound), err)
+}
+
+func (m *CopyServiceTestSuite) TestList_ShouldReturnBookNotFoundWhenBookDoesNotExist() {
+	m.mockBookRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{}, book.ErrNotFound)
+	_, err := m.copyService.List(context.Background(), 12)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BookNotFound), err)
+}
+
+func (m *CopyServiceTestSuite) TestUpdate_ShouldKeepStoredValuesForEmptyOptionalFields() {
+	acquired := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	stored := book.Copy{ID: 4, BookID: 12, Barcode: "BC-001", AcquiredOn: acquired, Condition: "good", Status: "available"}
+	m.mockBookRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12}, nil)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12, 4).Return(stored, nil)
+	m.mockRepo.EXPECT().Update(context.Background(), book.Copy{ID: 4, BookID: 12, Barcode: "BC-002", AcquiredOn: acquired, Condition: "good", Status: "in_repair"}).Return(nil)
+	err := m.copyService.Update(context.Background(), 12, 4, book.CreateOrUpdateCopyRequest{Barcode: "BC-002", Status: "in_repair"})
+	m.Suite.Nil(err)
+}
+
+func (m *CopyServiceTestSuite) TestDelete_ShouldReturnCopyNotFoundWhenCopyDoesNotExist() {
+	m.mockRepo.EXPECT().Delete(context.Background(), 12, 4).Return(book.ErrCopyNotFound)
+	err := m.copyService.Delete(context.Background(), 12, 4)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.CopyNotFound), err)
+}
+
+func (m *CopyServiceTestSuite) TestDelete_ShouldReturnConflictForACopyInCirculation() {
+	m.mockRepo.EXPECT().Delete(context.Background(), 12, 4).Return(book.ErrCopyInCirculation)
+	err := m.copyService.Delete(context.Background(), 12, 4)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.CopyInCirculation), err)
+	m.Suite.Equal(409, err.HttpStatusCode)
+}
+
+func (m *CopyServiceTestSuite) TestUpdate_ShouldReturnConflictWhenTheCopyIsOnLoan() {
+	stored := book.Copy{ID: 4, BookID: 12, Barcode: "BC-001", Condition: "good", Status: "on_loan"}
+	m.mockBookRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12}, nil)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12, 4).Return(stored, nil)
+	m.mockRepo.EXPECT().Update(context.Background(), book.Copy{ID: 4, BookID: 12, Barcode: "BC-001", Condition: "good", Status: "available"}).
+		Return(book.ErrCopyInCirculation)
+	err := m.copyService.Update(context.Background(), 12, 4, book.CreateOrUpdateCopyRequest{Barcode: "BC-001", Status: "available"})
+	m.Suite.Equal(book.GetErrorResponseByCode(book.CopyInCirculation), err)
+}
+
+func (m *CopyServiceTestSuite) TestGet_ShouldReturnBookNotFoundForABookInTheTrash() {
+	m.mockBookRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{}, book.ErrNotFound)
+	_, err := m.copyService.Get(context.Background(), 12, 4)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BookNotFound), err)
+}
+
+func (m *CopyServiceTestSuite) TestUpdate_ShouldReturnBookNotFoundForABookInTheTrash() {
+	m.mockBookRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{}, book.ErrNotFound)
+	err := m.copyService.Update(context.Background(), 12, 4, book.CreateOrUpdateCopyRequest{Barcode: "BC-001"})
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BookNotFound), err)
+}
+
+func (m *CopyServiceTestSuite) TestDelete_ShouldReturnBookNotFoundForABookInTheTrash() {
+	m.mockRepo.EXPECT().Delete(context.Background(), 12, 4).Return(book.ErrNotFound)
+	err := m.copyService.Delete(context.Background(), 12, 4)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BookNotFound), err)
+	m.Suite.Equal(404, err.HttpStatusCode)
+}
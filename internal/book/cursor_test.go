@@ -0,0 +1,44 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorCodec_ShouldRoundTrip(t *testing.T) {
+	codec := book.NewCursorCodec([]byte("secret"))
+	cur := book.Cursor{Sort: "title,-createdAt", Filter: "44b136a2", Keys: []string{"Dune", "2026-10-01T09:30:00Z", "12"}}
+
+	got, err := codec.Decode(codec.Encode(cur))
+	require.NoError(t, err)
+	require.Equal(t, cur, got)
+}
+
+func TestCursorCodec_ShouldRejectTamperedOrForeignCursors(t *testing.T) {
+	codec := book.NewCursorCodec([]byte("secret"))
+	token := codec.Encode(book.Cursor{Sort: "id", Keys: []string{"12"}})
+	payload, sig, _ := strings.Cut(token, ".")
+	forged := book.NewCursorCodec([]byte("secret")).Encode(book.Cursor{Sort: "id", Keys: []string{"99"}})
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	for _, in := range []string{
+		"",
+		payload,
+		forgedPayload + "." + sig,
+		payload + "." + sig[1:],
+		book.NewCursorCodec([]byte("other")).Encode(book.Cursor{Sort: "id", Keys: []string{"12"}}),
+	} {
+		_, err := codec.Decode(in)
+		require.True(t, errors.Is(err, book.ErrInvalidCursor), in)
+	}
+}
+
+func TestFormatSort_ShouldInvertParseSort(t *testing.T) {
+	sort, err := book.ParseSort("title,-createdAt")
+	require.NoError(t, err)
+	require.Equal(t, "title,-createdAt", book.FormatSort(sort))
+}
@@ -2,8 +2,20 @@ package book
 
 type CreateOrUpdateBookRequest struct {
     Title       string `json:"title" validate:"required,min=1,max=200"`
-    Author      string `json:"author" validate:"required,min=1,max=100"`
+    // Author is the byline, which may be left out when authors are linked
+    // and is then made of their names.
+    Author      string `json:"author" validate:"omitempty,max=100"`
     Description string `json:"description" validate:"omitempty,max=500"`
+    ISBN        string `json:"isbn" validate:"omitempty,isbn" example:"978-0-7475-3269-9"`
+    Authors     []BookAuthorRequest `json:"authors" validate:"omitempty,max=20,dive"`
+    Genre         string `json:"genre" validate:"omitempty,max=64" example:"fantasy"`
+    Language      string `json:"language" validate:"omitempty,alpha,min=2,max=3" example:"en"`
+    PublishedYear int    `json:"publishedYear" validate:"omitempty,min=1,max=9999" example:"1998"`
+}
+
+type BookAuthorRequest struct {
+    AuthorID int    `json:"authorId" validate:"required,gt=0" example:"1"`
+    Role     string `json:"role" validate:"omitempty,oneof=author editor translator illustrator" example:"author"`
 }
 
 type BookResponse struct {
@@ -11,6 +23,27 @@ type BookResponse struct {
 	Title       string `json:"title" example:"Harry Potter"`
 	Author      string `json:"author" example:"JK Rolling"`
 	Description string `json:"description" example:"harry potter and his friends"`
+	ISBN13      string `json:"isbn13,omitempty" example:"9780747532699"`
+	ISBN10      string `json:"isbn10,omitempty" example:"0747532699"`
+	CreatedAt   string `json:"createdAt" example:"2026-10-17T09:30:00Z"`
+	Genre         string `json:"genre,omitempty" example:"fantasy"`
+	Language      string `json:"language,omitempty" example:"en"`
+	PublishedYear int    `json:"publishedYear,omitempty" example:"1998"`
+	Authors     []BookAuthorResponse `json:"authors"`
+	AvailableCopies int `json:"availableCopies" example:"2"`
+	TotalCopies     int `json:"totalCopies" example:"3"`
+	Available       bool   `json:"available" example:"true"`
+	// NextDueOn is the earliest due date of the copies currently on loan.
+	NextDueOn       string `json:"nextDueOn,omitempty" example:"2026-11-07"`
+	// DeletedAt is only set on books in the trash.
+	DeletedAt       string `json:"deletedAt,omitempty" example:"2026-10-17T09:30:00Z"`
+}
+
+type BookAuthorResponse struct {
+	ID       int    `json:"id" example:"1"`
+	Name     string `json:"name" example:"J.K. Rowling"`
+	Role     string `json:"role" example:"author"`
+	Position int    `json:"position" example:"1"`
 }
 
 type PaginatedBookListResponse struct {
@@ -19,4 +52,167 @@ type PaginatedBookListResponse struct {
   Total      int            `json:"total" example:"42"`
   TotalPages int            `json:"totalPages" example:"5"`
   Data       []BookResponse `json:"data"`
+  Facets     *FacetsResponse `json:"facets,omitempty"`
+}
+
+// FacetsResponse counts the matching books per value of each facet, keyed by
+// the filter parameter that selects the value.
+type FacetsResponse struct {
+	AuthorID []FacetValueResponse `json:"authorId"`
+	Genre    []FacetValueResponse `json:"genre"`
+	Language []FacetValueResponse `json:"language"`
+	Decade   []FacetValueResponse `json:"decade"`
+}
+
+type FacetValueResponse struct {
+	Value string `json:"value" example:"3"`
+	Label string `json:"label,omitempty" example:"J.K. Rowling"`
+	Count int    `json:"count" example:"7"`
+}
+
+// CursorBookListResponse is a page of books in cursor mode. NextCursor is
+// left out on the last page and Total unless withTotal was set.
+type CursorBookListResponse struct {
+	Limit      int            `json:"limit" example:"10"`
+	Total      *int           `json:"total,omitempty" example:"42"`
+	NextCursor string         `json:"nextCursor,omitempty" example:"eyJzIjoiaWQiLCJmIjoiNDRiMTM2YTIiLCJrIjpbIjEwIl19.c2lnbmF0dXJl"`
+	Data       []BookResponse `json:"data"`
+	Facets     *FacetsResponse `json:"facets,omitempty"`
+}
+
+// BookSearchResult is a book in the search results. The highlights mark the
+// matched words with <mark> tags; the description highlight holds only the
+// fragments around the matches.
+type BookSearchResult struct {
+	BookResponse
+	Rank       float64          `json:"rank" example:"0.6079271"`
+	Highlights SearchHighlights `json:"highlights"`
+}
+
+type SearchHighlights struct {
+	Title       string `json:"title" example:"Harry <mark>Potter</mark>"`
+	Description string `json:"description" example:"<mark>harry</mark> <mark>potter</mark> and his friends"`
+}
+
+type PaginatedBookSearchResponse struct {
+	Page       int                `json:"page" example:"1"`
+	Limit      int                `json:"limit" example:"10"`
+	Total      int                `json:"total" example:"42"`
+	TotalPages int                `json:"totalPages" example:"5"`
+	Data       []BookSearchResult `json:"data"`
+	Facets     *FacetsResponse    `json:"facets,omitempty"`
+}
+
+// BookSuggestion is a book resembling the typed input. Score runs from 0 to 1.
+type BookSuggestion struct {
+	ID     int     `json:"id" example:"12"`
+	Title  string  `json:"title" example:"Harry Potter and the Chamber of Secrets"`
+	Author string  `json:"author" example:"J.K. Rowling"`
+	Score  float64 `json:"score" example:"0.82"`
+}
+
+type BookSuggestionsResponse struct {
+	Data []BookSuggestion `json:"data"`
+}
+
+// BatchRequest lists writes to apply together. In atomic mode, the default,
+// either all of them are applied or none; in bestEffort mode every write
+// that succeeds is kept.
+type BatchRequest struct {
+	Mode       string                  `json:"mode" validate:"omitempty,oneof=atomic bestEffort" example:"atomic"`
+	Operations []BatchOperationRequest `json:"operations" validate:"required,min=1,max=500"`
+}
+
+// BatchOperationRequest is one write of a batch. Updates and deletes name the
+// book by ID and carry its ETag in IfMatch; creates and updates carry the
+// whole book, as POST and PUT would.
+type BatchOperationRequest struct {
+	Op      string                     `json:"op" validate:"required,oneof=create update delete" example:"update"`
+	ID      int                        `json:"id" validate:"required_unless=Op create,min=0" example:"12"`
+	IfMatch string                     `json:"ifMatch"`
+	Book    *CreateOrUpdateBookRequest `json:"book" validate:"required_unless=Op delete"`
+}
+
+type BatchResponse struct {
+	Results []BatchItemResponse `json:"results"`
+}
+
+// BatchItemResponse reports on the operation at Index with the status the
+// single-book endpoint would have answered. Applied creates and updates
+// carry the ETag of the new version; failures carry the error.
+type BatchItemResponse struct {
+	Index  int            `json:"index" example:"0"`
+	Status int            `json:"status" example:"201"`
+	ID     int            `json:"id,omitempty" example:"12"`
+	ETag   string         `json:"etag,omitempty"`
+	Error  *ErrorResponse `json:"error,omitempty"`
+}
+
+// ImportJobResponse reports on a CSV import. Imported counts the rows that
+// became books, or would have in a dry run. ErrorReport links to the rows
+// that were skipped once there are any.
+type ImportJobResponse struct {
+	ID          int     `json:"id" example:"7"`
+	Status      string  `json:"status" example:"running"`
+	DryRun      bool    `json:"dryRun" example:"false"`
+	FileName    string  `json:"fileName" example:"catalog.csv"`
+	Progress    float64 `json:"progress" example:"0.42"`
+	Rows        int     `json:"rows" example:"1200"`
+	Imported    int     `json:"imported" example:"1180"`
+	Duplicates  int     `json:"duplicates" example:"15"`
+	Failed      int     `json:"failed" example:"5"`
+	Error       string  `json:"error,omitempty"`
+	ErrorReport string  `json:"errorReport,omitempty" example:"/imports/7/errors"`
+	CreatedBy   string  `json:"createdBy,omitempty" example:"alice"`
+	CreatedAt   string  `json:"createdAt" example:"2024-03-01T10:00:00Z"`
+	FinishedAt  string  `json:"finishedAt,omitempty" example:"2024-03-01T10:02:13Z"`
+}
+
+// RevisionResponse is the state of a book after one change. ChangedBy is
+// left out when the request that made the change had no X-User.
+type RevisionResponse struct {
+	Revision  int                       `json:"revision" example:"3"`
+	Action    string                    `json:"action" example:"update"`
+	ChangedBy string                    `json:"changedBy,omitempty" example:"alice"`
+	ChangedAt string                    `json:"changedAt" example:"2024-03-01T10:00:00Z"`
+	Snapshot  CreateOrUpdateBookRequest `json:"snapshot"`
+}
+
+type PaginatedRevisionListResponse struct {
+	Page       int                `json:"page" example:"1"`
+	Limit      int                `json:"limit" example:"10"`
+	Total      int                `json:"total" example:"3"`
+	TotalPages int                `json:"totalPages" example:"1"`
+	Data       []RevisionResponse `json:"data"`
+}
+
+// FieldChangeResponse holds the values of a field in the two revisions, as
+// they appear in the snapshots.
+type FieldChangeResponse struct {
+	Field string      `json:"field" example:"title"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type RevisionDiffResponse struct {
+	From    int                   `json:"from" example:"1"`
+	To      int                   `json:"to" example:"3"`
+	Changes []FieldChangeResponse `json:"changes"`
+}
+
+type CreateOrUpdateCopyRequest struct {
+	Barcode    string `json:"barcode" validate:"required,min=1,max=64" example:"LIB-000123"`
+	AcquiredOn string `json:"acquiredOn" validate:"omitempty,datetime=2006-01-02" example:"2024-03-01"`
+	Condition  string `json:"condition" validate:"omitempty,oneof=new good fair poor damaged" example:"good"`
+	// Status cannot be on_loan, which only checkout sets.
+	Status     string `json:"status" validate:"omitempty,oneof=available lost in_repair" example:"available"`
+}
+
+type CopyResponse struct {
+	ID         int    `json:"id" example:"1"`
+	BookID     int    `json:"bookId" example:"1"`
+	Barcode    string `json:"barcode" example:"LIB-000123"`
+	AcquiredOn string `json:"acquiredOn" example:"2024-03-01"`
+	Condition  string `json:"condition" example:"good"`
+	Status     string `json:"status" example:"available"`
 }
@@ -1,8 +1,91 @@
 package book
 
+import "time"
+
 type Book struct {
 	ID          int    `sql:"id"`
 	Title       string `sql:"title"`
 	Author      string `sql:"author"`
 	Description string `sql:"description"`
+	ISBN        string `sql:"isbn"`
+	CreatedAt   time.Time `sql:"created_at"`
+	Genre         string `sql:"genre"`
+	// Language is an ISO 639 code such as "en".
+	Language      string `sql:"language"`
+	PublishedYear int    `sql:"published_year"`
+	// Version counts the updates of the book, starting at 1.
+	Version       int    `sql:"version"`
+	// DeletedAt is set while the book is in the trash.
+	DeletedAt     *time.Time `sql:"deleted_at"`
+	Authors     []BookAuthor
+	// TotalCopies counts every copy the library holds except lost ones.
+	TotalCopies     int
+	AvailableCopies int
+	NextDueOn       *time.Time
+}
+
+// BookAuthor links a book to an author record. Position orders the
+// contributors the way they are credited on the book.
+type BookAuthor struct {
+	AuthorID int    `sql:"author_id"`
+	Name     string `sql:"name"`
+	Role     string `sql:"role"`
+	Position int    `sql:"position"`
+}
+
+const (
+	RoleAuthor      = "author"
+	RoleEditor      = "editor"
+	RoleTranslator  = "translator"
+	RoleIllustrator = "illustrator"
+)
+
+// Revision is the state of a book after one change to it. Revisions are
+// numbered by the version the change produced.
+type Revision struct {
+	BookID    int    `sql:"book_id"`
+	Revision  int    `sql:"revision"`
+	Action    string `sql:"action"`
+	// Snapshot holds the book in the shape of a PUT body, so it can be
+	// reverted to.
+	Snapshot  CreateOrUpdateBookRequest `sql:"snapshot"`
+	// ChangedBy is the X-User of the request, empty when none was sent.
+	ChangedBy string    `sql:"changed_by"`
+	ChangedAt time.Time `sql:"changed_at"`
 }
+
+const (
+	RevisionCreate  = "create"
+	RevisionUpdate  = "update"
+	RevisionDelete  = "delete"
+	RevisionRestore = "restore"
+)
+
+// Copy is a physical item of a book, identified by the barcode on it.
+type Copy struct {
+	ID         int       `sql:"id"`
+	BookID     int       `sql:"book_id"`
+	Barcode    string    `sql:"barcode"`
+	AcquiredOn time.Time `sql:"acquired_on"`
+	Condition  string    `sql:"condition"`
+	Status     string    `sql:"status"`
+}
+
+const (
+	CopyStatusAvailable = "available"
+	CopyStatusOnLoan    = "on_loan"
+	CopyStatusLost      = "lost"
+	CopyStatusInRepair  = "in_repair"
+
+	// CopyStatusOnHold marks a copy set aside for the member at the head of
+	// the hold queue. Only the loan subsystem moves copies in and out of it.
+	CopyStatusOnHold = "on_hold"
+)
+
+const (
+	CopyConditionNew     = "new"
+	CopyConditionGood    = "good"
+	CopyConditionFair    = "fair"
+	CopyConditionPoor    = "poor"
+	CopyConditionDamaged = "damaged"
+)
@@ -28,6 +28,81 @@ var errorResponseMap = map[ErrorCode]*ErrorResponse{
 		ErrorCode:      BadRequest,
 		ErrorMessage:   "request is invalid.",
 	},
+	IsbnAlreadyExists: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      IsbnAlreadyExists,
+		ErrorMessage:   "a book with this isbn already exists",
+	},
+	CopyNotFound: {
+		HttpStatusCode: http.StatusNotFound,
+		ErrorCode:      CopyNotFound,
+		ErrorMessage:   "copy not found",
+	},
+	BarcodeAlreadyExists: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      BarcodeAlreadyExists,
+		ErrorMessage:   "a copy with this barcode already exists",
+	},
+	CopyInCirculation: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      CopyInCirculation,
+		ErrorMessage:   "the copy is on loan or set aside for a hold",
+	},
+	CopyHasLoans: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      CopyHasLoans,
+		ErrorMessage:   "the copy has been lent before and is kept for its loan history; mark it lost instead",
+	},
+	BookInCirculation: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      BookInCirculation,
+		ErrorMessage:   "the book has copies on loan or members waiting for it",
+	},
+	BookInTrash: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      BookInTrash,
+		ErrorMessage:   "the book is in the trash; restore it with POST /books/{id}/restore before replacing it",
+	},
+	PreconditionFailed: {
+		HttpStatusCode: http.StatusPreconditionFailed,
+		ErrorCode:      PreconditionFailed,
+		ErrorMessage:   "the book has changed since it was read; fetch it again and retry",
+	},
+	PreconditionRequired: {
+		HttpStatusCode: http.StatusPreconditionRequired,
+		ErrorCode:      PreconditionRequired,
+		ErrorMessage:   "an If-Match header with the ETag of the book is required",
+	},
+	PatchTestFailed: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      PatchTestFailed,
+		ErrorMessage:   "a test operation of the patch did not hold",
+	},
+	UnsupportedMediaType: {
+		HttpStatusCode: http.StatusUnsupportedMediaType,
+		ErrorCode:      UnsupportedMediaType,
+		ErrorMessage:   "patches must be application/merge-patch+json or application/json-patch+json",
+	},
+	RevisionNotFound: {
+		HttpStatusCode: http.StatusNotFound,
+		ErrorCode:      RevisionNotFound,
+		ErrorMessage:   "revision not found",
+	},
+	BatchAborted: {
+		HttpStatusCode: http.StatusFailedDependency,
+		ErrorCode:      BatchAborted,
+		ErrorMessage:   "not applied because another operation of the atomic batch failed",
+	},
+	ImportNotFound: {
+		HttpStatusCode: http.StatusNotFound,
+		ErrorCode:      ImportNotFound,
+		ErrorMessage:   "import not found",
+	},
+	SuggestTimeout: {
+		HttpStatusCode: http.StatusServiceUnavailable,
+		ErrorCode:      SuggestTimeout,
+		ErrorMessage:   "suggestions took too long, try a longer query",
+	},
 }
 
 func GetErrorResponseByCode(errCode ErrorCode) *ErrorResponse {
@@ -45,7 +120,22 @@ func GetErrorResponse(errCode ErrorCode,errorMessage string,statusCode int) *Err
 type ErrorCode string
 
 const (
-	BookNotFound        ErrorCode = "BOOK_NOT_FOUND"
-	InternalServerError ErrorCode = "INTERNAL_SERVER_ERROR"
-	BadRequest          ErrorCode = "BAD_REQUEST"
+	BookNotFound         ErrorCode = "BOOK_NOT_FOUND"
+	InternalServerError  ErrorCode = "INTERNAL_SERVER_ERROR"
+	BadRequest           ErrorCode = "BAD_REQUEST"
+	IsbnAlreadyExists    ErrorCode = "ISBN_ALREADY_EXISTS"
+	CopyNotFound         ErrorCode = "COPY_NOT_FOUND"
+	BarcodeAlreadyExists ErrorCode = "BARCODE_ALREADY_EXISTS"
+	CopyInCirculation    ErrorCode = "COPY_IN_CIRCULATION"
+	CopyHasLoans         ErrorCode = "COPY_HAS_LOANS"
+	BookInCirculation    ErrorCode = "BOOK_IN_CIRCULATION"
+	BookInTrash          ErrorCode = "BOOK_IN_TRASH"
+	SuggestTimeout       ErrorCode = "SUGGEST_TIMEOUT"
+	PreconditionFailed   ErrorCode = "PRECONDITION_FAILED"
+	PreconditionRequired ErrorCode = "PRECONDITION_REQUIRED"
+	PatchTestFailed      ErrorCode = "PATCH_TEST_FAILED"
+	UnsupportedMediaType ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	RevisionNotFound     ErrorCode = "REVISION_NOT_FOUND"
+	BatchAborted         ErrorCode = "BATCH_ABORTED"
+	ImportNotFound       ErrorCode = "IMPORT_NOT_FOUND"
 )
@@ -0,0 +1,61 @@
+package book
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ETag renders the version of a book as a strong entity tag.
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// Precondition is a parsed If-Match header: either * or a list of entity tags.
+type Precondition struct {
+	Any  bool
+	Tags []string
+}
+
+// ParseIfMatch reads an If-Match header, returning nil when there is none.
+func ParseIfMatch(header string) *Precondition {
+	if strings.TrimSpace(header) == "" {
+		return nil
+	}
+	p := &Precondition{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			p.Any = true
+			continue
+		}
+		if tag != "" {
+			p.Tags = append(p.Tags, tag)
+		}
+	}
+	return p
+}
+
+// Matches compares strongly, as If-Match requires, so weak tags never match.
+func (p Precondition) Matches(version int) bool {
+	if p.Any {
+		return true
+	}
+	for _, tag := range p.Tags {
+		if tag == ETag(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// noneMatchHit reports whether an If-None-Match header lists the version.
+// If-None-Match compares weakly, so W/ prefixes are ignored.
+func noneMatchHit(header string, version int) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == ETag(version) {
+			return true
+		}
+	}
+	return false
+}
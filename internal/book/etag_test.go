@@ -0,0 +1,22 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	require.Nil(t, book.ParseIfMatch("  "))
+	require.Equal(t, &book.Precondition{Tags: []string{`"1"`, `W/"2"`}}, book.ParseIfMatch(` "1" , W/"2"`))
+	require.Equal(t, &book.Precondition{Any: true}, book.ParseIfMatch("*"))
+}
+
+func TestPrecondition_ShouldCompareStrongly(t *testing.T) {
+	p := book.ParseIfMatch(`"1", W/"2"`)
+	require.True(t, p.Matches(1))
+	require.False(t, p.Matches(2))
+	require.False(t, p.Matches(3))
+	require.True(t, book.ParseIfMatch("*").Matches(3))
+}
@@ -0,0 +1,102 @@
+package book
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ExportCSV    = "csv"
+	ExportNDJSON = "ndjson"
+)
+
+// exportColumns are the columns of a CSV export. Those named like the fields
+// of a POST body are read back as they are by an import.
+var exportColumns = []string{
+	"id", "title", "author", "description", "isbn", "genre", "language", "publishedYear",
+	"authors", "availableCopies", "totalCopies", "createdAt",
+}
+
+// exportEncoder writes an export in one format. Nothing is guaranteed to
+// reach the underlying writer before Flush.
+type exportEncoder interface {
+	ContentType() string
+	Begin() error
+	Encode(b Book) error
+	Flush() error
+}
+
+// newExportEncoder returns the encoder of the format, or false if there is
+// no such format.
+func newExportEncoder(format string, w io.Writer) (exportEncoder, bool) {
+	switch format {
+	case ExportCSV:
+		return &csvExport{w: csv.NewWriter(w)}, true
+	case ExportNDJSON:
+		buf := bufio.NewWriter(w)
+		return &ndjsonExport{buf: buf, enc: json.NewEncoder(buf)}, true
+	}
+	return nil, false
+}
+
+// csvExport writes a header row and then a row per book. Linked authors are
+// listed by name in their order, separated by semicolons.
+type csvExport struct {
+	w *csv.Writer
+}
+
+func (e *csvExport) ContentType() string {
+	return "text/csv"
+}
+
+func (e *csvExport) Begin() error {
+	return e.w.Write(exportColumns)
+}
+
+func (e *csvExport) Encode(b Book) error {
+	names := make([]string, len(b.Authors))
+	for i, a := range b.Authors {
+		names[i] = a.Name
+	}
+	year := ""
+	if b.PublishedYear != 0 {
+		year = strconv.Itoa(b.PublishedYear)
+	}
+	return e.w.Write([]string{
+		strconv.Itoa(b.ID), b.Title, b.Author, b.Description, b.ISBN, b.Genre, b.Language, year,
+		strings.Join(names, "; "), strconv.Itoa(b.AvailableCopies), strconv.Itoa(b.TotalCopies),
+		b.CreatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+func (e *csvExport) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// ndjsonExport writes every book as a BookResponse on a line of its own.
+type ndjsonExport struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func (e *ndjsonExport) ContentType() string {
+	return "application/x-ndjson"
+}
+
+func (e *ndjsonExport) Begin() error {
+	return nil
+}
+
+func (e *ndjsonExport) Encode(b Book) error {
+	return e.enc.Encode(toBookResponse(b))
+}
+
+func (e *ndjsonExport) Flush() error {
+	return e.buf.Flush()
+}
@@ -0,0 +1,23 @@
+package book
+
+// maxFacetValues caps the values returned per facet; the rest are the long
+// tail nobody picks from.
+const maxFacetValues = 10
+
+// Facets counts the books matching a list or search per value of the fields
+// they can be narrowed by, most frequent values first.
+type Facets struct {
+	Authors   []FacetValue
+	Genres    []FacetValue
+	Languages []FacetValue
+	Decades   []FacetValue
+}
+
+// FacetValue is one choice of a facet. Value is what the filter parameter of
+// the facet takes; Label is the display name where the value is not one, such
+// as the name of an author whose value is the author id.
+type FacetValue struct {
+	Value string
+	Label string
+	Count int
+}
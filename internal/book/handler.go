@@ -1,12 +1,17 @@
 package book
 
 import (
+	"book-store/internal/stream"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
+	"mime"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
@@ -21,15 +26,35 @@ type BookHandler struct {
 }
 
 func NewBookHandler(s BookService) *BookHandler {
-	return &BookHandler{svc: s,val: *validator.New()}
+	return &BookHandler{svc: s,val: *NewValidator()}
+}
+
+// NewValidator validates book requests, including the custom isbn tag.
+func NewValidator() *validator.Validate {
+	val := validator.New()
+	val.RegisterValidation("isbn", validateISBN)
+	return val
 }
 
 // List godoc
 // @Summary      List books with pagination
-// @Description  Returns a paginated list of books
+// @Description  Returns a paginated list of books, optionally filtered and sorted. Dates are RFC 3339 timestamps or YYYY-MM-DD days. Passing cursor (empty for the first page) switches to keyset paging: the response is a CursorBookListResponse, page is ignored and the nextCursor of each response fetches the following page
 // @Tags         books
 // @Accept       json
 // @Produce      json
+// @Param        title          query     string  false  "Case-insensitive part of the title"
+// @Param        author         query     string  false  "Case-insensitive part of the byline or of a linked author's name"
+// @Param        authorId       query     int     false  "Only books linked to this author"
+// @Param        available      query     bool    false  "Only books with (true) or without (false) a copy on the shelf"
+// @Param        createdAfter   query     string  false  "Only books added at or after this time"
+// @Param        createdBefore  query     string  false  "Only books added before this time"
+// @Param        genre          query     string  false  "Only books of this genre, as listed in the genre facet"
+// @Param        language       query     string  false  "Only books in this language, an ISO 639 code such as en"
+// @Param        decade         query     int     false  "Only books published in the decade starting with this year, e.g. 1990"
+// @Param        facets         query     bool    false  "Also count the matching books per author, genre, language and decade (default false)"
+// @Param        sort           query     string  false  "Comma separated fields out of id, title, author and createdAt; prefix with - to sort descending, e.g. title,-createdAt"
+// @Param        cursor         query     string  false  "Opaque cursor from nextCursor; send it empty to start keyset paging"
+// @Param        withTotal      query     bool    false  "In cursor mode, also count the matching books (default false)"
 // @Param        page   query     int  false  "Page number (default 1)"    default(1)
 // @Param        limit  query     int  false  "Page size (1–100, default 10)" default(10)
 // @Success      200    {object}  PaginatedBookListResponse
@@ -37,6 +62,10 @@ func NewBookHandler(s BookService) *BookHandler {
 // @Router       /books [get]
 func (h *BookHandler) List(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
+	if q.Has("cursor") {
+		h.scroll(w, r)
+		return
+	}
 	page, pageConvErr := strconv.Atoi(q.Get("page"))
 	if pageConvErr!=nil{
 		logrus.Error("invalid page number provided ",q.Get("page"))
@@ -52,15 +81,25 @@ func (h *BookHandler) List(w http.ResponseWriter, r *http.Request) {
 	if page < 1 { page = 1 }
 	if limit < 1 || limit > 100 { limit = 10 }
 	offset := (page - 1) * limit
+	f, sort, errResp := parseListQuery(q)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	withFacets, errResp := parseFacetsFlag(q)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
 
-	books,totalCount, err := h.svc.List(r.Context(),limit,offset)
+	books,totalCount, err := h.svc.List(r.Context(), f, sort, limit, offset)
 	if err != nil {
 		sendError(w, *err)
 		return
 	}
 	out := make([]BookResponse, len(books))
 	for i, b := range books {
-		out[i] = BookResponse{ID: b.ID, Title: b.Title, Author: b.Author, Description: b.Description}
+		out[i] = toBookResponse(b)
 	}
 	p := PaginatedBookListResponse{
 		Page: page,
@@ -69,18 +108,303 @@ func (h *BookHandler) List(w http.ResponseWriter, r *http.Request) {
 		TotalPages: int(math.Ceil(float64(totalCount) / float64(limit))),
 		Data: out,
 	}
+	if withFacets {
+		facets, err := h.svc.Facets(r.Context(), f, "")
+		if err != nil {
+			sendError(w, *err)
+			return
+		}
+		p.Facets = toFacetsResponse(facets)
+	}
 	json.NewEncoder(w).Encode(p)
 }
 
+// scroll serves List in cursor mode. The sort may be left out after the first
+// page since the cursor carries it.
+func (h *BookHandler) scroll(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := 10
+	if v := q.Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil {
+			logrus.Error("invalid limit number provided ", v)
+			sendError(w, *GetErrorResponseByCode(BadRequest))
+			return
+		}
+		limit = l
+	}
+	limit = min(limit, maxLimit)
+	if limit < 1 {
+		limit = 10
+	}
+	withTotal := false
+	if v := q.Get("withTotal"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			logrus.Error("invalid withTotal provided ", v)
+			sendError(w, *GetErrorResponse(BadRequest, "withTotal must be true or false", http.StatusBadRequest))
+			return
+		}
+		withTotal = b
+	}
+	f, sort, errResp := parseListQuery(q)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	withFacets, errResp := parseFacetsFlag(q)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+
+	page, err := h.svc.Scroll(r.Context(), f, sort, q.Get("cursor"), limit, withTotal)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	out := make([]BookResponse, len(page.Books))
+	for i, b := range page.Books {
+		out[i] = toBookResponse(b)
+	}
+	resp := CursorBookListResponse{
+		Limit:      limit,
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+		Data:       out,
+	}
+	if withFacets {
+		facets, err := h.svc.Facets(r.Context(), f, "")
+		if err != nil {
+			sendError(w, *err)
+			return
+		}
+		resp.Facets = toFacetsResponse(facets)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Export godoc
+// @Summary      Export books
+// @Description  Streams every book matching the filters, as accepted by the book list, as CSV or as newline-delimited JSON with one BookResponse per line. All books come from one consistent snapshot of the catalog, however long the download takes. A failure after the download has started breaks off the connection, so a cut-off export never looks complete
+// @Tags         books
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        format         query     string  false  "csv (default) or ndjson"
+// @Param        title          query     string  false  "Case-insensitive part of the title"
+// @Param        author         query     string  false  "Case-insensitive part of the byline or of a linked author's name"
+// @Param        authorId       query     int     false  "Only books linked to this author"
+// @Param        available      query     bool    false  "Only books with (true) or without (false) a copy on the shelf"
+// @Param        createdAfter   query     string  false  "Only books added at or after this time"
+// @Param        createdBefore  query     string  false  "Only books added before this time"
+// @Param        genre          query     string  false  "Only books of this genre, as listed in the genre facet"
+// @Param        language       query     string  false  "Only books in this language, an ISO 639 code such as en"
+// @Param        decade         query     int     false  "Only books published in the decade starting with this year, e.g. 1990"
+// @Param        sort           query     string  false  "Comma separated fields out of id, title, author and createdAt; prefix with - to sort descending (default id)"
+// @Success      200    {string}  string
+// @Failure      400    {object}  ErrorResponse
+// @Router       /books/export [get]
+func (h *BookHandler) Export(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format := q.Get("format")
+	if format == "" {
+		format = ExportCSV
+	}
+	enc, ok := newExportEncoder(format, w)
+	if !ok {
+		logrus.Error("invalid export format provided ", format)
+		sendError(w, *GetErrorResponse(BadRequest, "format must be csv or ndjson", http.StatusBadRequest))
+		return
+	}
+	f, sort, errResp := parseListQuery(q)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	rc := http.NewResponseController(w)
+	stream.ExtendWriteDeadline(r, rc)
+	started := false
+	begin := func() error {
+		started = true
+		w.Header().Set("Content-Type", enc.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="books.%s"`, format))
+		return enc.Begin()
+	}
+	errResp = h.svc.Export(r.Context(), f, sort, func(books []Book) error {
+		if !started {
+			if err := begin(); err != nil {
+				return err
+			}
+		}
+		for _, b := range books {
+			if err := enc.Encode(b); err != nil {
+				return err
+			}
+		}
+		if err := enc.Flush(); err != nil {
+			return err
+		}
+		if err := rc.Flush(); err != nil {
+			return err
+		}
+		stream.ExtendWriteDeadline(r, rc)
+		return nil
+	})
+	if errResp != nil {
+		if started {
+			panic(http.ErrAbortHandler)
+		}
+		sendError(w, *errResp)
+		return
+	}
+	if !started {
+		begin()
+		enc.Flush()
+	}
+}
+
+// Search godoc
+// @Summary      Search books
+// @Description  Full-text search over title, author and description, most relevant first, narrowed by the same filters as the book list. All words must match; "quoted words" must appear as a phrase and a word ending in * matches as a prefix
+// @Tags         books
+// @Accept       json
+// @Produce      json
+// @Param        q      query     string  true   "Search terms, e.g. wizard pott*"
+// @Param        title          query     string  false  "Case-insensitive part of the title"
+// @Param        author         query     string  false  "Case-insensitive part of the byline or of a linked author's name"
+// @Param        authorId       query     int     false  "Only books linked to this author"
+// @Param        available      query     bool    false  "Only books with (true) or without (false) a copy on the shelf"
+// @Param        createdAfter   query     string  false  "Only books added at or after this time"
+// @Param        createdBefore  query     string  false  "Only books added before this time"
+// @Param        genre          query     string  false  "Only books of this genre, as listed in the genre facet"
+// @Param        language       query     string  false  "Only books in this language, an ISO 639 code such as en"
+// @Param        decade         query     int     false  "Only books published in the decade starting with this year, e.g. 1990"
+// @Param        facets         query     bool    false  "Also count the matching books per author, genre, language and decade (default false)"
+// @Param        page   query     int     false  "Page number (default 1)"    default(1)
+// @Param        limit  query     int     false  "Page size (1–100, default 10)" default(10)
+// @Success      200    {object}  PaginatedBookSearchResponse
+// @Failure      400    {object}  ErrorResponse
+// @Router       /books/search [get]
+func (h *BookHandler) Search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, limit := 1, 10
+	if v := q.Get("page"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil {
+			logrus.Error("invalid page number provided ", v)
+			sendError(w, *GetErrorResponseByCode(BadRequest))
+			return
+		}
+		page = max(p, 1)
+	}
+	if v := q.Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil {
+			logrus.Error("invalid limit number provided ", v)
+			sendError(w, *GetErrorResponseByCode(BadRequest))
+			return
+		}
+		if l >= 1 {
+			limit = min(l, maxLimit)
+		}
+	}
+	offset := (page - 1) * limit
+	f, errResp := parseFilter(q)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	withFacets, errResp := parseFacetsFlag(q)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+
+	results, totalCount, err := h.svc.Search(r.Context(), q.Get("q"), f, limit, offset)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	out := make([]BookSearchResult, len(results))
+	for i, s := range results {
+		out[i] = BookSearchResult{
+			BookResponse: toBookResponse(s.Book),
+			Rank:         s.Rank,
+			Highlights:   SearchHighlights{Title: s.TitleHighlight, Description: s.DescriptionHighlight},
+		}
+	}
+	resp := PaginatedBookSearchResponse{
+		Page:       page,
+		Limit:      limit,
+		Total:      totalCount,
+		TotalPages: int(math.Ceil(float64(totalCount) / float64(limit))),
+		Data:       out,
+	}
+	if withFacets {
+		facets, err := h.svc.Facets(r.Context(), f, q.Get("q"))
+		if err != nil {
+			sendError(w, *err)
+			return
+		}
+		resp.Facets = toFacetsResponse(facets)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Suggest godoc
+// @Summary      Suggest books
+// @Description  Fuzzy matches partial or misspelled input against titles and authors, best match first, for autocomplete
+// @Tags         books
+// @Accept       json
+// @Produce      json
+// @Param        q      query     string  true   "Typed input, at least 3 characters, e.g. hary poter"
+// @Param        limit  query     int     false  "Number of suggestions (1–20, default 5)" default(5)
+// @Success      200    {object}  BookSuggestionsResponse
+// @Failure      400    {object}  ErrorResponse
+// @Failure      503    {object}  ErrorResponse
+// @Router       /books/suggest [get]
+func (h *BookHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := 5
+	if v := q.Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil {
+			logrus.Error("invalid limit number provided ", v)
+			sendError(w, *GetErrorResponseByCode(BadRequest))
+			return
+		}
+		if l >= 1 {
+			limit = min(l, maxSuggestLimit)
+		}
+	}
+
+	suggestions, err := h.svc.Suggest(r.Context(), q.Get("q"), limit)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	out := make([]BookSuggestion, len(suggestions))
+	for i, s := range suggestions {
+		out[i] = BookSuggestion{ID: s.ID, Title: s.Title, Author: s.Author, Score: s.Score}
+	}
+	json.NewEncoder(w).Encode(BookSuggestionsResponse{Data: out})
+}
 
 // Get godoc
 // @Summary      Get book by ID
-// @Description  Retrieve a single book by its ID
+// @Description  Retrieve a single book by its ID. The ETag header carries the version of the book; sending it back in If-None-Match answers 304 while the book is unchanged. The book is rendered as a BibTeX, RIS or CSL-JSON citation instead when the format parameter or the Accept header asks for one
 // @Tags         books
 // @Accept       json
 // @Produce      json
+// @Produce      application/x-bibtex
+// @Produce      application/x-research-info-systems
+// @Produce      application/vnd.citationstyles.csl+json
 // @Param        id     path      int   true   "Book ID"
+// @Param        format query     string  false  "json (default), bibtex, ris or csl-json; overrides the Accept header"
+// @Param        If-None-Match  header  string  false  "ETag of a copy the client already holds"
 // @Success      200    {object}  BookResponse
+// @Header       200    {string}  ETag  "Version of the book as a quoted number"
+// @Success      304    {object}  nil
 // @Failure      400    {object}  ErrorResponse
 // @Failure      404    {object}  ErrorResponse
 // @Router       /books/{id} [get]
@@ -91,12 +415,43 @@ func (h *BookHandler) Get(w http.ResponseWriter, r *http.Request) {
 		sendError(w, *GetErrorResponseByCode(BadRequest))
 		return
 	}
+	w.Header().Set("Vary", "Accept")
+	format, ok := bookFormat(r)
+	if !ok {
+		logrus.Error("invalid book format provided ", format)
+		sendError(w, *GetErrorResponse(BadRequest, "format must be json, bibtex, ris or csl-json", http.StatusBadRequest))
+		return
+	}
 	b, err := h.svc.Get(r.Context(), id)
 	if err != nil {
 		sendError(w, *err)
 		return
 	}
-	json.NewEncoder(w).Encode(BookResponse{ID: b.ID, Title: b.Title, Author: b.Author, Description: b.Description})
+	if format != formatJSON {
+		writeCitations(w, format, []Book{b})
+		return
+	}
+	writeBook(w, r, b)
+}
+
+// GetByISBN godoc
+// @Summary      Get book by ISBN
+// @Description  Retrieve a single book by its ISBN-10 or ISBN-13, hyphens allowed
+// @Tags         books
+// @Accept       json
+// @Produce      json
+// @Param        isbn   path      string  true   "ISBN-10 or ISBN-13"
+// @Success      200    {object}  BookResponse
+// @Failure      400    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Router       /books/isbn/{isbn} [get]
+func (h *BookHandler) GetByISBN(w http.ResponseWriter, r *http.Request) {
+	b, err := h.svc.GetByISBN(r.Context(), mux.Vars(r)["isbn"])
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	writeBook(w, r, b)
 }
 
 
@@ -110,6 +465,7 @@ func (h *BookHandler) Get(w http.ResponseWriter, r *http.Request) {
 // @Success      201    {object}  nil
 // @Header       201    {string}  Location  "URL of created book"
 // @Failure      400    {object}  ErrorResponse
+// @Failure      409    {object}  ErrorResponse
 // @Router       /books [post]
 func (h *BookHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req CreateOrUpdateBookRequest
@@ -119,12 +475,7 @@ func (h *BookHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
     if err := h.val.Struct(&req); err != nil {
-        var errs []string
-        for _, fe := range err.(validator.ValidationErrors) {
-            errs = append(errs, fmt.Sprintf("%s failed on '%s'", fe.Field(), fe.Tag()))
-        }
-		logrus.Error("error while validating the request. error is ",errs)
-        sendError(w, *GetErrorResponse(BadRequest, strings.Join(errs, "; "), http.StatusBadRequest))
+        sendError(w, *validationErrorResponse(err))
         return
     }
 
@@ -137,23 +488,71 @@ func (h *BookHandler) Create(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// Batch godoc
+// @Summary      Create, update and delete books in bulk
+// @Description  Applies a list of create, update and delete operations, each checked like the single-book endpoint would check it; updates and deletes need the ETag of the book in ifMatch. In atomic mode, the default, the operations run in one transaction and nothing is kept unless all of them succeed, the others then being reported as BATCH_ABORTED. In bestEffort mode every operation that succeeds is kept. The response lists the status of each operation in order
+// @Tags         books
+// @Accept       json
+// @Produce      json
+// @Param        batch  body      BatchRequest  true  "Operations (at most 500)"
+// @Success      200    {object}  BatchResponse
+// @Failure      400    {object}  ErrorResponse
+// @Router       /books:batch [post]
+func (h *BookHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	var req BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, *GetErrorResponseByCode(BadRequest))
+		return
+	}
+	if err := h.val.Struct(&req); err != nil {
+		sendError(w, *validationErrorResponse(err))
+		return
+	}
+	ops := make([]BatchOperation, len(req.Operations))
+	for i, item := range req.Operations {
+		ops[i] = BatchOperation{Action: item.Op, ID: item.ID, IfMatch: ParseIfMatch(item.IfMatch)}
+		if item.Book != nil {
+			ops[i].Book = *item.Book
+		}
+		if err := h.val.Struct(&item); err != nil {
+			ops[i].Rejected = validationErrorResponse(err)
+		}
+	}
+	results := h.svc.Batch(r.Context(), ops, req.Mode != BatchModeBestEffort)
+	out := make([]BatchItemResponse, len(results))
+	for i, res := range results {
+		out[i] = BatchItemResponse{Index: i, Status: res.Status, ID: res.ID, Error: res.Err}
+		if res.Err == nil && res.Version > 0 {
+			out[i].ETag = ETag(res.Version)
+		}
+	}
+	json.NewEncoder(w).Encode(BatchResponse{Results: out})
+}
+
 // Update godoc
-// @Summary      Update or create book by ID
-// @Description  Update existing book or create if not exists
+// @Summary      Replace or create book by ID
+// @Description  Replace existing book or create it at the given id if it does not exist. The body replaces the whole book, so fields left out are cleared; use PATCH to change some fields only. Replacing requires the ETag of the book in If-Match, so edits based on an outdated copy are refused. A book in the trash is refused with 409 until it is restored. A replaced book is returned only when asked for with Prefer: return=representation
 // @Tags         books
 // @Accept       json
 // @Produce      json
 // @Param        id    path      int                        true  "Book ID"
+// @Param        If-Match  header  string                 false "ETag of the book being updated; required unless the book is created"
+// @Param        Prefer    header  string                 false "return=representation to get the replaced book back"
 // @Param        book  body      CreateOrUpdateBookRequest  true  "Book data"
+// @Success      200    {object}  BookResponse
+// @Success      201    {object}  BookResponse
 // @Success      204    {object}  nil
-// @Header       204    {string}  Location  "Optional new resource URL"
+// @Header       201    {string}  Location  "URL of created book"
+// @Header       200,201,204    {string}  ETag  "New version of the book"
 // @Failure      400    {object}  ErrorResponse
-// @Failure      404    {object}  ErrorResponse
+// @Failure      409    {object}  ErrorResponse
+// @Failure      412    {object}  ErrorResponse
+// @Failure      428    {object}  ErrorResponse
 // @Router       /books/{id} [put]
 func (h *BookHandler) Update(w http.ResponseWriter, r *http.Request) {
 	var req CreateOrUpdateBookRequest
 	id, cErr := strconv.Atoi(mux.Vars(r)["id"])
-	if cErr != nil {
+	if cErr != nil || id < 1 {
 		logrus.Error("invalid book id provided ",mux.Vars(r)["id"])
 		sendError(w, *GetErrorResponseByCode(BadRequest))
 		return
@@ -162,27 +561,102 @@ func (h *BookHandler) Update(w http.ResponseWriter, r *http.Request) {
 		sendError(w, *GetErrorResponseByCode(BadRequest))
 		return
 	}
-	bId, err := h.svc.CreateOrUpdate(r.Context(), id, req)
+	if err := h.val.Struct(&req); err != nil {
+		sendError(w, *validationErrorResponse(err))
+		return
+	}
+	b, created, err := h.svc.CreateOrUpdate(r.Context(), id, req, ParseIfMatch(r.Header.Get("If-Match")))
 	if err != nil {
 		sendError(w, *err)
 		return
 	}
-	if bId != 0 {
-		w.Header().Set("location", fmt.Sprintf("%s/%d", "/books", bId))
+	w.Header().Set("ETag", ETag(b.Version))
+	switch {
+	case created:
+		w.Header().Set("location", fmt.Sprintf("%s/%d", "/books", b.ID))
+		w.WriteHeader(http.StatusCreated)
+	case prefersRepresentation(r):
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	json.NewEncoder(w).Encode(toBookResponse(b))
+}
+
+// prefersRepresentation reports whether the client sent
+// Prefer: return=representation (RFC 7240).
+func prefersRepresentation(r *http.Request) bool {
+	for _, prefer := range r.Header.Values("Prefer") {
+		for _, p := range strings.Split(prefer, ",") {
+			if strings.EqualFold(strings.TrimSpace(p), "return=representation") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Patch godoc
+// @Summary      Patch book by ID
+// @Description  Change some fields of a book with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902), chosen by Content-Type. The patch applies to the book as a CreateOrUpdateBookRequest and the result must be valid as one. Requires the ETag of the book in If-Match
+// @Tags         books
+// @Accept       application/merge-patch+json
+// @Accept       application/json-patch+json
+// @Produce      json
+// @Param        id        path    int     true  "Book ID"
+// @Param        If-Match  header  string  true  "ETag of the book being patched"
+// @Param        patch     body    object  true  "Merge patch object or array of JSON Patch operations"
+// @Success      204    {object}  nil
+// @Header       204    {string}  ETag  "New version of the book"
+// @Failure      400    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Failure      409    {object}  ErrorResponse
+// @Failure      412    {object}  ErrorResponse
+// @Failure      415    {object}  ErrorResponse
+// @Failure      428    {object}  ErrorResponse
+// @Router       /books/{id} [patch]
+func (h *BookHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	id, convErr := strconv.Atoi(mux.Vars(r)["id"])
+	if convErr != nil {
+		logrus.Error("invalid book id provided ", mux.Vars(r)["id"])
+		sendError(w, *GetErrorResponseByCode(BadRequest))
+		return
+	}
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if contentType != MergePatchContentType && contentType != JSONPatchContentType {
+		logrus.Error("unsupported patch content type ", r.Header.Get("Content-Type"))
+		sendError(w, *GetErrorResponseByCode(UnsupportedMediaType))
+		return
+	}
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		sendError(w, *GetErrorResponseByCode(BadRequest))
+		return
+	}
+	version, errResp := h.svc.Patch(r.Context(), id, contentType, patch, ParseIfMatch(r.Header.Get("If-Match")))
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
 	}
+	w.Header().Set("ETag", ETag(version))
 	w.WriteHeader(http.StatusNoContent)
 }
 
 // Delete godoc
 // @Summary      Delete book by ID
-// @Description  Remove a book record
+// @Description  Move a book to the trash, from where it can be restored until it is purged. Requires the ETag of the book in If-Match. Books that do not exist or are already in the trash give 404. A book with copies on loan or with members waiting for it gives 409
 // @Tags         books
 // @Accept       json
 // @Produce      json
 // @Param        id    path      int   true   "Book ID"
+// @Param        If-Match  header  string  true  "ETag of the book being deleted"
 // @Success      204    {object}  nil
 // @Failure      400    {object}  ErrorResponse
 // @Failure      404    {object}  ErrorResponse
+// @Failure      409    {object}  ErrorResponse
+// @Failure      412    {object}  ErrorResponse
+// @Failure      428    {object}  ErrorResponse
 // @Router       /books/{id} [delete]
 func (h *BookHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id, convErr := strconv.Atoi(mux.Vars(r)["id"])
@@ -191,7 +665,7 @@ func (h *BookHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		sendError(w, *GetErrorResponseByCode(BadRequest))
 		return
 	}
-	err := h.svc.Delete(r.Context(), id)
+	err := h.svc.Delete(r.Context(), id, ParseIfMatch(r.Header.Get("If-Match")))
 	if err != nil {
 		sendError(w, *err)
 		return
@@ -199,6 +673,413 @@ func (h *BookHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ListTrash godoc
+// @Summary      List trashed books
+// @Description  Returns a paginated list of the deleted books that have not been purged yet, most recently deleted first
+// @Tags         books
+// @Produce      json
+// @Param        page   query     int  false  "Page number (default 1)"    default(1)
+// @Param        limit  query     int  false  "Page size (1–100, default 10)" default(10)
+// @Success      200    {object}  PaginatedBookListResponse
+// @Failure      400    {object}  ErrorResponse
+// @Router       /trash/books [get]
+func (h *BookHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	page, limit, errResp := parsePage(r.URL.Query())
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	books, total, errResp := h.svc.ListTrash(r.Context(), limit, (page-1)*limit)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	out := make([]BookResponse, len(books))
+	for i, b := range books {
+		out[i] = toBookResponse(b)
+	}
+	json.NewEncoder(w).Encode(PaginatedBookListResponse{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+		Data:       out,
+	})
+}
+
+// Restore godoc
+// @Summary      Restore book from the trash
+// @Description  Takes a deleted book out of the trash. Fails with 409 when another book has taken its isbn in the meantime
+// @Tags         books
+// @Produce      json
+// @Param        id    path      int   true   "Book ID"
+// @Success      200    {object}  BookResponse
+// @Header       200    {string}  ETag  "New version of the book"
+// @Failure      400    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Failure      409    {object}  ErrorResponse
+// @Router       /books/{id}/restore [post]
+func (h *BookHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id, convErr := strconv.Atoi(mux.Vars(r)["id"])
+	if convErr != nil {
+		logrus.Error("invalid book id provided ", mux.Vars(r)["id"])
+		sendError(w, *GetErrorResponseByCode(BadRequest))
+		return
+	}
+	b, errResp := h.svc.Restore(r.Context(), id)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	w.Header().Set("ETag", ETag(b.Version))
+	json.NewEncoder(w).Encode(toBookResponse(b))
+}
+
+// ListRevisions godoc
+// @Summary      List book revisions
+// @Description  Returns a paginated list of the revisions of a book, newest first. A revision is recorded for every create, update, delete and restore, numbered by the version it produced, with the X-User of the request that made it
+// @Tags         books
+// @Produce      json
+// @Param        id     path      int  true   "Book ID"
+// @Param        page   query     int  false  "Page number (default 1)"    default(1)
+// @Param        limit  query     int  false  "Page size (1–100, default 10)" default(10)
+// @Success      200    {object}  PaginatedRevisionListResponse
+// @Failure      400    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Router       /books/{id}/revisions [get]
+func (h *BookHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	id, convErr := strconv.Atoi(mux.Vars(r)["id"])
+	if convErr != nil {
+		logrus.Error("invalid book id provided ", mux.Vars(r)["id"])
+		sendError(w, *GetErrorResponseByCode(BadRequest))
+		return
+	}
+	page, limit, errResp := parsePage(r.URL.Query())
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	revisions, total, errResp := h.svc.ListRevisions(r.Context(), id, limit, (page-1)*limit)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	out := make([]RevisionResponse, len(revisions))
+	for i, rev := range revisions {
+		out[i] = toRevisionResponse(rev)
+	}
+	json.NewEncoder(w).Encode(PaginatedRevisionListResponse{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+		Data:       out,
+	})
+}
+
+// GetRevision godoc
+// @Summary      Get book revision
+// @Description  Returns one revision of a book with a snapshot of the book in the shape of a PUT body
+// @Tags         books
+// @Produce      json
+// @Param        id   path      int  true  "Book ID"
+// @Param        rev  path      int  true  "Revision number"
+// @Success      200  {object}  RevisionResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /books/{id}/revisions/{rev} [get]
+func (h *BookHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	id, rev, errResp := parseRevisionPath(r)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	revision, errResp := h.svc.GetRevision(r.Context(), id, rev)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	json.NewEncoder(w).Encode(toRevisionResponse(revision))
+}
+
+// DiffRevisions godoc
+// @Summary      Diff book revisions
+// @Description  Lists the fields whose values differ between two revisions of a book, in the order of the PUT body
+// @Tags         books
+// @Produce      json
+// @Param        id    path      int  true  "Book ID"
+// @Param        from  query     int  true  "Revision to compare from"
+// @Param        to    query     int  true  "Revision to compare to"
+// @Success      200   {object}  RevisionDiffResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Router       /books/{id}/revisions/diff [get]
+func (h *BookHandler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	id, convErr := strconv.Atoi(mux.Vars(r)["id"])
+	if convErr != nil {
+		logrus.Error("invalid book id provided ", mux.Vars(r)["id"])
+		sendError(w, *GetErrorResponseByCode(BadRequest))
+		return
+	}
+	q := r.URL.Query()
+	from, err := strconv.Atoi(q.Get("from"))
+	if err != nil {
+		logrus.Error("invalid from revision provided ", q.Get("from"))
+		sendError(w, *GetErrorResponse(BadRequest, "from must be a revision number", http.StatusBadRequest))
+		return
+	}
+	to, err := strconv.Atoi(q.Get("to"))
+	if err != nil {
+		logrus.Error("invalid to revision provided ", q.Get("to"))
+		sendError(w, *GetErrorResponse(BadRequest, "to must be a revision number", http.StatusBadRequest))
+		return
+	}
+	changes, errResp := h.svc.DiffRevisions(r.Context(), id, from, to)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	out := make([]FieldChangeResponse, len(changes))
+	for i, c := range changes {
+		out[i] = FieldChangeResponse{Field: c.Field, From: c.From, To: c.To}
+	}
+	json.NewEncoder(w).Encode(RevisionDiffResponse{From: from, To: to, Changes: out})
+}
+
+// Revert godoc
+// @Summary      Revert book to a revision
+// @Description  Replaces the book with the snapshot of one of its revisions, as a PUT of that snapshot would, and records the result as a new revision. Requires the ETag of the book in If-Match
+// @Tags         books
+// @Produce      json
+// @Param        id        path    int     true  "Book ID"
+// @Param        rev       path    int     true  "Revision number"
+// @Param        If-Match  header  string  true  "ETag of the book being reverted"
+// @Success      204    {object}  nil
+// @Header       204    {string}  ETag  "New version of the book"
+// @Failure      400    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Failure      409    {object}  ErrorResponse
+// @Failure      412    {object}  ErrorResponse
+// @Failure      428    {object}  ErrorResponse
+// @Router       /books/{id}/revisions/{rev}/revert [post]
+func (h *BookHandler) Revert(w http.ResponseWriter, r *http.Request) {
+	id, rev, errResp := parseRevisionPath(r)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	version, errResp := h.svc.Revert(r.Context(), id, rev, ParseIfMatch(r.Header.Get("If-Match")))
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	w.Header().Set("ETag", ETag(version))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseRevisionPath(r *http.Request) (int, int, *ErrorResponse) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		logrus.Error("invalid book id provided ", vars["id"])
+		return 0, 0, GetErrorResponseByCode(BadRequest)
+	}
+	rev, err := strconv.Atoi(vars["rev"])
+	if err != nil {
+		logrus.Error("invalid revision provided ", vars["rev"])
+		return 0, 0, GetErrorResponseByCode(BadRequest)
+	}
+	return id, rev, nil
+}
+
+func toRevisionResponse(rev Revision) RevisionResponse {
+	snapshot := rev.Snapshot
+	if snapshot.Authors == nil {
+		snapshot.Authors = []BookAuthorRequest{}
+	}
+	return RevisionResponse{
+		Revision:  rev.Revision,
+		Action:    rev.Action,
+		ChangedBy: rev.ChangedBy,
+		ChangedAt: rev.ChangedAt.UTC().Format(time.RFC3339),
+		Snapshot:  snapshot,
+	}
+}
+
+// parsePage reads the page and limit of an offset-paginated list.
+func parsePage(q url.Values) (int, int, *ErrorResponse) {
+	page, limit := 1, 10
+	if v := q.Get("page"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil {
+			logrus.Error("invalid page number provided ", v)
+			return 0, 0, GetErrorResponseByCode(BadRequest)
+		}
+		page = max(p, 1)
+	}
+	if v := q.Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil {
+			logrus.Error("invalid limit number provided ", v)
+			return 0, 0, GetErrorResponseByCode(BadRequest)
+		}
+		if l >= 1 {
+			limit = min(l, maxLimit)
+		}
+	}
+	return page, limit, nil
+}
+
+// writeBook sends a single book with its ETag, or 304 when the client's
+// If-None-Match shows it already holds this version.
+func writeBook(w http.ResponseWriter, r *http.Request, b Book) {
+	w.Header().Set("ETag", ETag(b.Version))
+	if inm := r.Header.Get("If-None-Match"); inm != "" && noneMatchHit(inm, b.Version) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	json.NewEncoder(w).Encode(toBookResponse(b))
+}
+
+func toBookResponse(b Book) BookResponse {
+	authors := make([]BookAuthorResponse, len(b.Authors))
+	for i, a := range b.Authors {
+		authors[i] = BookAuthorResponse{ID: a.AuthorID, Name: a.Name, Role: a.Role, Position: a.Position}
+	}
+	resp := BookResponse{
+		ID:          b.ID,
+		Title:       b.Title,
+		Author:      b.Author,
+		Description: b.Description,
+		ISBN13:      b.ISBN,
+		ISBN10:      ISBN10(b.ISBN),
+		CreatedAt:   b.CreatedAt.UTC().Format(time.RFC3339),
+		Genre:         b.Genre,
+		Language:      b.Language,
+		PublishedYear: b.PublishedYear,
+		Authors:     authors,
+		AvailableCopies: b.AvailableCopies,
+		TotalCopies:     b.TotalCopies,
+		Available:       b.AvailableCopies > 0,
+	}
+	if b.NextDueOn != nil {
+		resp.NextDueOn = b.NextDueOn.Format(time.DateOnly)
+	}
+	if b.DeletedAt != nil {
+		resp.DeletedAt = b.DeletedAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+// parseListQuery reads the filter and sort parameters of the book list.
+func parseListQuery(q url.Values) (BookFilter, []SortField, *ErrorResponse) {
+	f, errResp := parseFilter(q)
+	if errResp != nil {
+		return BookFilter{}, nil, errResp
+	}
+	sort, err := ParseSort(q.Get("sort"))
+	if err != nil {
+		logrus.Error("invalid sort provided ", q.Get("sort"))
+		return BookFilter{}, nil, GetErrorResponse(BadRequest, err.Error(), http.StatusBadRequest)
+	}
+	return f, sort, nil
+}
+
+// parseFilter reads the filter parameters shared by the book list and search.
+func parseFilter(q url.Values) (BookFilter, *ErrorResponse) {
+	f := BookFilter{
+		Title:    strings.TrimSpace(q.Get("title")),
+		Author:   strings.TrimSpace(q.Get("author")),
+		Genre:    strings.TrimSpace(q.Get("genre")),
+		Language: strings.ToLower(strings.TrimSpace(q.Get("language"))),
+	}
+	badRequest := func(param, value string) (BookFilter, *ErrorResponse) {
+		logrus.Error("invalid ", param, " provided ", value)
+		return BookFilter{}, GetErrorResponse(BadRequest, fmt.Sprintf("invalid %s '%s'", param, value), http.StatusBadRequest)
+	}
+	if v := q.Get("authorId"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil || id < 1 {
+			return badRequest("authorId", v)
+		}
+		f.AuthorID = id
+	}
+	if v := q.Get("available"); v != "" {
+		available, err := strconv.ParseBool(v)
+		if err != nil {
+			return badRequest("available", v)
+		}
+		f.Available = &available
+	}
+	for param, dst := range map[string]**time.Time{"createdAfter": &f.CreatedAfter, "createdBefore": &f.CreatedBefore} {
+		v := q.Get(param)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			if t, err = time.Parse(time.DateOnly, v); err != nil {
+				return badRequest(param, v)
+			}
+		}
+		*dst = &t
+	}
+	if v := q.Get("decade"); v != "" {
+		decade, err := strconv.Atoi(strings.TrimSuffix(v, "s"))
+		if err != nil || decade < 1 || decade%10 != 0 {
+			return badRequest("decade", v)
+		}
+		f.Decade = decade
+	}
+	return f, nil
+}
+
+// parseFacetsFlag reads whether the facets parameter asks for facet counts.
+func parseFacetsFlag(q url.Values) (bool, *ErrorResponse) {
+	v := q.Get("facets")
+	if v == "" {
+		return false, nil
+	}
+	withFacets, err := strconv.ParseBool(v)
+	if err != nil {
+		logrus.Error("invalid facets provided ", v)
+		return false, GetErrorResponse(BadRequest, "facets must be true or false", http.StatusBadRequest)
+	}
+	return withFacets, nil
+}
+
+func toFacetsResponse(f Facets) *FacetsResponse {
+	values := func(vs []FacetValue) []FacetValueResponse {
+		out := make([]FacetValueResponse, len(vs))
+		for i, v := range vs {
+			out[i] = FacetValueResponse{Value: v.Value, Label: v.Label, Count: v.Count}
+		}
+		return out
+	}
+	return &FacetsResponse{
+		AuthorID: values(f.Authors),
+		Genre:    values(f.Genres),
+		Language: values(f.Languages),
+		Decade:   values(f.Decades),
+	}
+}
+
+func validationErrorResponse(err error) *ErrorResponse {
+	msg := ValidationMessage(err)
+	logrus.Error("error while validating the request. error is ", msg)
+	return GetErrorResponse(BadRequest, msg, http.StatusBadRequest)
+}
+
+// ValidationMessage names every field that failed validation and the rule
+// it broke.
+func ValidationMessage(err error) string {
+	var errs []string
+	for _, fe := range err.(validator.ValidationErrors) {
+		errs = append(errs, fmt.Sprintf("%s failed on '%s'", fe.Field(), fe.Tag()))
+	}
+	return strings.Join(errs, "; ")
+}
+
 func sendError(w http.ResponseWriter, errResponse ErrorResponse) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(errResponse.HttpStatusCode)
@@ -4,12 +4,15 @@ import (
 	"book-store/internal/book"
 	mock_book "book-store/internal/mocks"
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"math"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
@@ -47,7 +50,7 @@ func (m *BookHandlerTestSuite) TestList() {
     req,_ := http.NewRequest("GET", "/books?page=1&limit=5", nil)
     w := httptest.NewRecorder()
 
-	m.mockService.EXPECT().List(req.Context(),5,0).Return(books,2,nil)
+	m.mockService.EXPECT().List(req.Context(), book.BookFilter{}, nil, 5, 0).Return(books,2,nil)
 
     m.bookHandler.List(w, req)
 
@@ -92,7 +95,7 @@ func (m *BookHandlerTestSuite) TestList_ShouldSetLimitToThresholdWhenLimitPassed
     req,_ := http.NewRequest("GET", "/books?page=1&limit=500", nil)
     w := httptest.NewRecorder()
 
-	m.mockService.EXPECT().List(req.Context(),100,0).Return(books,2,nil)
+	m.mockService.EXPECT().List(req.Context(), book.BookFilter{}, nil, 100, 0).Return(books,2,nil)
 
     m.bookHandler.List(w, req)
 
@@ -131,7 +134,7 @@ func (m *BookHandlerTestSuite) TestList_ShouldSetLimitToThresholdWhenLimitPassed
 func (m *BookHandlerTestSuite) TestList_ShouldThrowErrorWhenServiceReturnsError() {
     internalServerErr := book.GetErrorResponseByCode(book.InternalServerError)
     req := httptest.NewRequest("GET", "/books?page=1&limit=5", nil)
-	m.mockService.EXPECT().List(req.Context(),5,0).Return(nil,0,internalServerErr)
+	m.mockService.EXPECT().List(req.Context(), book.BookFilter{}, nil, 5, 0).Return(nil,0,internalServerErr)
 	w := httptest.NewRecorder()
     m.bookHandler.List(w, req)
 
@@ -235,11 +238,32 @@ func (m *BookHandlerTestSuite) TestUpdate() {
 
 	r, _ := http.NewRequest("GET", "/books", bytes.NewBuffer(responseBytes))
 	r = mux.SetURLVars(r, map[string]string{"id": "12"})
-	m.mockService.EXPECT().CreateOrUpdate(r.Context(), 12, b).Return(int64(0), nil)
+	r.Header.Set("If-Match", `"3"`)
+	m.mockService.EXPECT().CreateOrUpdate(r.Context(), 12, b, &book.Precondition{Tags: []string{`"3"`}}).Return(book.Book{ID: 12, Version: 4}, false, nil)
 	w := httptest.NewRecorder()
 
 	m.bookHandler.Update(w, r)
 	m.Suite.Equal(204, w.Result().StatusCode)
+	m.Suite.Equal(`"4"`, w.Result().Header.Get("ETag"))
+	m.Suite.Empty(w.Body.String())
+}
+
+func (m *BookHandlerTestSuite) TestUpdate_ShouldReturnTheBookWhenPreferred() {
+	b := book.CreateOrUpdateBookRequest{Title: "Harry Potter", Author: "JK Rolling"}
+	body, _ := json.Marshal(b)
+	r, _ := http.NewRequest("PUT", "/books/12", bytes.NewBuffer(body))
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	r.Header.Set("If-Match", `"3"`)
+	r.Header.Set("Prefer", "handling=strict, return=representation")
+	m.mockService.EXPECT().CreateOrUpdate(r.Context(), 12, b, &book.Precondition{Tags: []string{`"3"`}}).
+		Return(book.Book{ID: 12, Title: "Harry Potter", Author: "JK Rolling", Version: 4}, false, nil)
+	w := httptest.NewRecorder()
+
+	m.bookHandler.Update(w, r)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	var resp book.BookResponse
+	m.Suite.Nil(json.NewDecoder(w.Body).Decode(&resp))
+	m.Suite.Equal("Harry Potter", resp.Title)
 }
 
 func (m *BookHandlerTestSuite) TestUpdate_ShouldAddLocationHeaderWhenNewBookIsCreated() {
@@ -253,12 +277,17 @@ func (m *BookHandlerTestSuite) TestUpdate_ShouldAddLocationHeaderWhenNewBookIsCr
 
 	r, _ := http.NewRequest("GET", "/books", bytes.NewBuffer(responseBytes))
 	r = mux.SetURLVars(r, map[string]string{"id": "12"})
-	m.mockService.EXPECT().CreateOrUpdate(r.Context(), 12, b).Return(int64(12), nil)
+	m.mockService.EXPECT().CreateOrUpdate(r.Context(), 12, b, nil).
+		Return(book.Book{ID: 12, Title: "Harry Potter", Author: "JK Rolling", Version: 1}, true, nil)
 	w := httptest.NewRecorder()
 
 	m.bookHandler.Update(w, r)
-	m.Suite.Equal(204, w.Result().StatusCode)
+	m.Suite.Equal(201, w.Result().StatusCode)
 	m.Suite.Equal("/books/12", w.Result().Header.Get("Location"))
+	m.Suite.Equal(`"1"`, w.Result().Header.Get("ETag"))
+	var resp book.BookResponse
+	m.Suite.Nil(json.NewDecoder(w.Body).Decode(&resp))
+	m.Suite.Equal(12, resp.ID)
 }
 
 func (m *BookHandlerTestSuite) TestUpdate_ShouldThrowErrorWhenBookIdIsInvalid() {
@@ -291,7 +320,7 @@ func (m *BookHandlerTestSuite) TestUpdate_ShouldThrowErrorWhenServiceReturnsErro
 	r = mux.SetURLVars(r, map[string]string{"id": "12"})
 	w := httptest.NewRecorder()
 	internalErr := book.GetErrorResponseByCode(book.InternalServerError)
-	m.mockService.EXPECT().CreateOrUpdate(r.Context(), 12, b).Return(int64(0),internalErr)
+	m.mockService.EXPECT().CreateOrUpdate(r.Context(), 12, b, nil).Return(book.Book{}, false, internalErr)
 
 	m.bookHandler.Update(w, r)
 	m.Suite.Equal(500, w.Result().StatusCode)
@@ -307,8 +336,9 @@ func (m *BookHandlerTestSuite) TestUpdate_ShouldThrowErrorWhenServiceReturnsErro
 func (m *BookHandlerTestSuite) TestDelete() {
 	r, _ := http.NewRequest("DELETE", "/books", nil)
 	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	r.Header.Set("If-Match", `"3"`)
 	w := httptest.NewRecorder()
-	m.mockService.EXPECT().Delete(r.Context(), 12).Return(nil)
+	m.mockService.EXPECT().Delete(r.Context(), 12, &book.Precondition{Tags: []string{`"3"`}}).Return(nil)
 	m.bookHandler.Delete(w, r)
 	m.Suite.Equal(204, w.Result().StatusCode)
 }
@@ -318,7 +348,7 @@ func (m *BookHandlerTestSuite) TestDelete_ShouldReturnErrorWhenServiceReturnsErr
 	r = mux.SetURLVars(r, map[string]string{"id": "12"})
 	w := httptest.NewRecorder()
 	internalErr := book.GetErrorResponseByCode(book.InternalServerError)
-	m.mockService.EXPECT().Delete(r.Context(), 12).Return(internalErr)
+	m.mockService.EXPECT().Delete(r.Context(), 12, nil).Return(internalErr)
 	m.bookHandler.Delete(w, r)
 	var actualErr book.ErrorResponse
 	bodyBytes, err := io.ReadAll(w.Result().Body)
@@ -327,3 +357,658 @@ func (m *BookHandlerTestSuite) TestDelete_ShouldReturnErrorWhenServiceReturnsErr
 	m.Suite.Nil(err)
 	m.Suite.Equal(internalErr.Error(), actualErr.Error())
 }
+
+func (m *BookHandlerTestSuite) TestCreate_ShouldReturnBadRequestWhenISBNChecksumIsInvalid() {
+	createBookRequest := book.CreateOrUpdateBookRequest{
+		Title:  "Harry Potter",
+		Author: "JK Rolling",
+		ISBN:   "978-0-7475-3269-0",
+	}
+	requestBytes, _ := json.Marshal(createBookRequest)
+
+	r, _ := http.NewRequest("POST", "/books", bytes.NewReader(requestBytes))
+	w := httptest.NewRecorder()
+	m.bookHandler.Create(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+
+	var actualErr book.ErrorResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&actualErr))
+	m.Suite.Equal("ISBN failed on 'isbn'", actualErr.Error())
+}
+
+func (m *BookHandlerTestSuite) TestGetByISBN_ShouldReturnBothISBNForms() {
+	r, _ := http.NewRequest("GET", "/books/isbn/0747532699", nil)
+	r = mux.SetURLVars(r, map[string]string{"isbn": "0747532699"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().GetByISBN(r.Context(), "0747532699").
+		Return(book.Book{ID: 12, Title: "Harry Potter", Author: "JK Rolling", ISBN: "9780747532699"}, nil)
+
+	m.bookHandler.GetByISBN(w, r)
+	m.Suite.Equal(200, w.Result().StatusCode)
+
+	var res book.BookResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&res))
+	m.Suite.Equal("9780747532699", res.ISBN13)
+	m.Suite.Equal("0747532699", res.ISBN10)
+}
+
+func (m *BookHandlerTestSuite) TestGetByISBN_ShouldReturnNotFoundWhenServiceReturnsNotFound() {
+	r, _ := http.NewRequest("GET", "/books/isbn/9780747532699", nil)
+	r = mux.SetURLVars(r, map[string]string{"isbn": "9780747532699"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().GetByISBN(r.Context(), "9780747532699").
+		Return(book.Book{}, book.GetErrorResponseByCode(book.BookNotFound))
+
+	m.bookHandler.GetByISBN(w, r)
+	m.Suite.Equal(404, w.Result().StatusCode)
+}
+
+func (m *BookHandlerTestSuite) TestGet_ShouldReturnStructuredAuthors() {
+	r, _ := http.NewRequest("GET", "/books/12", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Get(r.Context(), 12).Return(book.Book{
+		ID:     12,
+		Title:  "Good Omens",
+		Author: "Pratchett & Gaiman",
+		Authors: []book.BookAuthor{
+			{AuthorID: 7, Name: "Terry Pratchett", Role: "author", Position: 1},
+			{AuthorID: 8, Name: "Neil Gaiman", Role: "author", Position: 2},
+		},
+	}, nil)
+
+	m.bookHandler.Get(w, r)
+	m.Suite.Equal(200, w.Result().StatusCode)
+
+	var res book.BookResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&res))
+	m.Suite.Equal([]book.BookAuthorResponse{
+		{ID: 7, Name: "Terry Pratchett", Role: "author", Position: 1},
+		{ID: 8, Name: "Neil Gaiman", Role: "author", Position: 2},
+	}, res.Authors)
+}
+
+func (m *BookHandlerTestSuite) TestCreate_ShouldReturnBadRequestWhenAuthorRoleIsUnknown() {
+	createBookRequest := book.CreateOrUpdateBookRequest{
+		Title:   "Good Omens",
+		Author:  "Pratchett & Gaiman",
+		Authors: []book.BookAuthorRequest{{AuthorID: 7, Role: "ghostwriter"}},
+	}
+	requestBytes, _ := json.Marshal(createBookRequest)
+
+	r, _ := http.NewRequest("POST", "/books", bytes.NewReader(requestBytes))
+	w := httptest.NewRecorder()
+	m.bookHandler.Create(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+
+	var actualErr book.ErrorResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&actualErr))
+	m.Suite.Equal("Role failed on 'oneof'", actualErr.Error())
+}
+
+func (m *BookHandlerTestSuite) TestSearch_ShouldReturnRankedResultsWithHighlights() {
+	req, _ := http.NewRequest("GET", "/books/search?q=potter&limit=1&page=2", nil)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Search(req.Context(), "potter", book.BookFilter{}, 1, 1).Return([]book.SearchResult{{
+		Book:                 book.Book{ID: 12, Title: "Harry Potter", Author: "JK Rolling", Description: "harry potter", CreatedAt: time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC), Authors: []book.BookAuthor{}},
+		Rank:                 0.6,
+		TitleHighlight:       "Harry <mark>Potter</mark>",
+		DescriptionHighlight: "harry <mark>potter</mark>",
+	}}, 3, nil)
+
+	m.bookHandler.Search(w, req)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+
+	var body book.PaginatedBookSearchResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal(2, body.Page)
+	m.Suite.Equal(3, body.TotalPages)
+	m.Suite.Equal(book.BookSearchResult{
+		BookResponse: book.BookResponse{ID: 12, Title: "Harry Potter", Author: "JK Rolling", Description: "harry potter", CreatedAt: "2026-10-01T09:30:00Z", Authors: []book.BookAuthorResponse{}},
+		Rank:         0.6,
+		Highlights:   book.SearchHighlights{Title: "Harry <mark>Potter</mark>", Description: "harry <mark>potter</mark>"},
+	}, body.Data[0])
+}
+
+func (m *BookHandlerTestSuite) TestSearch_ShouldReturnBadRequestForInvalidPage() {
+	req, _ := http.NewRequest("GET", "/books/search?q=potter&page=x", nil)
+	w := httptest.NewRecorder()
+
+	m.bookHandler.Search(w, req)
+	m.Suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func (m *BookHandlerTestSuite) TestList_ShouldPassFiltersAndSortToService() {
+	req, _ := http.NewRequest("GET", "/books?page=1&limit=5&title=potter&author=rowling&authorId=7&available=true&createdAfter=2026-01-01&createdBefore=2026-10-01T12:00:00Z&sort=title,-createdAt", nil)
+	w := httptest.NewRecorder()
+	available := true
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	m.mockService.EXPECT().List(req.Context(), book.BookFilter{
+		Title:         "potter",
+		Author:        "rowling",
+		AuthorID:      7,
+		Available:     &available,
+		CreatedAfter:  &after,
+		CreatedBefore: &before,
+	}, []book.SortField{{Field: "title"}, {Field: "createdAt", Desc: true}}, 5, 0).Return(nil, 0, nil)
+
+	m.bookHandler.List(w, req)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+}
+
+func (m *BookHandlerTestSuite) TestList_ShouldReturnBadRequestForInvalidFilterOrSort() {
+	for _, query := range []string{"sort=price", "sort=-", "authorId=x", "available=maybe", "createdAfter=yesterday"} {
+		req, _ := http.NewRequest("GET", "/books?page=1&limit=5&"+query, nil)
+		w := httptest.NewRecorder()
+
+		m.bookHandler.List(w, req)
+		m.Suite.Equal(http.StatusBadRequest, w.Result().StatusCode, query)
+	}
+}
+
+func (m *BookHandlerTestSuite) TestList_ShouldSwitchToCursorModeWhenCursorIsGiven() {
+	req, _ := http.NewRequest("GET", "/books?cursor=&limit=2&sort=title&page=9", nil)
+	w := httptest.NewRecorder()
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	m.mockService.EXPECT().Scroll(req.Context(), book.BookFilter{}, []book.SortField{{Field: "title"}}, "", 2, false).Return(book.BookScroll{
+		Books:      []book.Book{{ID: 1, Title: "A", Author: "X", CreatedAt: created}},
+		NextCursor: "next",
+	}, nil)
+
+	m.bookHandler.List(w, req)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	var body map[string]any
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal("next", body["nextCursor"])
+	m.Suite.Equal(float64(2), body["limit"])
+	m.Suite.NotContains(body, "total")
+	m.Suite.NotContains(body, "page")
+	m.Suite.Len(body["data"], 1)
+}
+
+func (m *BookHandlerTestSuite) TestList_ShouldReturnTotalInCursorModeWhenAskedFor() {
+	req, _ := http.NewRequest("GET", "/books?cursor=abc&withTotal=true", nil)
+	w := httptest.NewRecorder()
+	total := 42
+	m.mockService.EXPECT().Scroll(req.Context(), book.BookFilter{}, nil, "abc", 10, true).Return(book.BookScroll{Books: []book.Book{}, Total: &total}, nil)
+
+	m.bookHandler.List(w, req)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	var body map[string]any
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal(float64(42), body["total"])
+	m.Suite.NotContains(body, "nextCursor")
+}
+
+func (m *BookHandlerTestSuite) TestList_ShouldReturnBadRequestForInvalidCursorModeParameters() {
+	for _, query := range []string{"limit=x", "withTotal=maybe", "sort=price"} {
+		req, _ := http.NewRequest("GET", "/books?cursor=&"+query, nil)
+		w := httptest.NewRecorder()
+
+		m.bookHandler.List(w, req)
+		m.Suite.Equal(http.StatusBadRequest, w.Result().StatusCode, query)
+	}
+}
+
+func (m *BookHandlerTestSuite) TestSuggest_ShouldReturnScoredSuggestions() {
+	req, _ := http.NewRequest("GET", "/books/suggest?q=hary+poter&limit=50", nil)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Suggest(req.Context(), "hary poter", 20).Return([]book.Suggestion{{ID: 12, Title: "Harry Potter", Author: "JK Rolling", Score: 0.82}}, nil)
+
+	m.bookHandler.Suggest(w, req)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	var body book.BookSuggestionsResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal([]book.BookSuggestion{{ID: 12, Title: "Harry Potter", Author: "JK Rolling", Score: 0.82}}, body.Data)
+}
+
+func (m *BookHandlerTestSuite) TestSuggest_ShouldReturnServiceUnavailableOnTimeout() {
+	req, _ := http.NewRequest("GET", "/books/suggest?q=harry", nil)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Suggest(req.Context(), "harry", 5).Return(nil, book.GetErrorResponseByCode(book.SuggestTimeout))
+
+	m.bookHandler.Suggest(w, req)
+	m.Suite.Equal(http.StatusServiceUnavailable, w.Result().StatusCode)
+}
+
+func (m *BookHandlerTestSuite) TestList_ShouldReturnFacetsForSelectedFacetValues() {
+	req, _ := http.NewRequest("GET", "/books?page=1&limit=5&genre=fantasy&language=EN&decade=1990s&facets=true", nil)
+	w := httptest.NewRecorder()
+	f := book.BookFilter{Genre: "fantasy", Language: "en", Decade: 1990}
+	m.mockService.EXPECT().List(req.Context(), f, nil, 5, 0).Return([]book.Book{}, 0, nil)
+	m.mockService.EXPECT().Facets(req.Context(), f, "").Return(book.Facets{
+		Authors:   []book.FacetValue{{Value: "3", Label: "J.K. Rowling", Count: 2}},
+		Genres:    []book.FacetValue{{Value: "fantasy", Count: 2}},
+		Languages: []book.FacetValue{},
+		Decades:   []book.FacetValue{{Value: "1990", Label: "1990s", Count: 2}},
+	}, nil)
+
+	m.bookHandler.List(w, req)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	var body book.PaginatedBookListResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal(&book.FacetsResponse{
+		AuthorID: []book.FacetValueResponse{{Value: "3", Label: "J.K. Rowling", Count: 2}},
+		Genre:    []book.FacetValueResponse{{Value: "fantasy", Count: 2}},
+		Language: []book.FacetValueResponse{},
+		Decade:   []book.FacetValueResponse{{Value: "1990", Label: "1990s", Count: 2}},
+	}, body.Facets)
+}
+
+func (m *BookHandlerTestSuite) TestSearch_ShouldCountFacetsOfTheSearch() {
+	req, _ := http.NewRequest("GET", "/books/search?q=potter&genre=fantasy&facets=true", nil)
+	w := httptest.NewRecorder()
+	f := book.BookFilter{Genre: "fantasy"}
+	m.mockService.EXPECT().Search(req.Context(), "potter", f, 10, 0).Return([]book.SearchResult{}, 0, nil)
+	m.mockService.EXPECT().Facets(req.Context(), f, "potter").Return(book.Facets{}, nil)
+
+	m.bookHandler.Search(w, req)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	var body map[string]any
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Contains(body, "facets")
+}
+
+func (m *BookHandlerTestSuite) TestList_ShouldReturnBadRequestForInvalidFacetParameters() {
+	for _, query := range []string{"decade=1995", "decade=nineties", "decade=0", "facets=maybe"} {
+		req, _ := http.NewRequest("GET", "/books?page=1&limit=5&"+query, nil)
+		w := httptest.NewRecorder()
+
+		m.bookHandler.List(w, req)
+		m.Suite.Equal(http.StatusBadRequest, w.Result().StatusCode, query)
+	}
+}
+
+func (m *BookHandlerTestSuite) TestGet_ShouldReturnVersionAsETag() {
+	r, _ := http.NewRequest("GET", "/books/12", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Get(r.Context(), 12).Return(book.Book{ID: 12, Title: "Dune", Version: 3}, nil)
+
+	m.bookHandler.Get(w, r)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	m.Suite.Equal(`"3"`, w.Result().Header.Get("ETag"))
+}
+
+func (m *BookHandlerTestSuite) TestGet_ShouldReturnNotModifiedWhenIfNoneMatchHoldsCurrentVersion() {
+	for ifNoneMatch, status := range map[string]int{
+		`"3"`:         http.StatusNotModified,
+		`"1", W/"3"`:  http.StatusNotModified,
+		`*`:           http.StatusNotModified,
+		`"2"`:         http.StatusOK,
+	} {
+		r, _ := http.NewRequest("GET", "/books/12", nil)
+		r = mux.SetURLVars(r, map[string]string{"id": "12"})
+		r.Header.Set("If-None-Match", ifNoneMatch)
+		w := httptest.NewRecorder()
+		m.mockService.EXPECT().Get(r.Context(), 12).Return(book.Book{ID: 12, Title: "Dune", Version: 3}, nil)
+
+		m.bookHandler.Get(w, r)
+		m.Suite.Equal(status, w.Result().StatusCode, ifNoneMatch)
+		m.Suite.Equal(`"3"`, w.Result().Header.Get("ETag"))
+		if status == http.StatusNotModified {
+			m.Suite.Empty(w.Body.String())
+		}
+	}
+}
+
+func (m *BookHandlerTestSuite) TestUpdate_ShouldReturnPreconditionFailedForStaleETag() {
+	b := book.CreateOrUpdateBookRequest{Title: "Dune Messiah", Author: "Frank Herbert"}
+	body, _ := json.Marshal(b)
+	r, _ := http.NewRequest("PUT", "/books/12", bytes.NewBuffer(body))
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	r.Header.Set("If-Match", `"2"`)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().CreateOrUpdate(r.Context(), 12, b, &book.Precondition{Tags: []string{`"2"`}}).
+		Return(book.Book{}, false, book.GetErrorResponseByCode(book.PreconditionFailed))
+
+	m.bookHandler.Update(w, r)
+	m.Suite.Equal(http.StatusPreconditionFailed, w.Result().StatusCode)
+	m.Suite.Empty(w.Result().Header.Get("ETag"))
+}
+
+func (m *BookHandlerTestSuite) TestUpdate_ShouldValidateTheWholeBook() {
+	r, _ := http.NewRequest("PUT", "/books/12", bytes.NewBufferString(`{"description": "only the description"}`))
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	r.Header.Set("If-Match", `"2"`)
+	w := httptest.NewRecorder()
+
+	m.bookHandler.Update(w, r)
+	m.Suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func (m *BookHandlerTestSuite) TestPatch() {
+	patch := `{"description": null}`
+	r, _ := http.NewRequest("PATCH", "/books/12", bytes.NewBufferString(patch))
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	r.Header.Set("Content-Type", "application/merge-patch+json; charset=utf-8")
+	r.Header.Set("If-Match", `"2"`)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Patch(r.Context(), 12, book.MergePatchContentType, []byte(patch), &book.Precondition{Tags: []string{`"2"`}}).Return(3, nil)
+
+	m.bookHandler.Patch(w, r)
+	m.Suite.Equal(http.StatusNoContent, w.Result().StatusCode)
+	m.Suite.Equal(`"3"`, w.Result().Header.Get("ETag"))
+}
+
+func (m *BookHandlerTestSuite) TestPatch_ShouldRefuseOtherContentTypes() {
+	for _, contentType := range []string{"application/json", ""} {
+		r, _ := http.NewRequest("PATCH", "/books/12", bytes.NewBufferString(`{"title": "Dune"}`))
+		r = mux.SetURLVars(r, map[string]string{"id": "12"})
+		r.Header.Set("Content-Type", contentType)
+		r.Header.Set("If-Match", `"2"`)
+		w := httptest.NewRecorder()
+
+		m.bookHandler.Patch(w, r)
+		m.Suite.Equal(http.StatusUnsupportedMediaType, w.Result().StatusCode)
+	}
+}
+
+func (m *BookHandlerTestSuite) TestListTrash() {
+	deletedAt := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	r, _ := http.NewRequest("GET", "/trash/books?page=2&limit=5", nil)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().ListTrash(r.Context(), 5, 5).Return([]book.Book{{ID: 12, Title: "Dune", DeletedAt: &deletedAt}}, 6, nil)
+
+	m.bookHandler.ListTrash(w, r)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	var resp book.PaginatedBookListResponse
+	m.Suite.Nil(json.NewDecoder(w.Body).Decode(&resp))
+	m.Suite.Equal(2, resp.TotalPages)
+	m.Suite.Equal("2026-10-17T09:30:00Z", resp.Data[0].DeletedAt)
+}
+
+func (m *BookHandlerTestSuite) TestExport_ShouldStreamFilteredBooksAsCSV() {
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	r, _ := http.NewRequest("GET", "/books/export?format=csv&genre=fantasy&sort=title", nil)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Export(r.Context(), book.BookFilter{Genre: "fantasy"}, []book.SortField{{Field: "title"}}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ book.BookFilter, _ []book.SortField, fn func([]book.Book) error) *book.ErrorResponse {
+			m.Suite.Nil(fn([]book.Book{{
+				ID: 12, Title: "Dune", Author: "Frank Herbert", Description: "Arrakis, the desert planet", ISBN: "9780441013593", Genre: "fantasy",
+				Language: "en", PublishedYear: 1965, CreatedAt: created, AvailableCopies: 1, TotalCopies: 2,
+				Authors: []book.BookAuthor{{Name: "Frank Herbert"}, {Name: "Brian Herbert"}},
+			}}))
+			m.Suite.Nil(fn([]book.Book{{ID: 13, Title: "Emma", Author: "Jane Austen", CreatedAt: created}}))
+			return nil
+		})
+
+	m.bookHandler.Export(w, r)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	m.Suite.Equal("text/csv", w.Result().Header.Get("Content-Type"))
+	m.Suite.Equal(`attachment; filename="books.csv"`, w.Result().Header.Get("Content-Disposition"))
+	m.Suite.Equal("id,title,author,description,isbn,genre,language,publishedYear,authors,availableCopies,totalCopies,createdAt\n"+
+		"12,Dune,Frank Herbert,\"Arrakis, the desert planet\",9780441013593,fantasy,en,1965,Frank Herbert; Brian Herbert,1,2,2026-10-01T09:30:00Z\n"+
+		"13,Emma,Jane Austen,,,,,,,0,0,2026-10-01T09:30:00Z\n", w.Body.String())
+}
+
+func (m *BookHandlerTestSuite) TestExport_ShouldWriteABookResponsePerLineAsNDJSON() {
+	r, _ := http.NewRequest("GET", "/books/export?format=ndjson", nil)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Export(r.Context(), book.BookFilter{}, nil, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ book.BookFilter, _ []book.SortField, fn func([]book.Book) error) *book.ErrorResponse {
+			m.Suite.Nil(fn([]book.Book{{ID: 12, Title: "Dune"}, {ID: 13, Title: "Emma"}}))
+			return nil
+		})
+
+	m.bookHandler.Export(w, r)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	m.Suite.Equal("application/x-ndjson", w.Result().Header.Get("Content-Type"))
+	dec := json.NewDecoder(w.Body)
+	for _, id := range []int{12, 13} {
+		var resp book.BookResponse
+		m.Suite.Nil(dec.Decode(&resp))
+		m.Suite.Equal(id, resp.ID)
+	}
+	m.Suite.False(dec.More())
+}
+
+func (m *BookHandlerTestSuite) TestExport_ShouldOutlastTheWriteTimeoutWhileBatchesKeepComing() {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(m.bookHandler.Export))
+	srv.Config.WriteTimeout = 200 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+	m.mockService.EXPECT().Export(gomock.Any(), book.BookFilter{}, nil, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ book.BookFilter, _ []book.SortField, fn func([]book.Book) error) *book.ErrorResponse {
+			for id := 1; id <= 4; id++ {
+				time.Sleep(100 * time.Millisecond)
+				m.Suite.Nil(fn([]book.Book{{ID: id, Title: "Dune"}}))
+			}
+			return nil
+		})
+
+	resp, err := http.Get(srv.URL + "/books/export?format=ndjson")
+	m.Suite.Nil(err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	m.Suite.Nil(err)
+	m.Suite.Equal(4, bytes.Count(body, []byte("\n")))
+}
+
+func (m *BookHandlerTestSuite) TestExport_ShouldReturnBadRequestForUnknownFormat() {
+	r, _ := http.NewRequest("GET", "/books/export?format=xlsx", nil)
+	w := httptest.NewRecorder()
+
+	m.bookHandler.Export(w, r)
+	m.Suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func (m *BookHandlerTestSuite) TestExport_ShouldReturnTheErrorWhenNothingWasSent() {
+	r, _ := http.NewRequest("GET", "/books/export", nil)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Export(r.Context(), book.BookFilter{}, nil, gomock.Any()).Return(book.GetErrorResponseByCode(book.InternalServerError))
+
+	m.bookHandler.Export(w, r)
+	m.Suite.Equal(http.StatusInternalServerError, w.Result().StatusCode)
+	m.Suite.Equal("application/json", w.Result().Header.Get("Content-Type"))
+}
+
+func (m *BookHandlerTestSuite) TestGet_ShouldRenderACitationForTheFormatParameter() {
+	r, _ := http.NewRequest("GET", "/books/12?format=ris", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Get(r.Context(), 12).Return(book.Book{ID: 12, Title: "Dune", Author: "Frank Herbert", PublishedYear: 1965, Version: 2}, nil)
+
+	m.bookHandler.Get(w, r)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	m.Suite.Equal("application/x-research-info-systems; charset=utf-8", w.Result().Header.Get("Content-Type"))
+	m.Suite.Equal("Accept", w.Result().Header.Get("Vary"))
+	m.Suite.Empty(w.Result().Header.Get("ETag"))
+	m.Suite.Equal("TY  - BOOK\r\nID  - herbert1965dune-12\r\nAU  - Herbert, Frank\r\nTI  - Dune\r\nPY  - 1965\r\nER  - \r\n", w.Body.String())
+}
+
+func (m *BookHandlerTestSuite) TestGet_ShouldNegotiateTheFormatFromTheAcceptHeader() {
+	// An empty content type stands for the BookResponse.
+	accepts := map[string]string{
+		"application/x-bibtex":                               "application/x-bibtex; charset=utf-8",
+		"application/json;q=0.5, application/x-bibtex;q=0.9": "application/x-bibtex; charset=utf-8",
+		"application/vnd.citationstyles.csl+json, */*;q=0.1": "application/vnd.citationstyles.csl+json; charset=utf-8",
+		"application/x-bibtex;q=0.5, application/json":       "",
+		"text/html, */*;q=0.8":                               "",
+		"text/html":                                          "",
+	}
+	for accept, contentType := range accepts {
+		r, _ := http.NewRequest("GET", "/books/12", nil)
+		r = mux.SetURLVars(r, map[string]string{"id": "12"})
+		r.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		m.mockService.EXPECT().Get(r.Context(), 12).Return(book.Book{ID: 12, Title: "Dune"}, nil)
+
+		m.bookHandler.Get(w, r)
+		m.Suite.Equal(http.StatusOK, w.Result().StatusCode, accept)
+		if contentType != "" {
+			m.Suite.Equal(contentType, w.Result().Header.Get("Content-Type"), accept)
+			continue
+		}
+		var resp book.BookResponse
+		m.Suite.Nil(json.NewDecoder(w.Body).Decode(&resp), accept)
+		m.Suite.Equal(12, resp.ID, accept)
+		m.Suite.NotEmpty(w.Result().Header.Get("ETag"), accept)
+	}
+}
+
+func (m *BookHandlerTestSuite) TestGet_ShouldReturnBadRequestForUnknownFormat() {
+	r, _ := http.NewRequest("GET", "/books/12?format=mla", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+
+	m.bookHandler.Get(w, r)
+	m.Suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func (m *BookHandlerTestSuite) TestCitations_ShouldRenderTheBooksInTheOrderOfTheIDs() {
+	r, _ := http.NewRequest("GET", "/books/citations?ids=13,12", nil)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().GetMany(r.Context(), []int{13, 12}).Return([]book.Book{
+		{ID: 13, Title: "Emma", Author: "Jane Austen", PublishedYear: 1815},
+		{ID: 12, Title: "Dune", Author: "Frank Herbert", PublishedYear: 1965},
+	}, nil)
+
+	m.bookHandler.Citations(w, r)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	m.Suite.Equal("application/x-bibtex; charset=utf-8", w.Result().Header.Get("Content-Type"))
+	m.Suite.Equal(`attachment; filename="books.bib"`, w.Result().Header.Get("Content-Disposition"))
+	m.Suite.Equal("@book{austen1815emma-13,\n  author = {Austen, Jane},\n  title = {Emma},\n  year = {1815},\n}\n\n"+
+		"@book{herbert1965dune-12,\n  author = {Herbert, Frank},\n  title = {Dune},\n  year = {1965},\n}\n", w.Body.String())
+}
+
+func (m *BookHandlerTestSuite) TestCitations_ShouldTakeTheFormatFromTheAcceptHeader() {
+	r, _ := http.NewRequest("GET", "/books/citations?ids=12", nil)
+	r.Header.Set("Accept", "application/vnd.citationstyles.csl+json")
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().GetMany(r.Context(), []int{12}).Return([]book.Book{{ID: 12, Title: "Dune"}}, nil)
+
+	m.bookHandler.Citations(w, r)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	m.Suite.Equal(`attachment; filename="books.json"`, w.Result().Header.Get("Content-Disposition"))
+	m.Suite.JSONEq(`[{"id": "anondune-12", "type": "book", "title": "Dune"}]`, w.Body.String())
+}
+
+func (m *BookHandlerTestSuite) TestCitations_ShouldReturnBadRequestForInvalidQueries() {
+	for _, query := range []string{"", "ids=", "ids=1,x", "ids=0", "ids=1&format=json", "ids=1&format=mla", "ids=" + strings.Repeat("1,", 100) + "1"} {
+		r, _ := http.NewRequest("GET", "/books/citations?"+query, nil)
+		w := httptest.NewRecorder()
+
+		m.bookHandler.Citations(w, r)
+		m.Suite.Equal(http.StatusBadRequest, w.Result().StatusCode, query)
+	}
+}
+
+func (m *BookHandlerTestSuite) TestCitations_ShouldReturnNotFoundForMissingBooks() {
+	r, _ := http.NewRequest("GET", "/books/citations?ids=12,99&format=ris", nil)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().GetMany(r.Context(), []int{12, 99}).
+		Return(nil, book.GetErrorResponse(book.BookNotFound, "no book found for ids 99", http.StatusNotFound))
+
+	m.bookHandler.Citations(w, r)
+	m.Suite.Equal(http.StatusNotFound, w.Result().StatusCode)
+	m.Suite.Equal("application/json", w.Result().Header.Get("Content-Type"))
+}
+
+func (m *BookHandlerTestSuite) TestRestore() {
+	r, _ := http.NewRequest("POST", "/books/12/restore", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Restore(r.Context(), 12).Return(book.Book{ID: 12, Title: "Dune", Version: 3}, nil)
+
+	m.bookHandler.Restore(w, r)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	m.Suite.Equal(`"3"`, w.Result().Header.Get("ETag"))
+	var resp book.BookResponse
+	m.Suite.Nil(json.NewDecoder(w.Body).Decode(&resp))
+	m.Suite.Empty(resp.DeletedAt)
+}
+
+func (m *BookHandlerTestSuite) TestDelete_ShouldReturnPreconditionRequiredWithoutIfMatch() {
+	r, _ := http.NewRequest("DELETE", "/books/12", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Delete(r.Context(), 12, nil).Return(book.GetErrorResponseByCode(book.PreconditionRequired))
+
+	m.bookHandler.Delete(w, r)
+	m.Suite.Equal(http.StatusPreconditionRequired, w.Result().StatusCode)
+}
+
+func (m *BookHandlerTestSuite) TestListRevisions() {
+	changedAt := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	r, _ := http.NewRequest("GET", "/books/12/revisions?limit=1", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().ListRevisions(r.Context(), 12, 1, 0).Return([]book.Revision{
+		{BookID: 12, Revision: 2, Action: book.RevisionUpdate, ChangedBy: "alice", ChangedAt: changedAt, Snapshot: book.CreateOrUpdateBookRequest{Title: "Dune"}},
+	}, 2, nil)
+
+	m.bookHandler.ListRevisions(w, r)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	var resp book.PaginatedRevisionListResponse
+	m.Suite.Nil(json.NewDecoder(w.Body).Decode(&resp))
+	m.Suite.Equal(2, resp.TotalPages)
+	m.Suite.Equal(book.RevisionResponse{
+		Revision:  2,
+		Action:    "update",
+		ChangedBy: "alice",
+		ChangedAt: "2026-10-17T09:30:00Z",
+		Snapshot:  book.CreateOrUpdateBookRequest{Title: "Dune", Authors: []book.BookAuthorRequest{}},
+	}, resp.Data[0])
+}
+
+func (m *BookHandlerTestSuite) TestDiffRevisions_ShouldRequireBothRevisions() {
+	r, _ := http.NewRequest("GET", "/books/12/revisions/diff?from=1", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+
+	m.bookHandler.DiffRevisions(w, r)
+	m.Suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func (m *BookHandlerTestSuite) TestRevert() {
+	r, _ := http.NewRequest("POST", "/books/12/revisions/1/revert", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "12", "rev": "1"})
+	r.Header.Set("If-Match", `"3"`)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Revert(r.Context(), 12, 1, book.ParseIfMatch(`"3"`)).Return(4, nil)
+
+	m.bookHandler.Revert(w, r)
+	m.Suite.Equal(http.StatusNoContent, w.Result().StatusCode)
+	m.Suite.Equal(`"4"`, w.Result().Header.Get("ETag"))
+}
+
+func (m *BookHandlerTestSuite) TestBatch_ShouldRejectInvalidItemsAndReportEachResult() {
+	body := `{"mode": "bestEffort", "operations": [
+		{"op": "create", "book": {"title": "Dune", "author": "Frank Herbert"}},
+		{"op": "create", "book": {"author": "Frank Herbert"}},
+		{"op": "delete", "id": 12, "ifMatch": "\"3\""}
+	]}`
+	r, _ := http.NewRequest("POST", "/books:batch", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Batch(r.Context(), gomock.Any(), false).DoAndReturn(
+		func(_ context.Context, ops []book.BatchOperation, _ bool) []book.BatchResult {
+			m.Suite.Nil(ops[0].Rejected)
+			m.Suite.Equal("Title failed on 'required'", ops[1].Rejected.ErrorMessage)
+			m.Suite.Equal(book.ParseIfMatch(`"3"`), ops[2].IfMatch)
+			return []book.BatchResult{
+				{Status: http.StatusCreated, ID: 13, Version: 1},
+				{Status: http.StatusBadRequest, Err: ops[1].Rejected},
+				{Status: http.StatusNoContent, ID: 12},
+			}
+		})
+
+	m.bookHandler.Batch(w, r)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	var resp book.BatchResponse
+	m.Suite.Nil(json.NewDecoder(w.Body).Decode(&resp))
+	m.Suite.Equal(book.BatchItemResponse{Index: 0, Status: http.StatusCreated, ID: 13, ETag: `"1"`}, resp.Results[0])
+	m.Suite.Equal(book.BadRequest, resp.Results[1].Error.ErrorCode)
+	m.Suite.Equal(book.BatchItemResponse{Index: 2, Status: http.StatusNoContent, ID: 12}, resp.Results[2])
+}
+
+func (m *BookHandlerTestSuite) TestBatch_ShouldRejectEmptyOrUnknownModes() {
+	for _, body := range []string{`{"operations": []}`, `{"mode": "eventually", "operations": [{"op": "delete", "id": 1}]}`} {
+		r, _ := http.NewRequest("POST", "/books:batch", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+
+		m.bookHandler.Batch(w, r)
+		m.Suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+	}
+}
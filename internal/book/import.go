@@ -0,0 +1,138 @@
+package book
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ImportJob is a CSV import running in the background. Rows counts the
+// records read so far; each ends up imported, a duplicate or failed.
+type ImportJob struct {
+	ID         int        `sql:"id"`
+	Status     string     `sql:"status"`
+	DryRun     bool       `sql:"dry_run"`
+	FileName   string     `sql:"file_name"`
+	FileSize   int64      `sql:"file_size"`
+	BytesRead  int64      `sql:"bytes_read"`
+	Rows       int        `sql:"rows_read"`
+	Imported   int        `sql:"rows_imported"`
+	Duplicates int        `sql:"rows_duplicate"`
+	Failed     int        `sql:"rows_failed"`
+	// Error says why the job as a whole failed.
+	Error      string     `sql:"error"`
+	CreatedBy  string     `sql:"created_by"`
+	CreatedAt  time.Time  `sql:"created_at"`
+	FinishedAt *time.Time `sql:"finished_at"`
+}
+
+const (
+	ImportRunning   = "running"
+	ImportSucceeded = "succeeded"
+	ImportFailed    = "failed"
+)
+
+// ImportError is a row of an import that was not imported. Row counts the
+// records of the file, the header being row 1.
+type ImportError struct {
+	JobID   int    `sql:"job_id"`
+	Row     int    `sql:"row_number"`
+	Reason  string `sql:"reason"`
+	Message string `sql:"message"`
+}
+
+const (
+	ImportRowInvalid   = "invalid"
+	ImportRowDuplicate = "duplicate"
+	ImportRowFailed    = "failed"
+)
+
+// importFields are the members of a CreateOrUpdateBookRequest a CSV column
+// can be mapped to. Structured authors cannot be imported.
+var importFields = []string{"title", "author", "description", "isbn", "genre", "language", "publishedYear"}
+
+// importColumns holds the index of the column each field is read from, or
+// -1 when the file has none.
+type importColumns map[string]int
+
+// resolveColumns finds the column of every field in the header. A mapping
+// names the header of a field's column; fields left out of it are read
+// from the column named like the field, if any. Headers compare
+// case-insensitively.
+func resolveColumns(header []string, mapping map[string]string) (importColumns, error) {
+	known := make(map[string]bool, len(importFields))
+	for _, f := range importFields {
+		known[f] = true
+	}
+	unknown := []string{}
+	for f := range mapping {
+		if !known[f] {
+			unknown = append(unknown, f)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("mapping has unknown fields %s; fields are %s",
+			strings.Join(unknown, ", "), strings.Join(importFields, ", "))
+	}
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		if i == 0 {
+			// Spreadsheets often save CSV with a byte order mark.
+			h = strings.TrimPrefix(h, "\ufeff")
+		}
+		h = strings.ToLower(strings.TrimSpace(h))
+		if _, ok := index[h]; !ok {
+			index[h] = i
+		}
+	}
+	cols := make(importColumns, len(importFields))
+	for _, f := range importFields {
+		name, mapped := mapping[f]
+		if !mapped {
+			name = f
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		if !ok && mapped {
+			return nil, fmt.Errorf("column %q mapped to %s is not in the header", name, f)
+		}
+		if !ok {
+			i = -1
+		}
+		cols[f] = i
+	}
+	for _, f := range []string{"title", "author"} {
+		if cols[f] < 0 {
+			return nil, fmt.Errorf("no column for %s; map one in mapping", f)
+		}
+	}
+	return cols, nil
+}
+
+// request reads a record into a request. Missing trailing cells are empty.
+func (c importColumns) request(record []string) (CreateOrUpdateBookRequest, error) {
+	cell := func(f string) string {
+		if i := c[f]; i >= 0 && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	req := CreateOrUpdateBookRequest{
+		Title:       cell("title"),
+		Author:      cell("author"),
+		Description: cell("description"),
+		ISBN:        cell("isbn"),
+		Genre:       cell("genre"),
+		Language:    cell("language"),
+	}
+	if v := cell("publishedYear"); v != "" {
+		year, err := strconv.Atoi(v)
+		if err != nil {
+			return req, fmt.Errorf("publishedYear %q is not a year", v)
+		}
+		req.PublishedYear = year
+	}
+	return req, nil
+}
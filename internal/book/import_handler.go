@@ -0,0 +1,195 @@
+package book
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxImportSize bounds an import upload, file and fields together.
+const maxImportSize = 256 << 20
+
+type ImportHandler struct {
+	svc ImportService
+}
+
+func NewImportHandler(s ImportService) *ImportHandler {
+	return &ImportHandler{svc: s}
+}
+
+// Create godoc
+// @Summary      Import books from a CSV file
+// @Description  Starts importing the rows of a CSV file as books and returns the import job at once. The file needs a header row; columns are matched to the fields of CreateOrUpdateBookRequest by name unless the mapping, a JSON object from field name to column name, names them. Each row is validated like a POST body and skipped as a duplicate when its isbn, or else its title and author, is already in the catalog or earlier in the file. With dryRun every row is checked but no book is created
+// @Tags         imports
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file     formData  file    true   "CSV file (at most 256 MB)"
+// @Param        mapping  formData  string  false  "JSON object from field name to CSV column name"
+// @Param        dryRun   formData  bool    false  "Check the rows without creating books"
+// @Success      202    {object}  ImportJobResponse
+// @Header       202    {string}  Location  "URL of the import job"
+// @Failure      400    {object}  ErrorResponse
+// @Router       /imports [post]
+func (h *ImportHandler) Create(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	upload, errResp := receiveUpload(r)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	job, errResp := h.svc.Start(r.Context(), upload)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("/imports/%d", job.ID))
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(toImportJobResponse(job))
+}
+
+// Get godoc
+// @Summary      Get import job
+// @Description  Returns the status and progress of an import. Progress is the share of the file read so far, from 0 to 1. Once rows have been skipped, errorReport links to the list of them
+// @Tags         imports
+// @Produce      json
+// @Param        id   path      int  true  "Import ID"
+// @Success      200  {object}  ImportJobResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /imports/{id} [get]
+func (h *ImportHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	job, errResp := h.svc.Get(r.Context(), id)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	json.NewEncoder(w).Encode(toImportJobResponse(job))
+}
+
+// ErrorReport godoc
+// @Summary      Download import error report
+// @Description  Returns the rows of an import that were not imported as CSV with the columns row, reason and message. Row 1 is the header of the file. The reason is invalid, duplicate or failed
+// @Tags         imports
+// @Produce      text/csv
+// @Param        id   path      int  true  "Import ID"
+// @Success      200  {string}  string
+// @Failure      400  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /imports/{id}/errors [get]
+func (h *ImportHandler) ErrorReport(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	if _, errResp := h.svc.Get(r.Context(), id); errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="import-%d-errors.csv"`, id))
+	if errResp := h.svc.WriteErrorReport(r.Context(), id, w); errResp != nil {
+		sendError(w, *errResp)
+	}
+}
+
+// receiveUpload streams the file of a multipart upload to a temporary file
+// and reads the other fields, in whatever order they come.
+func receiveUpload(r *http.Request) (ImportUpload, *ErrorResponse) {
+	upload := ImportUpload{}
+	fail := func(msg string) (ImportUpload, *ErrorResponse) {
+		if upload.Path != "" {
+			os.Remove(upload.Path)
+		}
+		logrus.Error("invalid import upload: ", msg)
+		return ImportUpload{}, GetErrorResponse(BadRequest, msg, http.StatusBadRequest)
+	}
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return fail("the file must be uploaded as multipart/form-data")
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fail("the upload is not valid multipart/form-data")
+		}
+		switch part.FormName() {
+		case "file":
+			if upload.Path != "" {
+				return fail("only one file can be imported at a time")
+			}
+			f, err := os.CreateTemp("", "book-import-*.csv")
+			if err != nil {
+				logrus.Error("error while creating a file for the import upload. error is ", err)
+				return ImportUpload{}, GetErrorResponseByCode(InternalServerError)
+			}
+			upload.Path, upload.FileName = f.Name(), part.FileName()
+			upload.Size, err = io.Copy(f, part)
+			f.Close()
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return fail(fmt.Sprintf("the upload is larger than %d MB", maxImportSize>>20))
+			}
+			if err != nil {
+				return fail("the upload was interrupted")
+			}
+		case "mapping":
+			if err := json.NewDecoder(io.LimitReader(part, 64<<10)).Decode(&upload.Mapping); err != nil {
+				return fail("mapping must be a JSON object from field name to column name")
+			}
+		case "dryRun":
+			v, _ := io.ReadAll(io.LimitReader(part, 16))
+			if upload.DryRun, err = strconv.ParseBool(strings.TrimSpace(string(v))); err != nil {
+				return fail("dryRun must be true or false")
+			}
+		}
+		part.Close()
+	}
+	if upload.Path == "" {
+		return fail("file is required")
+	}
+	return upload, nil
+}
+
+func toImportJobResponse(job ImportJob) ImportJobResponse {
+	resp := ImportJobResponse{
+		ID:         job.ID,
+		Status:     job.Status,
+		DryRun:     job.DryRun,
+		FileName:   job.FileName,
+		Rows:       job.Rows,
+		Imported:   job.Imported,
+		Duplicates: job.Duplicates,
+		Failed:     job.Failed,
+		Error:      job.Error,
+		CreatedBy:  job.CreatedBy,
+		CreatedAt:  job.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	switch {
+	case job.Status != ImportRunning:
+		resp.Progress = 1
+	case job.FileSize > 0:
+		resp.Progress = float64(job.BytesRead) / float64(job.FileSize)
+	}
+	if job.Duplicates+job.Failed > 0 {
+		resp.ErrorReport = fmt.Sprintf("/imports/%d/errors", job.ID)
+	}
+	if job.FinishedAt != nil {
+		resp.FinishedAt = job.FinishedAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,113 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	mock_book "book-store/internal/mocks"
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportHandlerTestSuite struct {
+	suite.Suite
+	importHandler *book.ImportHandler
+	mockService   *mock_book.MockImportService
+	ctrl          *gomock.Controller
+}
+
+func TestImportHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportHandlerTestSuite))
+}
+
+func (m *ImportHandlerTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockService = mock_book.NewMockImportService(m.ctrl)
+	m.importHandler = book.NewImportHandler(m.mockService)
+}
+
+func (m *ImportHandlerTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+// multipartRequest builds an upload from fields and, unless empty, a file.
+func multipartRequest(file string, fields map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	for k, v := range fields {
+		mw.WriteField(k, v)
+	}
+	if file != "" {
+		fw, _ := mw.CreateFormFile("file", "catalog.csv")
+		fw.Write([]byte(file))
+	}
+	mw.Close()
+	r, _ := http.NewRequest("POST", "/imports", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return r
+}
+
+func (m *ImportHandlerTestSuite) TestCreate_ShouldStartTheImportOfTheUploadedFile() {
+	csv := "Book Title,Writer\nDune,Frank Herbert\n"
+	r := multipartRequest(csv, map[string]string{"mapping": `{"title": "Book Title", "author": "Writer"}`, "dryRun": "true"})
+	w := httptest.NewRecorder()
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	m.mockService.EXPECT().Start(r.Context(), gomock.Any()).DoAndReturn(func(_ context.Context, upload book.ImportUpload) (book.ImportJob, *book.ErrorResponse) {
+		content, err := os.ReadFile(upload.Path)
+		m.Suite.Nil(err)
+		os.Remove(upload.Path)
+		m.Suite.Equal(csv, string(content))
+		m.Suite.Equal("catalog.csv", upload.FileName)
+		m.Suite.Equal(int64(len(csv)), upload.Size)
+		m.Suite.Equal(map[string]string{"title": "Book Title", "author": "Writer"}, upload.Mapping)
+		m.Suite.True(upload.DryRun)
+		return book.ImportJob{ID: 7, Status: book.ImportRunning, DryRun: true, FileName: "catalog.csv", FileSize: upload.Size, CreatedAt: created}, nil
+	})
+
+	m.importHandler.Create(w, r)
+	m.Suite.Equal(202, w.Result().StatusCode)
+	m.Suite.Equal("/imports/7", w.Result().Header.Get("Location"))
+
+	var body book.ImportJobResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal(book.ImportJobResponse{ID: 7, Status: "running", DryRun: true, FileName: "catalog.csv", CreatedAt: "2024-03-01T10:00:00Z"}, body)
+}
+
+func (m *ImportHandlerTestSuite) TestCreate_ShouldReturnBadRequestWithoutAFile() {
+	r := multipartRequest("", map[string]string{"dryRun": "true"})
+	w := httptest.NewRecorder()
+
+	m.importHandler.Create(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+
+	var body book.ErrorResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal("file is required", body.ErrorMessage)
+}
+
+func (m *ImportHandlerTestSuite) TestGet_ShouldReportProgressAndLinkTheErrorReport() {
+	r, _ := http.NewRequest("GET", "/imports/7", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Get(r.Context(), 7).Return(book.ImportJob{
+		ID: 7, Status: book.ImportRunning, FileName: "catalog.csv", FileSize: 400, BytesRead: 100,
+		Rows: 10, Imported: 9, Failed: 1, CreatedAt: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+	}, nil)
+
+	m.importHandler.Get(w, r)
+	m.Suite.Equal(200, w.Result().StatusCode)
+
+	var body book.ImportJobResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal(0.25, body.Progress)
+	m.Suite.Equal("/imports/7/errors", body.ErrorReport)
+}
@@ -0,0 +1,147 @@
+package book
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+var ErrImportNotFound = errors.New("import not found")
+
+type ImportRepository interface {
+	Create(ctx context.Context, job ImportJob) (int, error)
+	GetByID(ctx context.Context, id int) (ImportJob, error)
+	// SaveProgress stores the counters of a running job together with the
+	// rows it rejected since the last save.
+	SaveProgress(ctx context.Context, job ImportJob, rejected []ImportError) error
+	// Finish stores the final state of a job.
+	Finish(ctx context.Context, job ImportJob) error
+	// EachError calls fn with the rejected rows of a job in row order,
+	// without loading them all at once.
+	EachError(ctx context.Context, jobID int, fn func(ImportError) error) error
+	// FindDuplicate returns the id of a book in the catalog with the isbn
+	// or, for books without one, with the title and author, or 0.
+	FindDuplicate(ctx context.Context, isbn, title, author string) (int, error)
+	// FailRunning fails every running job with reason followed by the rows
+	// it read, and returns how many there were.
+	FailRunning(ctx context.Context, reason string) (int, error)
+}
+
+type sqlImportRepo struct {
+	db *sql.DB
+}
+
+func NewImportRepository(db *sql.DB) ImportRepository {
+	return &sqlImportRepo{db: db}
+}
+
+func (r *sqlImportRepo) Create(ctx context.Context, job ImportJob) (int, error) {
+	var id int
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO import_jobs (status, dry_run, file_name, file_size, created_by) VALUES ($1, $2, $3, $4, NULLIF($5, '')) RETURNING id`,
+		job.Status, job.DryRun, job.FileName, job.FileSize, job.CreatedBy).Scan(&id)
+	return id, err
+}
+
+func (r *sqlImportRepo) GetByID(ctx context.Context, id int) (ImportJob, error) {
+	job := ImportJob{}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, status, dry_run, file_name, file_size, bytes_read, rows_read, rows_imported, rows_duplicate, rows_failed,
+		        error, COALESCE(created_by, ''), created_at, finished_at
+		 FROM import_jobs WHERE id = $1`, id).
+		Scan(&job.ID, &job.Status, &job.DryRun, &job.FileName, &job.FileSize, &job.BytesRead, &job.Rows, &job.Imported,
+			&job.Duplicates, &job.Failed, &job.Error, &job.CreatedBy, &job.CreatedAt, &job.FinishedAt)
+	if err == sql.ErrNoRows {
+		return ImportJob{}, ErrImportNotFound
+	}
+	return job, err
+}
+
+func (r *sqlImportRepo) SaveProgress(ctx context.Context, job ImportJob, rejected []ImportError) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := saveCounters(ctx, tx, job); err != nil {
+			return err
+		}
+		if len(rejected) == 0 {
+			return nil
+		}
+		rows := make([]int64, len(rejected))
+		reasons := make([]string, len(rejected))
+		messages := make([]string, len(rejected))
+		for i, e := range rejected {
+			rows[i], reasons[i], messages[i] = int64(e.Row), e.Reason, e.Message
+		}
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO import_job_errors (job_id, row_number, reason, message)
+			 SELECT $1, * FROM unnest($2::int[], $3::text[], $4::text[])`,
+			job.ID, pq.Array(rows), pq.Array(reasons), pq.Array(messages))
+		return err
+	})
+}
+
+func (r *sqlImportRepo) Finish(ctx context.Context, job ImportJob) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE import_jobs SET bytes_read = $2, rows_read = $3, rows_imported = $4, rows_duplicate = $5, rows_failed = $6,
+		        status = $7, error = $8, finished_at = now()
+		 WHERE id = $1`,
+		job.ID, job.BytesRead, job.Rows, job.Imported, job.Duplicates, job.Failed, job.Status, job.Error)
+	return err
+}
+
+func saveCounters(ctx context.Context, q queryer, job ImportJob) error {
+	_, err := q.ExecContext(ctx,
+		`UPDATE import_jobs SET bytes_read = $2, rows_read = $3, rows_imported = $4, rows_duplicate = $5, rows_failed = $6
+		 WHERE id = $1`,
+		job.ID, job.BytesRead, job.Rows, job.Imported, job.Duplicates, job.Failed)
+	return err
+}
+
+func (r *sqlImportRepo) EachError(ctx context.Context, jobID int, fn func(ImportError) error) error {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT job_id, row_number, reason, message FROM import_job_errors WHERE job_id = $1 ORDER BY row_number`, jobID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		e := ImportError{}
+		if err := rows.Scan(&e.JobID, &e.Row, &e.Reason, &e.Message); err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *sqlImportRepo) FindDuplicate(ctx context.Context, isbn, title, author string) (int, error) {
+	var id int
+	var err error
+	if isbn != "" {
+		err = r.db.QueryRowContext(ctx,
+			`SELECT id FROM books WHERE isbn = $1 AND deleted_at IS NULL`, isbn).Scan(&id)
+	} else {
+		err = r.db.QueryRowContext(ctx,
+			`SELECT id FROM books WHERE lower(title) = $1 AND lower(author) = $2 AND deleted_at IS NULL ORDER BY id LIMIT 1`,
+			strings.ToLower(title), strings.ToLower(author)).Scan(&id)
+	}
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return id, err
+}
+
+func (r *sqlImportRepo) FailRunning(ctx context.Context, reason string) (int, error) {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE import_jobs SET status = 'failed', error = $1 || ' after ' || rows_read || ' rows', finished_at = now()
+		 WHERE status = 'running'`, reason)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
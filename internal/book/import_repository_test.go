@@ -0,0 +1,87 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportRepositoryTestSuite struct {
+	suite.Suite
+	importRepository book.ImportRepository
+	sqlMock          sqlmock.Sqlmock
+	db               *sql.DB
+}
+
+func TestImportRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportRepositoryTestSuite))
+}
+
+func (m *ImportRepositoryTestSuite) SetupTest() {
+	m.db, m.sqlMock, _ = sqlmock.New()
+	m.importRepository = book.NewImportRepository(m.db)
+}
+
+func (m *ImportRepositoryTestSuite) TestSaveProgress_ShouldStoreCountersAndRejectedRowsTogether() {
+	job := book.ImportJob{ID: 7, BytesRead: 4096, Rows: 100, Imported: 97, Duplicates: 2, Failed: 1}
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec("UPDATE import_jobs SET bytes_read").WithArgs(7, int64(4096), 100, 97, 2, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO import_job_errors (job_id, row_number, reason, message) SELECT $1, * FROM unnest($2::int[], $3::text[], $4::text[])")).
+		WithArgs(7, pq.Array([]int64{12, 40}), pq.Array([]string{"invalid", "duplicate"}), pq.Array([]string{"Title failed on 'required'", "same book as row 3"})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	m.sqlMock.ExpectCommit()
+
+	err := m.importRepository.SaveProgress(context.Background(), job, []book.ImportError{
+		{JobID: 7, Row: 12, Reason: book.ImportRowInvalid, Message: "Title failed on 'required'"},
+		{JobID: 7, Row: 40, Reason: book.ImportRowDuplicate, Message: "same book as row 3"},
+	})
+	m.Suite.Nil(err)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+}
+
+func (m *ImportRepositoryTestSuite) TestFindDuplicate_ShouldMatchByISBN() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM books WHERE isbn = $1 AND deleted_at IS NULL")).
+		WithArgs("9780441013593").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+	id, err := m.importRepository.FindDuplicate(context.Background(), "9780441013593", "Dune", "Frank Herbert")
+	m.Suite.Nil(err)
+	m.Suite.Equal(5, id)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+}
+
+func (m *ImportRepositoryTestSuite) TestFindDuplicate_ShouldMatchTitleAndAuthorIgnoringCase() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM books WHERE lower(title) = $1 AND lower(author) = $2 AND deleted_at IS NULL ORDER BY id LIMIT 1")).
+		WithArgs("dune", "frank herbert").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	id, err := m.importRepository.FindDuplicate(context.Background(), "", "Dune", "Frank Herbert")
+	m.Suite.Nil(err)
+	m.Suite.Equal(0, id)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+}
+
+func (m *ImportRepositoryTestSuite) TestGetByID_ShouldReturnNotFoundErrorForUnknownJob() {
+	m.sqlMock.ExpectQuery("FROM import_jobs WHERE id = ").WithArgs(7).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := m.importRepository.GetByID(context.Background(), 7)
+	m.Suite.Equal(book.ErrImportNotFound, err)
+}
+
+func (m *ImportRepositoryTestSuite) TestFailRunning_ShouldFailJobsLeftRunning() {
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE import_jobs SET status = 'failed', error = $1 || ' after ' || rows_read || ' rows', finished_at = now() WHERE status = 'running'")).
+		WithArgs("interrupted").WillReturnResult(sqlmock.NewResult(0, 2))
+
+	n, err := m.importRepository.FailRunning(context.Background(), "interrupted")
+	m.Suite.Nil(err)
+	m.Suite.Equal(2, n)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,297 @@
+package book
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// importSaveEvery is how many rows an import reads between saving its
+// progress and the rows it rejected.
+const importSaveEvery = 100
+
+// importInterrupted is the error of a job cut short by the server stopping.
+const importInterrupted = "the import was interrupted when the server stopped"
+
+// ImportUpload is a CSV file received for import. The file at Path belongs
+// to the import service, which removes it when done with it.
+type ImportUpload struct {
+	Path     string
+	FileName string
+	Size     int64
+	// Mapping names the CSV column of each request field it maps.
+	Mapping map[string]string
+	// DryRun checks every row without creating any book.
+	DryRun bool
+}
+
+type ImportService interface {
+	// Start checks the header of the upload against its mapping and then
+	// imports the rows in the background, returning the job at once.
+	Start(ctx context.Context, upload ImportUpload) (ImportJob, *ErrorResponse)
+	Get(ctx context.Context, id int) (ImportJob, *ErrorResponse)
+	// WriteErrorReport writes the rows the job did not import to w as CSV.
+	WriteErrorReport(ctx context.Context, id int, w io.Writer) *ErrorResponse
+	// FailInterrupted marks the jobs still running as failed and returns how
+	// many there were. Imports run in the process that started them, so at
+	// startup these are the jobs the last process left unfinished.
+	FailInterrupted(ctx context.Context) (int, error)
+}
+
+type importService struct {
+	repository ImportRepository
+	books      BookService
+	val        *validator.Validate
+	// shutdown is done when the server stops, and jobs is what it waits for
+	// before closing the db pool.
+	shutdown context.Context
+	jobs     *sync.WaitGroup
+}
+
+// NewImportService returns a service whose imports stop when shutdown is
+// done, each saving its state before leaving jobs.
+func NewImportService(shutdown context.Context, jobs *sync.WaitGroup, r ImportRepository, books BookService) ImportService {
+	return &importService{repository: r, books: books, val: NewValidator(), shutdown: shutdown, jobs: jobs}
+}
+
+func (s *importService) Start(ctx context.Context, upload ImportUpload) (ImportJob, *ErrorResponse) {
+	f, err := os.Open(upload.Path)
+	if err != nil {
+		os.Remove(upload.Path)
+		logrus.Error("error while opening the import upload. error is ", err)
+		return ImportJob{}, GetErrorResponseByCode(InternalServerError)
+	}
+	in := &countingReader{r: f}
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	discard := func() {
+		f.Close()
+		os.Remove(upload.Path)
+	}
+	header, err := reader.Read()
+	if err != nil {
+		discard()
+		logrus.Error("no csv header in the import upload. error is ", err)
+		return ImportJob{}, GetErrorResponse(BadRequest, "the file must start with a CSV header row", http.StatusBadRequest)
+	}
+	cols, err := resolveColumns(header, upload.Mapping)
+	if err != nil {
+		discard()
+		logrus.Error("invalid import mapping. error is ", err)
+		return ImportJob{}, GetErrorResponse(BadRequest, err.Error(), http.StatusBadRequest)
+	}
+	job := ImportJob{
+		Status:    ImportRunning,
+		DryRun:    upload.DryRun,
+		FileName:  upload.FileName,
+		FileSize:  upload.Size,
+		CreatedBy: ActorFromContext(ctx),
+		CreatedAt: time.Now().UTC(),
+	}
+	if job.ID, err = s.repository.Create(ctx, job); err != nil {
+		discard()
+		logrus.Error("error while creating the import job. error is ", err)
+		return ImportJob{}, GetErrorResponseByCode(InternalServerError)
+	}
+	// The import outlives the request but keeps its values, the actor
+	// among them. It stops with the server instead.
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(s.shutdown, cancel)
+	s.jobs.Add(1)
+	go func() {
+		defer s.jobs.Done()
+		defer cancel()
+		defer stop()
+		defer discard()
+		s.run(runCtx, job, in, reader, cols)
+	}()
+	return job, nil
+}
+
+// run reads the rows one at a time, so the file is never held in memory.
+func (s *importService) run(ctx context.Context, job ImportJob, in *countingReader, reader *csv.Reader, cols importColumns) {
+	// seen maps the duplicate key of every row imported so far to its row.
+	seen := map[string]int{}
+	rejected := []ImportError{}
+	for {
+		if ctx.Err() != nil {
+			s.finish(ctx, job, rejected, nil)
+			return
+		}
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			s.finish(ctx, job, rejected, err)
+			return
+		}
+		job.Rows++
+		row := job.Rows + 1
+		var rej *ImportError
+		if parseErr != nil {
+			rej = &ImportError{Reason: ImportRowInvalid, Message: parseErr.Err.Error()}
+		} else {
+			rej = s.importRow(ctx, job.DryRun, record, row, cols, seen)
+			if ctx.Err() != nil {
+				// The row may have been cut short, so it is left to a new import.
+				job.Rows--
+				s.finish(ctx, job, rejected, nil)
+				return
+			}
+		}
+		if rej != nil {
+			rej.JobID, rej.Row = job.ID, row
+			if rej.Reason == ImportRowDuplicate {
+				job.Duplicates++
+			} else {
+				job.Failed++
+			}
+			rejected = append(rejected, *rej)
+		} else {
+			job.Imported++
+		}
+		if job.Rows%importSaveEvery == 0 {
+			job.BytesRead = in.n
+			if err := s.repository.SaveProgress(ctx, job, rejected); err != nil {
+				s.finish(ctx, job, nil, err)
+				return
+			}
+			rejected = rejected[:0]
+		}
+	}
+	job.BytesRead = in.n
+	s.finish(ctx, job, rejected, nil)
+}
+
+// importRow imports a record, or checks that it could be imported in a dry
+// run, and returns why it was not otherwise.
+func (s *importService) importRow(ctx context.Context, dryRun bool, record []string, row int, cols importColumns, seen map[string]int) *ImportError {
+	req, err := cols.request(record)
+	if err != nil {
+		return &ImportError{Reason: ImportRowInvalid, Message: err.Error()}
+	}
+	if err := s.val.Struct(&req); err != nil {
+		return &ImportError{Reason: ImportRowInvalid, Message: ValidationMessage(err)}
+	}
+	var b Book
+	if errResp := applyRequest(&b, req); errResp != nil {
+		return &ImportError{Reason: ImportRowInvalid, Message: errResp.ErrorMessage}
+	}
+	key := duplicateKey(b)
+	if first, ok := seen[key]; ok {
+		return &ImportError{Reason: ImportRowDuplicate, Message: fmt.Sprintf("same book as row %d", first)}
+	}
+	id, err := s.repository.FindDuplicate(ctx, b.ISBN, b.Title, b.Author)
+	if err != nil {
+		logrus.Error("error while looking for a duplicate of import row ", row, ". error is ", err)
+		return &ImportError{Reason: ImportRowFailed, Message: GetErrorResponseByCode(InternalServerError).ErrorMessage}
+	}
+	if id > 0 {
+		return &ImportError{Reason: ImportRowDuplicate, Message: fmt.Sprintf("book %d already exists", id)}
+	}
+	if !dryRun {
+		if _, errResp := s.books.Create(ctx, req); errResp != nil {
+			return &ImportError{Reason: ImportRowFailed, Message: errResp.ErrorMessage}
+		}
+	}
+	seen[key] = row
+	return nil
+}
+
+// duplicateKey identifies a book by its isbn or, lacking one, by its title
+// and author, ignoring case.
+func duplicateKey(b Book) string {
+	if b.ISBN != "" {
+		return b.ISBN
+	}
+	return strings.ToLower(b.Title) + "\x00" + strings.ToLower(b.Author)
+}
+
+// finish saves the last rejected rows and the outcome of the job. A failure
+// is logged in full but reported on the job without internals. A job whose
+// ctx is done was interrupted, and is saved regardless before the server
+// closes the db pool.
+func (s *importService) finish(ctx context.Context, job ImportJob, rejected []ImportError, failure error) {
+	interrupted := ctx.Err() != nil
+	ctx = context.WithoutCancel(ctx)
+	if len(rejected) > 0 {
+		if err := s.repository.SaveProgress(ctx, job, rejected); err != nil && failure == nil {
+			failure = err
+		}
+	}
+	job.Status = ImportSucceeded
+	switch {
+	case interrupted:
+		logrus.Info("import ", job.ID, " interrupted by the server stopping after ", job.Rows, " rows")
+		job.Status = ImportFailed
+		job.Error = fmt.Sprintf("%s after %d rows", importInterrupted, job.Rows)
+	case failure != nil:
+		logrus.Error("import ", job.ID, " stopped after ", job.Rows, " rows. error is ", failure)
+		job.Status = ImportFailed
+		job.Error = fmt.Sprintf("the import stopped after %d rows because of an internal error", job.Rows)
+	}
+	if err := s.repository.Finish(ctx, job); err != nil {
+		logrus.Error("error while finishing import ", job.ID, ". error is ", err)
+	}
+}
+
+func (s *importService) FailInterrupted(ctx context.Context) (int, error) {
+	return s.repository.FailRunning(ctx, importInterrupted)
+}
+
+func (s *importService) Get(ctx context.Context, id int) (ImportJob, *ErrorResponse) {
+	job, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		if err == ErrImportNotFound {
+			logrus.Error("no import found for given id ", id)
+			return ImportJob{}, GetErrorResponseByCode(ImportNotFound)
+		}
+		logrus.Error("error while fetching import ", id, ". error is ", err)
+		return ImportJob{}, GetErrorResponseByCode(InternalServerError)
+	}
+	return job, nil
+}
+
+func (s *importService) WriteErrorReport(ctx context.Context, id int, w io.Writer) *ErrorResponse {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"row", "reason", "message"})
+	err := s.repository.EachError(ctx, id, func(e ImportError) error {
+		return cw.Write([]string{strconv.Itoa(e.Row), e.Reason, e.Message})
+	})
+	cw.Flush()
+	if err == nil {
+		err = cw.Error()
+	}
+	if err != nil {
+		logrus.Error("error while writing the error report of import ", id, ". error is ", err)
+		return GetErrorResponseByCode(InternalServerError)
+	}
+	return nil
+}
+
+// countingReader counts the bytes read through it, which gives the
+// progress of an import through its file.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,159 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	mock_book "book-store/internal/mocks"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportServiceTestSuite struct {
+	suite.Suite
+	importService   book.ImportService
+	mockRepo        *mock_book.MockImportRepository
+	mockBookService *mock_book.MockBookService
+	ctrl            *gomock.Controller
+}
+
+func TestImportServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportServiceTestSuite))
+}
+
+func (m *ImportServiceTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockRepo = mock_book.NewMockImportRepository(m.ctrl)
+	m.mockBookService = mock_book.NewMockBookService(m.ctrl)
+	m.importService = book.NewImportService(context.Background(), &sync.WaitGroup{}, m.mockRepo, m.mockBookService)
+}
+
+func (m *ImportServiceTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+// upload writes csv to a file the way the handler would.
+func (m *ImportServiceTestSuite) upload(csv string, mapping map[string]string, dryRun bool) book.ImportUpload {
+	path := filepath.Join(m.T().TempDir(), "catalog.csv")
+	m.Suite.Nil(os.WriteFile(path, []byte(csv), 0o600))
+	return book.ImportUpload{Path: path, FileName: "catalog.csv", Size: int64(len(csv)), Mapping: mapping, DryRun: dryRun}
+}
+
+// awaitFinish returns the job the import finished with.
+func (m *ImportServiceTestSuite) awaitFinish() <-chan book.ImportJob {
+	done := make(chan book.ImportJob, 1)
+	m.mockRepo.EXPECT().Finish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job book.ImportJob) error {
+		done <- job
+		return nil
+	})
+	return done
+}
+
+func (m *ImportServiceTestSuite) TestStart_ShouldImportValidRowsAndReportTheRest() {
+	csv := "\ufeffBook Title,Writer,ISBN,Year\n" +
+		"Dune,Frank Herbert,978-0-441-01359-3,1965\n" +
+		",Nobody,,\n" +
+		"Dune,Frank Herbert,9780441013593,1965\n" +
+		"Emma,Jane Austen,,18x5\n" +
+		"Persuasion,Jane Austen,,1817\n"
+	upload := m.upload(csv, map[string]string{"title": "Book Title", "author": "Writer", "publishedYear": "Year"}, false)
+	m.mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(7, nil)
+	m.mockRepo.EXPECT().FindDuplicate(gomock.Any(), "9780441013593", "Dune", "Frank Herbert").Return(0, nil)
+	m.mockRepo.EXPECT().FindDuplicate(gomock.Any(), "", "Persuasion", "Jane Austen").Return(5, nil)
+	m.mockBookService.EXPECT().Create(gomock.Any(), book.CreateOrUpdateBookRequest{
+		Title: "Dune", Author: "Frank Herbert", ISBN: "978-0-441-01359-3", PublishedYear: 1965,
+	}).Return(int64(12), nil)
+	m.mockRepo.EXPECT().SaveProgress(gomock.Any(), gomock.Any(), []book.ImportError{
+		{JobID: 7, Row: 3, Reason: book.ImportRowInvalid, Message: "Title failed on 'required'"},
+		{JobID: 7, Row: 4, Reason: book.ImportRowDuplicate, Message: "same book as row 2"},
+		{JobID: 7, Row: 5, Reason: book.ImportRowInvalid, Message: `publishedYear "18x5" is not a year`},
+		{JobID: 7, Row: 6, Reason: book.ImportRowDuplicate, Message: "book 5 already exists"},
+	}).Return(nil)
+	done := m.awaitFinish()
+
+	job, err := m.importService.Start(context.Background(), upload)
+	m.Suite.Nil(err)
+	m.Suite.Equal(7, job.ID)
+	select {
+	case job = <-done:
+	case <-time.After(5 * time.Second):
+		m.Suite.FailNow("the import did not finish")
+	}
+	m.Suite.Equal(book.ImportSucceeded, job.Status)
+	m.Suite.Equal(5, job.Rows)
+	m.Suite.Equal(1, job.Imported)
+	m.Suite.Equal(2, job.Duplicates)
+	m.Suite.Equal(2, job.Failed)
+	m.Suite.Equal(int64(len(csv)), job.BytesRead)
+}
+
+func (m *ImportServiceTestSuite) TestStart_ShouldNotCreateBooksInADryRun() {
+	upload := m.upload("title,author\nDune,Frank Herbert\n", nil, true)
+	m.mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(7, nil)
+	m.mockRepo.EXPECT().FindDuplicate(gomock.Any(), "", "Dune", "Frank Herbert").Return(0, nil)
+	done := m.awaitFinish()
+
+	_, err := m.importService.Start(context.Background(), upload)
+	m.Suite.Nil(err)
+	job := <-done
+	m.Suite.True(job.DryRun)
+	m.Suite.Equal(1, job.Imported)
+}
+
+func (m *ImportServiceTestSuite) TestStart_ShouldSaveAnImportInterruptedByShutdown() {
+	shutdown, stop := context.WithCancel(context.Background())
+	defer stop()
+	var jobs sync.WaitGroup
+	importService := book.NewImportService(shutdown, &jobs, m.mockRepo, m.mockBookService)
+	upload := m.upload("title,author\nEmma,Jane Austen\nDune,Frank Herbert\nMortality,Terry Pratchett\n", nil, false)
+	m.mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(7, nil)
+	m.mockRepo.EXPECT().FindDuplicate(gomock.Any(), "", gomock.Any(), gomock.Any()).Return(0, nil).Times(2)
+	m.mockBookService.EXPECT().Create(gomock.Any(), book.CreateOrUpdateBookRequest{Title: "Emma", Author: "Jane Austen"}).
+		Return(int64(12), nil)
+	m.mockBookService.EXPECT().Create(gomock.Any(), book.CreateOrUpdateBookRequest{Title: "Dune", Author: "Frank Herbert"}).
+		DoAndReturn(func(ctx context.Context, _ book.CreateOrUpdateBookRequest) (int64, *book.ErrorResponse) {
+			stop()
+			<-ctx.Done()
+			return 0, book.GetErrorResponseByCode(book.InternalServerError)
+		})
+	done := m.awaitFinish()
+
+	_, err := importService.Start(context.Background(), upload)
+	m.Suite.Nil(err)
+	jobs.Wait()
+	job := <-done
+	m.Suite.Equal(book.ImportFailed, job.Status)
+	m.Suite.Equal(1, job.Rows)
+	m.Suite.Equal(1, job.Imported)
+	m.Suite.Equal("the import was interrupted when the server stopped after 1 rows", job.Error)
+}
+
+func (m *ImportServiceTestSuite) TestStart_ShouldRejectHeadersTheMappingDoesNotFit() {
+	for mapping, msg := range map[string]string{
+		"isbn13": "mapping has unknown fields isbn13; fields are title, author, description, isbn, genre, language, publishedYear",
+		"author": `column "Writer" mapped to author is not in the header`,
+		"":       "no column for author; map one in mapping",
+	} {
+		var fields map[string]string
+		if mapping != "" {
+			fields = map[string]string{mapping: "Writer"}
+		}
+		upload := m.upload("title,by\nDune,Frank Herbert\n", fields, false)
+		_, err := m.importService.Start(context.Background(), upload)
+		m.Suite.Equal(book.GetErrorResponse(book.BadRequest, msg, http.StatusBadRequest), err)
+		_, statErr := os.Stat(upload.Path)
+		m.Suite.True(os.IsNotExist(statErr))
+	}
+}
+
+func (m *ImportServiceTestSuite) TestGet_ShouldReturnImportNotFound() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 7).Return(book.ImportJob{}, book.ErrImportNotFound)
+	_, err := m.importService.Get(context.Background(), 7)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.ImportNotFound), err)
+}
@@ -0,0 +1,92 @@
+package book
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var ErrInvalidISBN = errors.New("isbn is invalid")
+
+// NormalizeISBN validates an ISBN-10 or ISBN-13, ignoring hyphens and spaces,
+// and returns it as a bare ISBN-13.
+func NormalizeISBN(s string) (string, error) {
+	digits := strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(s))
+	switch len(digits) {
+	case 10:
+		if !validISBN10(digits) {
+			return "", ErrInvalidISBN
+		}
+		isbn13 := "978" + digits[:9]
+		return isbn13 + string(isbn13CheckDigit(isbn13)), nil
+	case 13:
+		if !validISBN13(digits) {
+			return "", ErrInvalidISBN
+		}
+		return digits, nil
+	}
+	return "", ErrInvalidISBN
+}
+
+// ISBN10 converts a normalized ISBN-13 back to ISBN-10. Only 978-prefixed
+// numbers have an ISBN-10 form; for anything else it returns "".
+func ISBN10(isbn13 string) string {
+	if len(isbn13) != 13 || !strings.HasPrefix(isbn13, "978") {
+		return ""
+	}
+	body := isbn13[3:12]
+	sum := 0
+	for i, c := range body {
+		sum += (10 - i) * int(c-'0')
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return body + "X"
+	}
+	return body + string(rune('0'+check))
+}
+
+func validISBN10(s string) bool {
+	sum := 0
+	for i, c := range s {
+		var d int
+		switch {
+		case c >= '0' && c <= '9':
+			d = int(c - '0')
+		case (c == 'X' || c == 'x') && i == 9:
+			d = 10
+		default:
+			return false
+		}
+		sum += (10 - i) * d
+	}
+	return sum%11 == 0
+}
+
+func validISBN13(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return isbn13CheckDigit(s[:12]) == rune(s[12])
+}
+
+func isbn13CheckDigit(first12 string) rune {
+	sum := 0
+	for i, c := range first12[:12] {
+		d := int(c - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return rune('0' + (10-sum%10)%10)
+}
+
+// validateISBN backs the `isbn` validation tag registered by NewValidator.
+func validateISBN(fl validator.FieldLevel) bool {
+	_, err := NormalizeISBN(fl.Field().String())
+	return err == nil
+}
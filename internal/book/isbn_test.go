@@ -0,0 +1,35 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	valid := map[string]string{
+		"0747532699":        "9780747532699",
+		"0-7475-3269-9":     "9780747532699",
+		"978-0-7475-3269-9": "9780747532699",
+		"9780747532699":     "9780747532699",
+		"080442957X":        "9780804429573",
+		"979-10-90636-07-1": "9791090636071",
+	}
+	for in, want := range valid {
+		got, err := book.NormalizeISBN(in)
+		require.NoError(t, err, in)
+		require.Equal(t, want, got, in)
+	}
+	for _, in := range []string{"", "0747532698", "9780747532690", "97807475326", "X747532699", "978074753269A"} {
+		_, err := book.NormalizeISBN(in)
+		require.ErrorIs(t, err, book.ErrInvalidISBN, in)
+	}
+}
+
+func TestISBN10(t *testing.T) {
+	require.Equal(t, "0747532699", book.ISBN10("9780747532699"))
+	require.Equal(t, "080442957X", book.ISBN10("9780804429573"))
+	require.Equal(t, "", book.ISBN10("9791090636071"))
+	require.Equal(t, "", book.ISBN10(""))
+}
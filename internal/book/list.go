@@ -0,0 +1,217 @@
+package book
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+// BookFilter narrows List. Empty fields do not filter. Title and Author match
+// case-insensitively on part of the value; Author also matches the names of
+// the linked authors. Genre and Language match exactly, as they are picked
+// from the facets, and Decade is the first year of a decade such as 1990.
+// IDs limits the books to those ids.
+type BookFilter struct {
+	IDs           []int
+	Title         string
+	Author        string
+	AuthorID      int
+	Available     *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Genre         string
+	Language      string
+	Decade        int
+}
+
+// BookScroll is a page of books in cursor mode. NextCursor is empty on the
+// last page and Total is only set when it was asked for.
+type BookScroll struct {
+	Books      []Book
+	NextCursor string
+	Total      *int
+}
+
+// SortField orders List by one field, ascending unless Desc is set.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// sortColumn describes a sortable field: the expression it is ordered by, the
+// same expression applied to a query argument for keyset comparisons, and the
+// text form of a book's value of it, which the cast in param reads back.
+type sortColumn struct {
+	expr  string
+	param string
+	key   func(b Book) string
+}
+
+// bookSortColumns is the whitelist of fields books can be sorted by. Only
+// these expressions ever reach the ORDER BY and keyset clauses.
+var bookSortColumns = map[string]sortColumn{
+	"id":        {expr: "b.id", param: "$%d::int", key: func(b Book) string { return strconv.Itoa(b.ID) }},
+	"title":     {expr: "lower(b.title)", param: "lower($%d::text)", key: func(b Book) string { return b.Title }},
+	"author":    {expr: "lower(b.author)", param: "lower($%d::text)", key: func(b Book) string { return b.Author }},
+	"createdAt": {expr: "b.created_at", param: "$%d::timestamptz", key: func(b Book) string { return b.CreatedAt.UTC().Format(time.RFC3339Nano) }},
+}
+
+// ParseSort reads a comma separated list of fields such as "title,-createdAt",
+// where a leading minus sorts that field in descending order.
+func ParseSort(s string) ([]SortField, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var fields []SortField
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		f := SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if _, ok := bookSortColumns[f.Field]; !ok {
+			return nil, fmt.Errorf("%w: unknown field '%s', use one of id, title, author, createdAt", ErrInvalidSort, f.Field)
+		}
+		if seen[f.Field] {
+			return nil, fmt.Errorf("%w: field '%s' given twice", ErrInvalidSort, f.Field)
+		}
+		seen[f.Field] = true
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// withTieBreak appends id to the sort unless it is already part of it, so
+// the order is total and pages are stable.
+func withTieBreak(sort []SortField) []SortField {
+	for _, f := range sort {
+		if f.Field == "id" {
+			return sort
+		}
+	}
+	return append(append([]SortField{}, sort...), SortField{Field: "id"})
+}
+
+func orderBy(sort []SortField) (string, error) {
+	var terms []string
+	for _, f := range withTieBreak(sort) {
+		col, ok := bookSortColumns[f.Field]
+		if !ok {
+			return "", fmt.Errorf("%w: unknown field '%s'", ErrInvalidSort, f.Field)
+		}
+		term := col.expr
+		if f.Desc {
+			term += " DESC"
+		}
+		terms = append(terms, term)
+	}
+	return "ORDER BY " + strings.Join(terms, ", "), nil
+}
+
+// keysetClause renders the condition selecting the rows that sort after the
+// row with the given keys, one per field of withTieBreak(sort). Arguments are
+// numbered from $first.
+func keysetClause(sort []SortField, keys []string, first int) (string, []any, error) {
+	sort = withTieBreak(sort)
+	if len(keys) != len(sort) {
+		return "", nil, fmt.Errorf("%w: %d keys for %d sort fields", ErrInvalidSort, len(keys), len(sort))
+	}
+	var params []string
+	for i, f := range sort {
+		col, ok := bookSortColumns[f.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: unknown field '%s'", ErrInvalidSort, f.Field)
+		}
+		params = append(params, fmt.Sprintf(col.param, first+i))
+	}
+	// (a > x) OR (a = x AND b > y) OR ..., flipping the comparison of
+	// descending fields.
+	var alternatives []string
+	for i, f := range sort {
+		var conds []string
+		for j := 0; j < i; j++ {
+			conds = append(conds, bookSortColumns[sort[j].Field].expr+" = "+params[j])
+		}
+		op := " > "
+		if f.Desc {
+			op = " < "
+		}
+		conds = append(conds, bookSortColumns[f.Field].expr+op+params[i])
+		alternatives = append(alternatives, "("+strings.Join(conds, " AND ")+")")
+	}
+	args := make([]any, len(keys))
+	for i, k := range keys {
+		args[i] = k
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args, nil
+}
+
+// sortKeys returns the values of the book for every field of
+// withTieBreak(sort).
+func sortKeys(sort []SortField, b Book) []string {
+	var keys []string
+	for _, f := range withTieBreak(sort) {
+		keys = append(keys, bookSortColumns[f.Field].key(b))
+	}
+	return keys
+}
+
+// whereClause renders the filter as a WHERE clause, which always leaves out
+// trashed books. Values are only ever passed as arguments, numbered from $1.
+func whereClause(f BookFilter) (string, []any) {
+	conds := []string{"b.deleted_at IS NULL"}
+	var args []any
+	add := func(format string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(format, len(args)))
+	}
+	if len(f.IDs) > 0 {
+		ids := make([]int64, len(f.IDs))
+		for i, id := range f.IDs {
+			ids[i] = int64(id)
+		}
+		add(`b.id = ANY($%d)`, pq.Array(ids))
+	}
+	if f.Title != "" {
+		add(`b.title ILIKE '%%' || $%d || '%%'`, f.Title)
+	}
+	if f.Author != "" {
+		add(`(b.author ILIKE '%%' || $%[1]d || '%%' OR EXISTS (
+                SELECT 1 FROM book_authors ba JOIN authors a ON a.id = ba.author_id
+                WHERE ba.book_id = b.id AND a.name ILIKE '%%' || $%[1]d || '%%'))`, f.Author)
+	}
+	if f.AuthorID != 0 {
+		add(`EXISTS (SELECT 1 FROM book_authors ba WHERE ba.book_id = b.id AND ba.author_id = $%d)`, f.AuthorID)
+	}
+	if f.Available != nil {
+		add(`EXISTS (SELECT 1 FROM copies c WHERE c.book_id = b.id AND c.status = 'available') = $%d`, *f.Available)
+	}
+	if f.CreatedAfter != nil {
+		add(`b.created_at >= $%d`, *f.CreatedAfter)
+	}
+	if f.CreatedBefore != nil {
+		add(`b.created_at < $%d`, *f.CreatedBefore)
+	}
+	if f.Genre != "" {
+		add(`b.genre = $%d`, f.Genre)
+	}
+	if f.Language != "" {
+		add(`b.language = $%d`, f.Language)
+	}
+	if f.Decade != 0 {
+		add(`b.published_year BETWEEN $%[1]d AND $%[1]d + 9`, f.Decade)
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// andWhere adds a condition to a clause built by whereClause.
+func andWhere(where, cond string) string {
+	if where == "" {
+		return "WHERE " + cond
+	}
+	return where + " AND " + cond
+}
@@ -0,0 +1,26 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSort(t *testing.T) {
+	sort, err := book.ParseSort(" title, -createdAt ")
+	require.NoError(t, err)
+	require.Equal(t, []book.SortField{{Field: "title"}, {Field: "createdAt", Desc: true}}, sort)
+
+	sort, err = book.ParseSort("")
+	require.NoError(t, err)
+	require.Nil(t, sort)
+}
+
+func TestParseSort_ShouldRejectFieldsOutsideTheWhitelist(t *testing.T) {
+	for _, in := range []string{"isbn", "title;DROP TABLE books", "title,,id", "-title,title", "created_at"} {
+		_, err := book.ParseSort(in)
+		require.True(t, errors.Is(err, book.ErrInvalidSort), in)
+	}
+}
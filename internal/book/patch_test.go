@@ -0,0 +1,83 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	cases := []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":["c","d"]}`, `{"a":["c","d"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+	}
+	for _, c := range cases {
+		got, err := book.ApplyMergePatch([]byte(c.doc), []byte(c.patch))
+		require.NoError(t, err)
+		require.JSONEq(t, c.want, string(got), c.patch)
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	cases := []struct {
+		doc, patch, want string
+	}{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"foo":"bar","baz":"qux"}`},
+		{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":"qux"}]`, `{"foo":["bar","qux"]}`},
+		{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{`{"foo":"bar"}`, `[{"op":"replace","path":"/foo","value":"qux"}]`, `{"foo":"qux"}`},
+		{`{"foo":{"bar":"baz"},"qux":{}}`, `[{"op":"move","from":"/foo/bar","path":"/qux/thud"}]`, `{"foo":{},"qux":{"thud":"baz"}}`},
+		{`{"foo":["a","b","c","d"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["a","c","d","b"]}`},
+		{`{"foo":{"a":1}}`, `[{"op":"copy","from":"/foo","path":"/bar"}]`, `{"foo":{"a":1},"bar":{"a":1}}`},
+		{`{"a/b":1,"m~n":2}`, `[{"op":"test","path":"/a~1b","value":1},{"op":"remove","path":"/m~0n"}]`, `{"a/b":1}`},
+		{`{"foo":1}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+	}
+	for _, c := range cases {
+		got, err := book.ApplyJSONPatch([]byte(c.doc), []byte(c.patch))
+		require.NoError(t, err, c.patch)
+		require.JSONEq(t, c.want, string(got), c.patch)
+	}
+}
+
+func TestApplyJSONPatch_ShouldFailAsAWhole(t *testing.T) {
+	cases := []struct {
+		patch string
+		err   error
+	}{
+		{`[{"op":"remove","path":"/missing"}]`, book.ErrInvalidPatch},
+		{`[{"op":"replace","path":"/missing","value":1}]`, book.ErrInvalidPatch},
+		{`[{"op":"add","path":"/list/5","value":1}]`, book.ErrInvalidPatch},
+		{`[{"op":"add","path":"/list/01","value":1}]`, book.ErrInvalidPatch},
+		{`[{"op":"add","path":"list","value":1}]`, book.ErrInvalidPatch},
+		{`[{"op":"add","path":"/a"}]`, book.ErrInvalidPatch},
+		{`[{"op":"move","from":"/obj","path":"/obj/child"}]`, book.ErrInvalidPatch},
+		{`[{"op":"frobnicate","path":"/a"}]`, book.ErrInvalidPatch},
+		{`{"op":"add","path":"/a","value":1}`, book.ErrInvalidPatch},
+		{`[{"op":"add","path":"/a","value":1},{"op":"test","path":"/list/0","value":"2"}]`, book.ErrPatchTestFailed},
+	}
+	for _, c := range cases {
+		_, err := book.ApplyJSONPatch([]byte(`{"list":[2],"obj":{}}`), []byte(c.patch))
+		require.True(t, errors.Is(err, c.err), "%s: %v", c.patch, err)
+	}
+}
+
+func TestApplyJSONPatch_ShouldNotShareCopiedValues(t *testing.T) {
+	got, err := book.ApplyJSONPatch([]byte(`{"a":{"b":1}}`),
+		[]byte(`[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`))
+	require.NoError(t, err)
+	var doc map[string]map[string]int
+	require.NoError(t, json.Unmarshal(got, &doc))
+	require.Equal(t, 1, doc["a"]["b"])
+	require.Equal(t, 2, doc["c"]["b"])
+}
@@ -3,34 +3,105 @@ package book
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrNotFound       = errors.New("book not found")
+	ErrDuplicateISBN  = errors.New("isbn already exists")
+	ErrAuthorNotFound = errors.New("author not found")
+	// ErrVersionConflict means the book changed or went away since the
+	// version the caller holds was read.
+	ErrVersionConflict = errors.New("book version conflict")
+	// ErrBookInCirculation refuses to trash a book that is lent or held.
+	ErrBookInCirculation = errors.New("book has open loans or holds")
+	ErrRevisionNotFound  = errors.New("revision not found")
 )
 
-var ErrNotFound = errors.New("book not found")
+// exportFetchSize is how many books Export reads from its cursor at a time.
+const exportFetchSize = 500
+
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
 
 type BookRepository interface {
 	Create(ctx context.Context, b Book) (int64, error)
 	GetByID(ctx context.Context, id int) (Book, error)
-	List(ctx context.Context,limit, offset int) ([]Book,int, error)
-	Update(ctx context.Context, b Book) error
-	Delete(ctx context.Context, id int) error
+	GetByISBN(ctx context.Context, isbn string) (Book, error)
+	// List pages through the books matching the filter in the given order.
+	List(ctx context.Context, f BookFilter, sort []SortField, limit, offset int) ([]Book, int, error)
+	// ListAfter returns up to limit books matching the filter that sort after
+	// the book with the given sort keys, or from the start when after is nil.
+	ListAfter(ctx context.Context, f BookFilter, sort []SortField, after []string, limit int) ([]Book, error)
+	// Export calls fn with every book matching the filter in the given
+	// order, a batch at a time, all read from one snapshot of the catalog.
+	Export(ctx context.Context, f BookFilter, sort []SortField, fn func([]Book) error) error
+	Count(ctx context.Context, f BookFilter) (int, error)
+	// Search pages through the books matching the tsquery and the filter, most
+	// relevant first.
+	Search(ctx context.Context, tsquery string, f BookFilter, limit, offset int) ([]SearchResult, int, error)
+	// Facets counts the books matching the filter, and the tsquery unless it
+	// is empty, per facet value.
+	Facets(ctx context.Context, f BookFilter, tsquery string) (Facets, error)
+	// Suggest returns up to limit books whose title or author resembles q,
+	// best match first.
+	Suggest(ctx context.Context, q string, limit int) ([]Suggestion, error)
+	// Update writes the book if it is still at b.Version and returns its new
+	// version.
+	Update(ctx context.Context, b Book) (int, error)
+	// Upsert inserts the book at b.ID or, when that id is taken, replaces the
+	// book provided it is still at b.Version. It reports whether it inserted
+	// and returns the resulting version.
+	Upsert(ctx context.Context, b Book) (bool, int, error)
+	// Delete moves the book to the trash if it is still at the given version.
+	Delete(ctx context.Context, id, version int) error
+	// ListTrash pages through the trashed books, most recently deleted first.
+	ListTrash(ctx context.Context, limit, offset int) ([]Book, int, error)
+	// InTrash reports whether the book with the id is in the trash.
+	InTrash(ctx context.Context, id int) (bool, error)
+	// Restore takes the book out of the trash and returns its new version.
+	Restore(ctx context.Context, id int) (int, error)
+	// Purge removes the books trashed before the given time for good.
+	Purge(ctx context.Context, before time.Time) (int, error)
+	// ApplyBatch runs the creates, updates and deletes of a batch and
+	// returns the outcome of each, in one transaction when atomic.
+	ApplyBatch(ctx context.Context, writes []BatchWrite, atomic bool) ([]BatchOutcome, error)
+	// ListRevisions pages through the revisions of a book, newest first.
+	ListRevisions(ctx context.Context, bookID, limit, offset int) ([]Revision, int, error)
+	GetRevision(ctx context.Context, bookID, revision int) (Revision, error)
 }
 
 type sqlBookRepo struct {
 	db *sql.DB
 }
 
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
 func NewBookRepository(db *sql.DB) BookRepository {
 	return &sqlBookRepo{db: db}
 }
 
 func (r *sqlBookRepo) Create(ctx context.Context, b Book) (int64, error) {
 	var id int64
-	err := r.db.QueryRowContext(ctx,
-		`INSERT INTO books (title, author, description) VALUES ($1, $2, $3) RETURNING id`,
-		b.Title, b.Author, b.Description).Scan(&id)
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		id, err = insertBook(ctx, tx, b)
+		return err
+	})
 	if err != nil {
-		return 0, err
+		return 0, translateErr(err)
 	}
 	return id, nil
 }
@@ -38,45 +109,777 @@ func (r *sqlBookRepo) Create(ctx context.Context, b Book) (int64, error) {
 func (r *sqlBookRepo) GetByID(ctx context.Context, id int) (Book, error) {
 	b := Book{}
 	err := r.db.QueryRowContext(ctx,
-		`SELECT id, title, author, description FROM books WHERE id = $1`, id).
-		Scan(&b.ID, &b.Title, &b.Author, &b.Description)
+		`SELECT id, title, author, description, COALESCE(isbn, ''), created_at,
+		        COALESCE(genre, ''), COALESCE(language, ''), COALESCE(published_year, 0), version FROM books WHERE id = $1 AND deleted_at IS NULL`, id).
+		Scan(&b.ID, &b.Title, &b.Author, &b.Description, &b.ISBN, &b.CreatedAt, &b.Genre, &b.Language, &b.PublishedYear, &b.Version)
+	if err == sql.ErrNoRows {
+		return Book{}, ErrNotFound
+	}
+	if err != nil {
+		return Book{}, err
+	}
+	books := []Book{b}
+	if err := loadDetails(ctx, r.db, books); err != nil {
+		return Book{}, err
+	}
+	return books[0], nil
+}
+
+func (r *sqlBookRepo) GetByISBN(ctx context.Context, isbn string) (Book, error) {
+	b := Book{}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, title, author, description, COALESCE(isbn, ''), created_at,
+		        COALESCE(genre, ''), COALESCE(language, ''), COALESCE(published_year, 0), version FROM books WHERE isbn = $1 AND deleted_at IS NULL`, isbn).
+		Scan(&b.ID, &b.Title, &b.Author, &b.Description, &b.ISBN, &b.CreatedAt, &b.Genre, &b.Language, &b.PublishedYear, &b.Version)
 	if err == sql.ErrNoRows {
 		return Book{}, ErrNotFound
 	}
-	return b, err
+	if err != nil {
+		return Book{}, err
+	}
+	books := []Book{b}
+	if err := loadDetails(ctx, r.db, books); err != nil {
+		return Book{}, err
+	}
+	return books[0], nil
 }
 
-func (r *sqlBookRepo) List(ctx context.Context,limit, offset int) ([]Book,int, error) {
-	  rows, err := r.db.QueryContext(ctx, `
-        SELECT id, title, author, description,
+// List builds its WHERE and ORDER BY clauses from the whitelisted filter and
+// sort fields only; user input reaches the query as arguments.
+func (r *sqlBookRepo) List(ctx context.Context, f BookFilter, sort []SortField, limit, offset int) ([]Book, int, error) {
+	order, err := orderBy(sort)
+	if err != nil {
+		return nil, 0, err
+	}
+	where, args := whereClause(f)
+	args = append(args, limit, offset)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+        SELECT b.id, b.title, b.author, b.description, COALESCE(b.isbn, ''), b.created_at,
+               COALESCE(b.genre, ''), COALESCE(b.language, ''), COALESCE(b.published_year, 0),
                COUNT(*) OVER() AS total_count
-        FROM books
-        ORDER BY id
-        LIMIT $1 OFFSET $2`, limit, offset)
+        FROM books b
+        %s
+        %s
+        LIMIT $%d OFFSET $%d`, where, order, len(args)-1, len(args)), args...)
 	if err != nil {
-		return nil,0, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 	var books []Book
 	var total int
 	for rows.Next() {
 		b := Book{}
-		if err := rows.Scan(&b.ID, &b.Title, &b.Author, &b.Description,&total); err != nil {
-			return nil,0, err
+		if err := rows.Scan(&b.ID, &b.Title, &b.Author, &b.Description, &b.ISBN, &b.CreatedAt, &b.Genre, &b.Language, &b.PublishedYear, &total); err != nil {
+			return nil, 0, err
 		}
 		books = append(books, b)
 	}
-	return books,total, nil
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if err := loadDetails(ctx, r.db, books); err != nil {
+		return nil, 0, err
+	}
+	return books, total, nil
+}
+
+// ListAfter seeks past the previous page through the sort keys instead of
+// skipping rows, so every page costs the same however deep it is.
+func (r *sqlBookRepo) ListAfter(ctx context.Context, f BookFilter, sort []SortField, after []string, limit int) ([]Book, error) {
+	order, err := orderBy(sort)
+	if err != nil {
+		return nil, err
+	}
+	where, args := whereClause(f)
+	if after != nil {
+		keyset, keyArgs, err := keysetClause(sort, after, len(args)+1)
+		if err != nil {
+			return nil, err
+		}
+		where = andWhere(where, keyset)
+		args = append(args, keyArgs...)
+	}
+	args = append(args, limit)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+        SELECT b.id, b.title, b.author, b.description, COALESCE(b.isbn, ''), b.created_at,
+               COALESCE(b.genre, ''), COALESCE(b.language, ''), COALESCE(b.published_year, 0)
+        FROM books b
+        %s
+        %s
+        LIMIT $%d`, where, order, len(args)), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	books := []Book{}
+	for rows.Next() {
+		b := Book{}
+		if err := rows.Scan(&b.ID, &b.Title, &b.Author, &b.Description, &b.ISBN, &b.CreatedAt, &b.Genre, &b.Language, &b.PublishedYear); err != nil {
+			return nil, err
+		}
+		books = append(books, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := loadDetails(ctx, r.db, books); err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// Export reads through a cursor in a read-only repeatable-read transaction,
+// so however long the client takes, the export is one consistent snapshot
+// and only a single batch of books is held in memory.
+func (r *sqlBookRepo) Export(ctx context.Context, f BookFilter, sort []SortField, fn func([]Book) error) error {
+	order, err := orderBy(sort)
+	if err != nil {
+		return err
+	}
+	where, args := whereClause(f)
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	// Nothing is written, so ending the transaction either way is the same.
+	defer tx.Rollback()
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+        DECLARE book_export NO SCROLL CURSOR FOR
+        SELECT b.id, b.title, b.author, b.description, COALESCE(b.isbn, ''), b.created_at,
+               COALESCE(b.genre, ''), COALESCE(b.language, ''), COALESCE(b.published_year, 0), b.version
+        FROM books b
+        %s
+        %s`, where, order), args...)
+	if err != nil {
+		return err
+	}
+	for {
+		books, err := fetchBooks(ctx, tx, fmt.Sprintf(`FETCH %d FROM book_export`, exportFetchSize))
+		if err != nil {
+			return err
+		}
+		if len(books) == 0 {
+			return nil
+		}
+		if err := loadDetails(ctx, tx, books); err != nil {
+			return err
+		}
+		if err := fn(books); err != nil {
+			return err
+		}
+		if len(books) < exportFetchSize {
+			return nil
+		}
+	}
+}
+
+// fetchBooks reads the rows of a FETCH from the export cursor.
+func fetchBooks(ctx context.Context, q queryer, fetch string) ([]Book, error) {
+	rows, err := q.QueryContext(ctx, fetch)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	books := make([]Book, 0, exportFetchSize)
+	for rows.Next() {
+		b := Book{}
+		if err := rows.Scan(&b.ID, &b.Title, &b.Author, &b.Description, &b.ISBN, &b.CreatedAt, &b.Genre, &b.Language, &b.PublishedYear, &b.Version); err != nil {
+			return nil, err
+		}
+		books = append(books, b)
+	}
+	return books, rows.Err()
+}
+
+func (r *sqlBookRepo) Count(ctx context.Context, f BookFilter) (int, error) {
+	where, args := whereClause(f)
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM books b `+where, args...).Scan(&total)
+	return total, err
+}
+
+// Search ranks and pages the matches first and highlights only the page, since
+// ts_headline has to re-parse the text of every row it is given.
+func (r *sqlBookRepo) Search(ctx context.Context, tsquery string, f BookFilter, limit, offset int) ([]SearchResult, int, error) {
+	where, args := whereClause(f)
+	where = andWhere(where, "b.search_vector @@ q")
+	args = append(args, tsquery, limit, offset)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+        SELECT b.id, b.title, b.author, b.description, COALESCE(b.isbn, ''), b.created_at,
+               COALESCE(b.genre, ''), COALESCE(b.language, ''), COALESCE(b.published_year, 0), m.rank,
+               ts_headline('english', b.title, m.q, 'HighlightAll=true, StartSel=<mark>, StopSel=</mark>'),
+               ts_headline('english', b.description, m.q, 'MaxFragments=2, MaxWords=30, MinWords=10, StartSel=<mark>, StopSel=</mark>'),
+               m.total_count
+        FROM (
+            SELECT b.id, q, ts_rank(b.search_vector, q) AS rank, COUNT(*) OVER() AS total_count
+            FROM books b, to_tsquery('english', $%d) q
+            %s
+            ORDER BY rank DESC, b.id
+            LIMIT $%d OFFSET $%d
+        ) m
+        JOIN books b ON b.id = m.id
+        ORDER BY m.rank DESC, b.id`, len(args)-2, where, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	var results []SearchResult
+	var total int
+	for rows.Next() {
+		s := SearchResult{}
+		if err := rows.Scan(&s.ID, &s.Title, &s.Author, &s.Description, &s.ISBN, &s.CreatedAt, &s.Genre, &s.Language, &s.PublishedYear, &s.Rank,
+			&s.TitleHighlight, &s.DescriptionHighlight, &total); err != nil {
+			return nil, 0, err
+		}
+		results = append(results, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	books := make([]Book, len(results))
+	for i, s := range results {
+		books[i] = s.Book
+	}
+	if err := loadDetails(ctx, r.db, books); err != nil {
+		return nil, 0, err
+	}
+	for i := range results {
+		results[i].Book = books[i]
+	}
+	return results, total, nil
+}
+
+// Suggest filters with the <% operator so the trigram indexes on title and
+// author pick the candidates, and only scores those.
+func (r *sqlBookRepo) Suggest(ctx context.Context, q string, limit int) ([]Suggestion, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, title, author, score
+        FROM (
+            SELECT b.id, b.title, b.author,
+                   GREATEST(word_similarity($1, b.title), word_similarity($1, b.author)) AS score
+            FROM books b
+            WHERE ($1 <% b.title OR $1 <% b.author) AND b.deleted_at IS NULL
+        ) s
+        ORDER BY score DESC, title, id
+        LIMIT $2`, q, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	suggestions := []Suggestion{}
+	for rows.Next() {
+		s := Suggestion{}
+		if err := rows.Scan(&s.ID, &s.Title, &s.Author, &s.Score); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, s)
+	}
+	return suggestions, rows.Err()
+}
+
+// Facets gathers every facet in one statement: the matching books are
+// selected once and counted per author, genre, language and decade, keeping
+// the most frequent values of each.
+func (r *sqlBookRepo) Facets(ctx context.Context, f BookFilter, tsquery string) (Facets, error) {
+	where, args := whereClause(f)
+	if tsquery != "" {
+		args = append(args, tsquery)
+		where = andWhere(where, fmt.Sprintf("b.search_vector @@ to_tsquery('english', $%d)", len(args)))
+	}
+	args = append(args, maxFacetValues)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+        WITH matched AS (
+            SELECT b.id, b.genre, b.language, b.published_year FROM books b %s
+        ), counts AS (
+            SELECT 'author' AS facet, a.id::text AS value, a.name AS label, COUNT(DISTINCT m.id) AS n
+            FROM matched m
+            JOIN book_authors ba ON ba.book_id = m.id
+            JOIN authors a ON a.id = ba.author_id
+            GROUP BY a.id, a.name
+            UNION ALL
+            SELECT 'genre', genre, '', COUNT(*) FROM matched WHERE genre IS NOT NULL GROUP BY genre
+            UNION ALL
+            SELECT 'language', language, '', COUNT(*) FROM matched WHERE language IS NOT NULL GROUP BY language
+            UNION ALL
+            SELECT 'decade', (published_year / 10 * 10)::text, (published_year / 10 * 10)::text || 's', COUNT(*)
+            FROM matched WHERE published_year IS NOT NULL GROUP BY 2, 3
+        )
+        SELECT facet, value, label, n
+        FROM (
+            SELECT facet, value, label, n, row_number() OVER (PARTITION BY facet ORDER BY n DESC, value) AS pos
+            FROM counts
+        ) c
+        WHERE pos <= $%d
+        ORDER BY facet, pos`, where, len(args)), args...)
+	if err != nil {
+		return Facets{}, err
+	}
+	defer rows.Close()
+	facets := Facets{Authors: []FacetValue{}, Genres: []FacetValue{}, Languages: []FacetValue{}, Decades: []FacetValue{}}
+	for rows.Next() {
+		var facet string
+		v := FacetValue{}
+		if err := rows.Scan(&facet, &v.Value, &v.Label, &v.Count); err != nil {
+			return Facets{}, err
+		}
+		switch facet {
+		case "author":
+			facets.Authors = append(facets.Authors, v)
+		case "genre":
+			facets.Genres = append(facets.Genres, v)
+		case "language":
+			facets.Languages = append(facets.Languages, v)
+		case "decade":
+			facets.Decades = append(facets.Decades, v)
+		}
+	}
+	return facets, rows.Err()
+}
+
+// Update rewrites the book row. The author links are replaced only when
+// b.Authors is non-nil, so callers that never loaded them leave them intact.
+func (r *sqlBookRepo) Update(ctx context.Context, b Book) (int, error) {
+	var version int
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		version, err = updateBook(ctx, tx, b)
+		return err
+	})
+	if err != nil {
+		return 0, translateErr(err)
+	}
+	return version, nil
+}
+
+func (r *sqlBookRepo) Upsert(ctx context.Context, b Book) (bool, int, error) {
+	var created, derived bool
+	var version int
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		// xmax is only set on the row when ON CONFLICT updated it.
+		err := tx.QueryRowContext(ctx,
+			`INSERT INTO books AS b (id, title, author, description, isbn, genre, language, published_year, byline_derived)
+             VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, 0), $3 = '')
+             ON CONFLICT (id) DO UPDATE SET title=EXCLUDED.title, author=EXCLUDED.author, description=EXCLUDED.description,
+                 isbn=EXCLUDED.isbn, genre=EXCLUDED.genre, language=EXCLUDED.language,
+                 published_year=EXCLUDED.published_year, version=b.version+1,
+                 byline_derived = (EXCLUDED.byline_derived OR (b.byline_derived AND b.author = EXCLUDED.author))
+             WHERE b.version = $9 AND b.deleted_at IS NULL
+             RETURNING b.version, b.xmax = 0, b.byline_derived`,
+			b.ID, b.Title, b.Author, b.Description, b.ISBN, b.Genre, b.Language, b.PublishedYear, b.Version).Scan(&version, &created, &derived)
+		if err == sql.ErrNoRows {
+			return ErrVersionConflict
+		}
+		if err != nil {
+			return err
+		}
+		if created {
+			// Move the sequence past the chosen id so POST never hands it out.
+			if _, err := tx.ExecContext(ctx,
+				`SELECT setval(seq, GREATEST($1, pg_sequence_last_value(seq::regclass)))
+				 FROM pg_get_serial_sequence('books', 'id') AS seq`,
+				b.ID); err != nil {
+				return err
+			}
+		}
+		if b.Authors != nil {
+			if err := replaceAuthors(ctx, tx, b.ID, b.Authors); err != nil {
+				return err
+			}
+		}
+		if derived {
+			if err := fillByline(ctx, tx, b.ID); err != nil {
+				return err
+			}
+		}
+		action := RevisionUpdate
+		if created {
+			action = RevisionCreate
+		}
+		return recordRevision(ctx, tx, b.ID, action)
+	})
+	if err != nil {
+		return false, 0, translateErr(err)
+	}
+	return created, version, nil
+}
+
+func (r *sqlBookRepo) Delete(ctx context.Context, id, version int) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		return trashBook(ctx, tx, id, version)
+	})
 }
 
-func (r *sqlBookRepo) Update(ctx context.Context, b Book) error {
-	_, err := r.db.ExecContext(ctx,
-		`UPDATE books SET title=$1, author=$2, description=$3 WHERE id=$4`,
-		b.Title, b.Author, b.Description, b.ID)
+// ApplyBatch runs the writes in order. Atomic batches share one transaction
+// and stop at the first failure, leaving the outcomes after it empty; other
+// batches commit every write on its own.
+func (r *sqlBookRepo) ApplyBatch(ctx context.Context, writes []BatchWrite, atomic bool) ([]BatchOutcome, error) {
+	outcomes := make([]BatchOutcome, len(writes))
+	if !atomic {
+		for i, w := range writes {
+			err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+				outcomes[i] = applyBatchWrite(ctx, tx, w)
+				return outcomes[i].Err
+			})
+			if err != nil {
+				outcomes[i].Err = translateErr(err)
+			}
+		}
+		return outcomes, nil
+	}
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		for i, w := range writes {
+			outcomes[i] = applyBatchWrite(ctx, tx, w)
+			if outcomes[i].Err != nil {
+				outcomes[i].Err = translateErr(outcomes[i].Err)
+				return errBatchFailed
+			}
+		}
+		return nil
+	})
+	if err != nil && err != errBatchFailed {
+		return nil, err
+	}
+	return outcomes, nil
+}
+
+func applyBatchWrite(ctx context.Context, q queryer, w BatchWrite) BatchOutcome {
+	switch w.Action {
+	case BatchCreate:
+		id, err := insertBook(ctx, q, w.Book)
+		return BatchOutcome{ID: int(id), Version: 1, Err: err}
+	case BatchUpdate:
+		version, err := updateBook(ctx, q, w.Book)
+		return BatchOutcome{ID: w.Book.ID, Version: version, Err: err}
+	case BatchDelete:
+		return BatchOutcome{ID: w.Book.ID, Err: trashBook(ctx, q, w.Book.ID, w.Book.Version)}
+	}
+	return BatchOutcome{Err: fmt.Errorf("unknown batch action %q", w.Action)}
+}
+
+func (r *sqlBookRepo) ListTrash(ctx context.Context, limit, offset int) ([]Book, int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT b.id, b.title, b.author, b.description, COALESCE(b.isbn, ''), b.created_at,
+               COALESCE(b.genre, ''), COALESCE(b.language, ''), COALESCE(b.published_year, 0), b.version, b.deleted_at,
+               COUNT(*) OVER() AS total_count
+        FROM books b
+        WHERE b.deleted_at IS NOT NULL
+        ORDER BY b.deleted_at DESC, b.id
+        LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	books := []Book{}
+	var total int
+	for rows.Next() {
+		b := Book{}
+		if err := rows.Scan(&b.ID, &b.Title, &b.Author, &b.Description, &b.ISBN, &b.CreatedAt, &b.Genre, &b.Language, &b.PublishedYear,
+			&b.Version, &b.DeletedAt, &total); err != nil {
+			return nil, 0, err
+		}
+		books = append(books, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if err := loadDetails(ctx, r.db, books); err != nil {
+		return nil, 0, err
+	}
+	return books, total, nil
+}
+
+func (r *sqlBookRepo) InTrash(ctx context.Context, id int) (bool, error) {
+	var trashed bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM books WHERE id = $1 AND deleted_at IS NOT NULL)`, id).Scan(&trashed)
+	return trashed, err
+}
+
+func (r *sqlBookRepo) Restore(ctx context.Context, id int) (int, error) {
+	var version int
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx,
+			`UPDATE books SET deleted_at=NULL, version=version+1 WHERE id=$1 AND deleted_at IS NOT NULL RETURNING version`, id).Scan(&version)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return recordRevision(ctx, tx, id, RevisionRestore)
+	})
+	if err != nil {
+		return 0, translateErr(err)
+	}
+	return version, nil
+}
+
+// Purge relies on the foreign keys to cascade to the authors, copies and
+// closed holds of the purged books. Books with a copy that was ever lent, or
+// with open holds, stay in the trash, as the loans of their copies are kept
+// and open holds still wait for a copy.
+func (r *sqlBookRepo) Purge(ctx context.Context, before time.Time) (int, error) {
+	res, err := r.db.ExecContext(ctx, `
+        DELETE FROM books b
+        WHERE b.deleted_at < $1
+          AND NOT EXISTS (SELECT 1 FROM copies c JOIN loans l ON l.copy_id = c.id WHERE c.book_id = b.id)
+          AND NOT EXISTS (SELECT 1 FROM holds h WHERE h.book_id = b.id AND h.status IN ('waiting', 'ready'))`, before)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (r *sqlBookRepo) ListRevisions(ctx context.Context, bookID, limit, offset int) ([]Revision, int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT book_id, revision, action, snapshot, COALESCE(changed_by, ''), changed_at,
+               COUNT(*) OVER() AS total_count
+        FROM book_revisions
+        WHERE book_id = $1
+        ORDER BY revision DESC
+        LIMIT $2 OFFSET $3`, bookID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	revisions := []Revision{}
+	var total int
+	for rows.Next() {
+		var rev Revision
+		var snapshot []byte
+		if err := rows.Scan(&rev.BookID, &rev.Revision, &rev.Action, &snapshot, &rev.ChangedBy, &rev.ChangedAt, &total); err != nil {
+			return nil, 0, err
+		}
+		if err := json.Unmarshal(snapshot, &rev.Snapshot); err != nil {
+			return nil, 0, err
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, total, rows.Err()
+}
+
+func (r *sqlBookRepo) GetRevision(ctx context.Context, bookID, revision int) (Revision, error) {
+	var rev Revision
+	var snapshot []byte
+	err := r.db.QueryRowContext(ctx,
+		`SELECT book_id, revision, action, snapshot, COALESCE(changed_by, ''), changed_at
+         FROM book_revisions WHERE book_id = $1 AND revision = $2`, bookID, revision).
+		Scan(&rev.BookID, &rev.Revision, &rev.Action, &snapshot, &rev.ChangedBy, &rev.ChangedAt)
+	if err == sql.ErrNoRows {
+		return rev, ErrRevisionNotFound
+	}
+	if err != nil {
+		return rev, err
+	}
+	return rev, json.Unmarshal(snapshot, &rev.Snapshot)
+}
+
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertBook(ctx context.Context, q queryer, b Book) (int64, error) {
+	var id int64
+	err := q.QueryRowContext(ctx,
+		`INSERT INTO books (title, author, description, isbn, genre, language, published_year, byline_derived)
+         VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, 0), $2 = '') RETURNING id`,
+		b.Title, b.Author, b.Description, b.ISBN, b.Genre, b.Language, b.PublishedYear).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	if len(b.Authors) > 0 {
+		if err := replaceAuthors(ctx, q, int(id), b.Authors); err != nil {
+			return 0, err
+		}
+	}
+	if b.Author == "" {
+		if err := fillByline(ctx, q, int(id)); err != nil {
+			return 0, err
+		}
+	}
+	return id, recordRevision(ctx, q, int(id), RevisionCreate)
+}
+
+// updateBook keeps a derived byline derived when it is written back as it
+// was, as a patch leaving the author alone does.
+func updateBook(ctx context.Context, q queryer, b Book) (int, error) {
+	var version int
+	var derived bool
+	err := q.QueryRowContext(ctx,
+		`UPDATE books SET title=$1, author=$2, description=$3, isbn=NULLIF($4, ''),
+             genre=NULLIF($5, ''), language=NULLIF($6, ''), published_year=NULLIF($7, 0), version=version+1,
+             byline_derived = ($2 = '' OR (byline_derived AND author = $2))
+         WHERE id=$8 AND version=$9 AND deleted_at IS NULL RETURNING version, byline_derived`,
+		b.Title, b.Author, b.Description, b.ISBN, b.Genre, b.Language, b.PublishedYear, b.ID, b.Version).Scan(&version, &derived)
+	if err == sql.ErrNoRows {
+		return 0, ErrVersionConflict
+	}
+	if err != nil {
+		return 0, err
+	}
+	if b.Authors != nil {
+		if err := replaceAuthors(ctx, q, b.ID, b.Authors); err != nil {
+			return 0, err
+		}
+	}
+	if derived {
+		if err := fillByline(ctx, q, b.ID); err != nil {
+			return 0, err
+		}
+	}
+	return version, recordRevision(ctx, q, b.ID, RevisionUpdate)
+}
+
+// trashBook refuses a book that has copies out on loan or members queueing
+// for it. The check locks the book row, which checkout and the hold queue
+// lock too, so neither can slip in before the book is in the trash.
+func trashBook(ctx context.Context, q queryer, id, version int) error {
+	var circulating bool
+	err := q.QueryRowContext(ctx, `
+        SELECT EXISTS (SELECT 1 FROM copies c JOIN loans l ON l.copy_id = c.id WHERE c.book_id = b.id AND l.returned_at IS NULL)
+            OR EXISTS (SELECT 1 FROM holds h WHERE h.book_id = b.id AND h.status IN ('waiting', 'ready'))
+        FROM books b WHERE b.id = $1 AND b.deleted_at IS NULL FOR UPDATE`, id).Scan(&circulating)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if circulating {
+		return ErrBookInCirculation
+	}
+	res, err := q.ExecContext(ctx,
+		`UPDATE books SET deleted_at=now(), version=version+1 WHERE id=$1 AND version=$2 AND deleted_at IS NULL`, id, version)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrVersionConflict
+	}
+	return recordRevision(ctx, q, id, RevisionDelete)
+}
+
+func replaceAuthors(ctx context.Context, q queryer, bookID int, authors []BookAuthor) error {
+	if _, err := q.ExecContext(ctx, `DELETE FROM book_authors WHERE book_id = $1`, bookID); err != nil {
+		return err
+	}
+	for _, a := range authors {
+		_, err := q.ExecContext(ctx,
+			`INSERT INTO book_authors (book_id, author_id, role, position) VALUES ($1, $2, $3, $4)`,
+			bookID, a.AuthorID, a.Role, a.Position)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// derivedByline is the byline of book b made of the names of its linked
+// authors in credit order, or of all its contributors when none is credited
+// as author.
+const derivedByline = `COALESCE((
+        SELECT left(COALESCE(
+                   string_agg(a.name, ' & ' ORDER BY ba.position) FILTER (WHERE ba.role = 'author'),
+                   string_agg(a.name, ' & ' ORDER BY ba.position)), 255)
+        FROM book_authors ba JOIN authors a ON a.id = ba.author_id
+        WHERE ba.book_id = b.id), b.author)`
+
+// fillByline sets the byline of a book written without one from its linked
+// authors.
+func fillByline(ctx context.Context, q queryer, bookID int) error {
+	_, err := q.ExecContext(ctx, `UPDATE books b SET author = `+derivedByline+` WHERE b.id = $1`, bookID)
 	return err
 }
 
-func (r *sqlBookRepo) Delete(ctx context.Context, id int) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM books WHERE id=$1`, id)
+// RefreshBylines derives again the bylines made of the name of the author,
+// within tx renaming it. Bylines typed in are left alone.
+func RefreshBylines(ctx context.Context, tx *sql.Tx, authorID int) error {
+	_, err := tx.ExecContext(ctx, `
+        UPDATE books b SET author = `+derivedByline+`
+        WHERE b.byline_derived
+          AND EXISTS (SELECT 1 FROM book_authors ba WHERE ba.book_id = b.id AND ba.author_id = $1)`, authorID)
+	return err
+}
+
+// recordRevision snapshots the book as it now stands in the transaction,
+// numbering the revision after the version the change produced. The snapshot
+// has the shape of a CreateOrUpdateBookRequest.
+func recordRevision(ctx context.Context, q queryer, bookID int, action string) error {
+	_, err := q.ExecContext(ctx, `
+        INSERT INTO book_revisions (book_id, revision, action, snapshot, changed_by)
+        SELECT b.id, b.version, $2, jsonb_build_object(
+                   'title', b.title, 'author', b.author, 'description', b.description, 'isbn', COALESCE(b.isbn, ''),
+                   'genre', COALESCE(b.genre, ''), 'language', COALESCE(b.language, ''),
+                   'publishedYear', COALESCE(b.published_year, 0),
+                   'authors', COALESCE((SELECT jsonb_agg(jsonb_build_object('authorId', ba.author_id, 'role', ba.role) ORDER BY ba.position)
+                                        FROM book_authors ba WHERE ba.book_id = b.id), '[]'::jsonb)),
+               NULLIF($3, '')
+        FROM books b
+        WHERE b.id = $1`, bookID, action, ActorFromContext(ctx))
+	return err
+}
+
+// loadDetails fills in everything about the books that lives outside the books table.
+func loadDetails(ctx context.Context, q queryer, books []Book) error {
+	if err := loadAuthors(ctx, q, books); err != nil {
+		return err
+	}
+	return loadCopyCounts(ctx, q, books)
+}
+
+// loadAuthors fills in the Authors of every book with a single query.
+func loadAuthors(ctx context.Context, q queryer, books []Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(books))
+	index := make(map[int]int, len(books))
+	for i, b := range books {
+		ids[i] = int64(b.ID)
+		index[b.ID] = i
+		books[i].Authors = []BookAuthor{}
+	}
+	rows, err := q.QueryContext(ctx, `
+        SELECT ba.book_id, a.id, a.name, ba.role, ba.position
+        FROM book_authors ba
+        JOIN authors a ON a.id = ba.author_id
+        WHERE ba.book_id = ANY($1)
+        ORDER BY ba.book_id, ba.position`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var bookID int
+		a := BookAuthor{}
+		if err := rows.Scan(&bookID, &a.AuthorID, &a.Name, &a.Role, &a.Position); err != nil {
+			return err
+		}
+		i := index[bookID]
+		books[i].Authors = append(books[i].Authors, a)
+	}
+	return rows.Err()
+}
+
+// translateErr maps driver errors the service cares about to repository errors.
+func translateErr(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch {
+	case pqErr.Code == uniqueViolation && pqErr.Constraint == "books_isbn_key":
+		return ErrDuplicateISBN
+	case pqErr.Code == foreignKeyViolation && pqErr.Constraint == "book_authors_author_id_fkey":
+		return ErrAuthorNotFound
+	}
 	return err
 }
@@ -7,9 +7,11 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -25,7 +27,7 @@ func TestBookRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(BookRepositoryTestSuite))
 }
 
-func (m *BookRepositoryTestSuite) SetupSuite() {
+func (m *BookRepositoryTestSuite) SetupTest() {
 	m.ctrl = gomock.NewController(m.T())
 	m.db, m.sqlMock, _ = sqlmock.New()
 	m.bookRepository = book.NewBookRepository(m.db)
@@ -41,25 +43,52 @@ func (m *BookRepositoryTestSuite) TestCreate_ShouldInsertBookInDatabase() {
 		Author:      "JK Rolling",
 		Description: "HarryPotter and Chambers of Secret",
 	}
-	m.sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO books (title, author, description) VALUES ($1, $2, $3) RETURNING id")).
-		WithArgs("Harry Potter", "JK Rolling", "HarryPotter and Chambers of Secret").
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO books (title, author, description, isbn, genre, language, published_year, byline_derived) VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, 0), $2 = '') RETURNING id")).
+		WithArgs("Harry Potter", "JK Rolling", "HarryPotter and Chambers of Secret", "", "", "", 0).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).
 			AddRow(10))
+	m.sqlMock.ExpectExec("INSERT INTO book_revisions").WithArgs(10, "create", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
 	bId, err := m.bookRepository.Create(context.Background(), b)
 	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
 	m.Suite.Nil(err)
 	m.Suite.Equal(int64(10), bId)
 }
 
+func (m *BookRepositoryTestSuite) TestCreate_ShouldFillAMissingBylineFromTheLinkedAuthors() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("INSERT INTO books").WithArgs("Good Omens", "", "", "", "", "", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM book_authors WHERE book_id = $1")).WithArgs(10).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectExec("INSERT INTO book_authors").WithArgs(10, 7, "author", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE books b SET author = COALESCE(( SELECT left(COALESCE( string_agg(a.name, ' & ' ORDER BY ba.position) FILTER (WHERE ba.role = 'author'),")).
+		WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec("INSERT INTO book_revisions").WithArgs(10, "create", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	_, err := m.bookRepository.Create(context.Background(), book.Book{
+		Title:   "Good Omens",
+		Authors: []book.BookAuthor{{AuthorID: 7, Role: "author", Position: 1}},
+	})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+}
+
 func (m *BookRepositoryTestSuite) TestCreate_ShouldThrowErrorWhenInsertQueryFails() {
 	b := book.Book{
 		Title:       "Harry Potter",
 		Author:      "JK Rolling",
 		Description: "HarryPotter and Chambers of Secret",
 	}
-	m.sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO books (title, author, description) VALUES ($1, $2, $3) RETURNING id")).
-		WithArgs("Harry Potter", "JK Rolling", "HarryPotter and Chambers of Secret").
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO books (title, author, description, isbn, genre, language, published_year, byline_derived) VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, 0), $2 = '') RETURNING id")).
+		WithArgs("Harry Potter", "JK Rolling", "HarryPotter and Chambers of Secret", "", "", "", 0).
 		WillReturnError(errors.New("unique constraint violation"))
+	m.sqlMock.ExpectRollback()
 	bId, err := m.bookRepository.Create(context.Background(), b)
 	m.Suite.Equal(int64(0), bId)
 	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
@@ -67,9 +96,16 @@ func (m *BookRepositoryTestSuite) TestCreate_ShouldThrowErrorWhenInsertQueryFail
 }
 
 func (m *BookRepositoryTestSuite) TestGetById_ShouldShouldReturnBookWithTheProvidedId() {
-	rows := sqlmock.NewRows([]string{"id", "title", "author", "description"}).
-		AddRow(12, "Harry Potter", "JK Rolling", "HarryPotter and Chambers of Secret")
-	m.sqlMock.ExpectQuery("SELECT id, title, author, description FROM books").WillReturnRows(rows)
+	nextDue := time.Date(2026, 11, 7, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "title", "author", "description", "isbn", "created_at", "genre", "language", "published_year", "version"}).
+		AddRow(12, "Harry Potter", "JK Rolling", "HarryPotter and Chambers of Secret", "", created, "fantasy", "en", 1998, 3)
+	m.sqlMock.ExpectQuery("SELECT id, title, author, description, COALESCE\\(isbn, ''\\), created_at, COALESCE\\(genre, ''\\), COALESCE\\(language, ''\\), COALESCE\\(published_year, 0\\), version FROM books").WillReturnRows(rows)
+	m.sqlMock.ExpectQuery("FROM book_authors").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "id", "name", "role", "position"}).
+			AddRow(12, 3, "J.K. Rowling", "author", 1))
+	m.sqlMock.ExpectQuery("FROM copies").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "total", "available", "next_due_on"}).AddRow(12, 3, 1, nextDue))
 	b, err := m.bookRepository.GetByID(context.Background(), 12)
 	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
 	m.Suite.Nil(err)
@@ -78,11 +114,20 @@ func (m *BookRepositoryTestSuite) TestGetById_ShouldShouldReturnBookWithTheProvi
 		Title:       "Harry Potter",
 		Author:      "JK Rolling",
 		Description: "HarryPotter and Chambers of Secret",
+		CreatedAt:   created,
+		Genre:         "fantasy",
+		Language:      "en",
+		PublishedYear: 1998,
+		Version:       3,
+		Authors:     []book.BookAuthor{{AuthorID: 3, Name: "J.K. Rowling", Role: "author", Position: 1}},
+		TotalCopies:     3,
+		AvailableCopies: 1,
+		NextDueOn:       &nextDue,
 	}, b)
 }
 
 func (m *BookRepositoryTestSuite) TestGetById_ShouldReturnNotFoundErrorIfNoBookPresentForGivenId() {
-	m.sqlMock.ExpectQuery("SELECT id, title, author, description FROM books").WillReturnError(sql.ErrNoRows)
+	m.sqlMock.ExpectQuery("SELECT id, title, author, description, COALESCE\\(isbn, ''\\), created_at, COALESCE\\(genre, ''\\), COALESCE\\(language, ''\\), COALESCE\\(published_year, 0\\), version FROM books").WillReturnError(sql.ErrNoRows)
 	b, err := m.bookRepository.GetByID(context.Background(), 12)
 	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
 	m.Suite.Empty(b)
@@ -90,11 +135,17 @@ func (m *BookRepositoryTestSuite) TestGetById_ShouldReturnNotFoundErrorIfNoBookP
 }
 
 func (m *BookRepositoryTestSuite) TestList_ShouldReturAllBooks() {
-	rows := sqlmock.NewRows([]string{"id", "title", "author", "description","total_count"}).
-		AddRow(12, "Harry Potter", "JK Rolling", "HarryPotter and Chambers of Secret",2).
-		AddRow(13, "Harry Potter", "JK Rolling", "HarryPotter and Goblet of Fire",2)
-	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, author, description, COUNT(*) OVER() AS total_count FROM books ORDER BY id LIMIT $1 OFFSET $2")).WithArgs(5,1).WillReturnRows(rows)
-	b,totalCount, err := m.bookRepository.List(context.Background(),5,1)
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "title", "author", "description", "isbn", "created_at", "genre", "language", "published_year", "total_count"}).
+		AddRow(12, "Harry Potter", "JK Rolling", "HarryPotter and Chambers of Secret", "", created, "", "", 0, 2).
+		AddRow(13, "Harry Potter", "JK Rolling", "HarryPotter and Goblet of Fire", "", created, "", "", 0, 2)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT b.id, b.title, b.author, b.description, COALESCE(b.isbn, ''), b.created_at, COALESCE(b.genre, ''), COALESCE(b.language, ''), COALESCE(b.published_year, 0), COUNT(*) OVER() AS total_count FROM books b WHERE b.deleted_at IS NULL ORDER BY b.id LIMIT $1 OFFSET $2")).WithArgs(5,1).WillReturnRows(rows)
+	m.sqlMock.ExpectQuery("FROM book_authors").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "id", "name", "role", "position"}).
+			AddRow(13, 3, "J.K. Rowling", "author", 1))
+	m.sqlMock.ExpectQuery("FROM copies").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "total", "available", "next_due_on"}))
+	b,totalCount, err := m.bookRepository.List(context.Background(), book.BookFilter{}, nil, 5, 1)
 		m.Suite.Nil(err)
 	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
 	m.Suite.Len(b, 2)
@@ -104,40 +155,408 @@ func (m *BookRepositoryTestSuite) TestList_ShouldReturAllBooks() {
 		Title:       "Harry Potter",
 		Author:      "JK Rolling",
 		Description: "HarryPotter and Chambers of Secret",
+		CreatedAt:   created,
+		Authors:     []book.BookAuthor{},
 	}, {
 		ID:          13,
 		Title:       "Harry Potter",
 		Author:      "JK Rolling",
 		Description: "HarryPotter and Goblet of Fire",
+		CreatedAt:   created,
+		Authors:     []book.BookAuthor{{AuthorID: 3, Name: "J.K. Rowling", Role: "author", Position: 1}},
 	}}, b)
 }
 
 func (m *BookRepositoryTestSuite) TestList_ShouldReturnErrorWhenQueryFails() {
-	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, author, description, COUNT(*) OVER() AS total_count FROM books ORDER BY id LIMIT $1 OFFSET $2")).WithArgs(1,2).WillReturnError(errors.New("unable to connect"))
-	b,totalCount ,err := m.bookRepository.List(context.Background(),1,2)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books b WHERE b.deleted_at IS NULL ORDER BY b.id LIMIT $1 OFFSET $2")).WithArgs(1,2).WillReturnError(errors.New("unable to connect"))
+	b,totalCount ,err := m.bookRepository.List(context.Background(), book.BookFilter{}, nil, 1, 2)
 	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
 	m.Suite.Equal(0,totalCount)
 	m.Suite.EqualError(err, "unable to connect")
 	m.Suite.Empty(b)
 }
 
+func (m *BookRepositoryTestSuite) TestListAfter_ShouldSeekPastTheGivenSortKeys() {
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books b WHERE b.deleted_at IS NULL AND b.title ILIKE '%' || $1 || '%' AND ((lower(b.title) > lower($2::text)) OR (lower(b.title) = lower($2::text) AND b.created_at < $3::timestamptz) OR (lower(b.title) = lower($2::text) AND b.created_at = $3::timestamptz AND b.id > $4::int)) ORDER BY lower(b.title), b.created_at DESC, b.id LIMIT $5")).
+		WithArgs("potter", "Harry Potter", "2026-10-01T09:30:00Z", "12", 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "description", "isbn", "created_at", "genre", "language", "published_year"}).
+			AddRow(13, "Harry Potter", "JK Rolling", "HarryPotter and Goblet of Fire", "", created, "", "", 0))
+	m.sqlMock.ExpectQuery("FROM book_authors").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "id", "name", "role", "position"}))
+	m.sqlMock.ExpectQuery("FROM copies").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "total", "available", "next_due_on"}))
+	sort := []book.SortField{{Field: "title"}, {Field: "createdAt", Desc: true}}
+	b, err := m.bookRepository.ListAfter(context.Background(), book.BookFilter{Title: "potter"}, sort, []string{"Harry Potter", "2026-10-01T09:30:00Z", "12"}, 3)
+	m.Suite.Nil(err)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Len(b, 1)
+	m.Suite.Equal(13, b[0].ID)
+}
+
+func (m *BookRepositoryTestSuite) TestListAfter_ShouldStartFromTheFirstBookWithoutKeys() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books b WHERE b.deleted_at IS NULL ORDER BY b.id LIMIT $1")).WithArgs(11).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "description", "isbn", "created_at", "genre", "language", "published_year"}))
+	b, err := m.bookRepository.ListAfter(context.Background(), book.BookFilter{}, nil, nil, 11)
+	m.Suite.Nil(err)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Empty(b)
+}
+
+func (m *BookRepositoryTestSuite) TestListAfter_ShouldRejectKeysNotMatchingTheSort() {
+	_, err := m.bookRepository.ListAfter(context.Background(), book.BookFilter{}, []book.SortField{{Field: "title"}}, []string{"12"}, 11)
+	m.Suite.True(errors.Is(err, book.ErrInvalidSort))
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+}
+
+func (m *BookRepositoryTestSuite) TestExport_ShouldFetchFilteredBooksFromACursorInASnapshot() {
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("DECLARE book_export NO SCROLL CURSOR FOR SELECT b.id, b.title, b.author, b.description, COALESCE(b.isbn, ''), b.created_at, COALESCE(b.genre, ''), COALESCE(b.language, ''), COALESCE(b.published_year, 0), b.version FROM books b WHERE b.deleted_at IS NULL AND b.genre = $1 ORDER BY lower(b.title), b.id")).
+		WithArgs("fantasy").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FETCH 500 FROM book_export")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "description", "isbn", "created_at", "genre", "language", "published_year", "version"}).
+			AddRow(12, "Dune", "Frank Herbert", "", "9780441013593", created, "fantasy", "en", 1965, 2).
+			AddRow(13, "Emma", "Jane Austen", "", "", created, "fantasy", "", 0, 1))
+	m.sqlMock.ExpectQuery("FROM book_authors").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "id", "name", "role", "position"}))
+	m.sqlMock.ExpectQuery("FROM copies").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "total", "available", "next_due_on"}))
+	m.sqlMock.ExpectRollback()
+
+	var batches [][]book.Book
+	err := m.bookRepository.Export(context.Background(), book.BookFilter{Genre: "fantasy"}, []book.SortField{{Field: "title"}}, func(books []book.Book) error {
+		batches = append(batches, books)
+		return nil
+	})
+	m.Suite.Nil(err)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Len(batches, 1)
+	m.Suite.Equal([]int{12, 13}, []int{batches[0][0].ID, batches[0][1].ID})
+	m.Suite.Equal(1965, batches[0][0].PublishedYear)
+}
+
+func (m *BookRepositoryTestSuite) TestCount_ShouldCountFilteredBooks() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM books b WHERE b.deleted_at IS NULL AND EXISTS (SELECT 1 FROM book_authors ba WHERE ba.book_id = b.id AND ba.author_id = $1)")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+	total, err := m.bookRepository.Count(context.Background(), book.BookFilter{AuthorID: 3})
+	m.Suite.Nil(err)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(42, total)
+}
+
+func (m *BookRepositoryTestSuite) TestCount_ShouldMatchAnyOfTheIDs() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM books b WHERE b.deleted_at IS NULL AND b.id = ANY($1)")).
+		WithArgs("{13,12}").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	total, err := m.bookRepository.Count(context.Background(), book.BookFilter{IDs: []int{13, 12}})
+	m.Suite.Nil(err)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(2, total)
+}
+
+func (m *BookRepositoryTestSuite) TestSuggest_ShouldReturnTrigramMatchesBestFirst() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("WHERE ($1 <% b.title OR $1 <% b.author) AND b.deleted_at IS NULL ) s ORDER BY score DESC, title, id LIMIT $2")).
+		WithArgs("hary poter", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "score"}).
+			AddRow(12, "Harry Potter", "JK Rolling", 0.82).
+			AddRow(13, "Harry Potter and the Goblet of Fire", "JK Rolling", 0.82))
+	s, err := m.bookRepository.Suggest(context.Background(), "hary poter", 5)
+	m.Suite.Nil(err)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal([]book.Suggestion{
+		{ID: 12, Title: "Harry Potter", Author: "JK Rolling", Score: 0.82},
+		{ID: 13, Title: "Harry Potter and the Goblet of Fire", Author: "JK Rolling", Score: 0.82},
+	}, s)
+}
+
+func (m *BookRepositoryTestSuite) TestSearch_ShouldNumberSearchArgumentsAfterTheFilter() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books b, to_tsquery('english', $3) q WHERE b.deleted_at IS NULL AND b.genre = $1 AND b.published_year BETWEEN $2 AND $2 + 9 AND b.search_vector @@ q ORDER BY rank DESC, b.id LIMIT $4 OFFSET $5")).
+		WithArgs("fantasy", 1990, "potter", 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "description", "isbn", "created_at", "genre", "language", "published_year", "rank", "title_hl", "description_hl", "total_count"}))
+	_, total, err := m.bookRepository.Search(context.Background(), "potter", book.BookFilter{Genre: "fantasy", Decade: 1990}, 10, 0)
+	m.Suite.Nil(err)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(0, total)
+}
+
+func (m *BookRepositoryTestSuite) TestFacets_ShouldGroupCountsByFacet() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT b.id, b.genre, b.language, b.published_year FROM books b WHERE b.deleted_at IS NULL AND b.language = $1 AND b.search_vector @@ to_tsquery('english', $2) )")).
+		WithArgs("en", "potter", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"facet", "value", "label", "n"}).
+			AddRow("author", "3", "J.K. Rowling", 7).
+			AddRow("decade", "1990", "1990s", 4).
+			AddRow("decade", "2000", "2000s", 3).
+			AddRow("genre", "fantasy", "", 7).
+			AddRow("language", "en", "", 7))
+	facets, err := m.bookRepository.Facets(context.Background(), book.BookFilter{Language: "en"}, "potter")
+	m.Suite.Nil(err)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.Facets{
+		Authors:   []book.FacetValue{{Value: "3", Label: "J.K. Rowling", Count: 7}},
+		Genres:    []book.FacetValue{{Value: "fantasy", Count: 7}},
+		Languages: []book.FacetValue{{Value: "en", Count: 7}},
+		Decades:   []book.FacetValue{{Value: "1990", Label: "1990s", Count: 4}, {Value: "2000", Label: "2000s", Count: 3}},
+	}, facets)
+}
+
+func (m *BookRepositoryTestSuite) TestFacets_ShouldReturnEmptyFacetsWhenNothingMatches() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books b WHERE b.deleted_at IS NULL )")).WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"facet", "value", "label", "n"}))
+	facets, err := m.bookRepository.Facets(context.Background(), book.BookFilter{}, "")
+	m.Suite.Nil(err)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.Facets{Authors: []book.FacetValue{}, Genres: []book.FacetValue{}, Languages: []book.FacetValue{}, Decades: []book.FacetValue{}}, facets)
+}
+
 func (m *BookRepositoryTestSuite) TestUpdate_ShouldUpdateTheBookRecord() {
-	m.sqlMock.ExpectExec("UPDATE books").
-		WithArgs("Harry Potter", "JK Rolling", "HarryPotter and Goblet of Fire", 13).
-		WillReturnResult(sqlmock.NewResult(13, 1))
-	err := m.bookRepository.Update(context.Background(), book.Book{
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("WHERE id=$8 AND version=$9 AND deleted_at IS NULL RETURNING version, byline_derived")).
+		WithArgs("Harry Potter", "JK Rolling", "HarryPotter and Goblet of Fire", "", "", "", 0, 13, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "byline_derived"}).AddRow(3, false))
+	m.sqlMock.ExpectExec("INSERT INTO book_revisions").WithArgs(13, "update", "alice").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	version, err := m.bookRepository.Update(book.WithActor(context.Background(), "alice"), book.Book{
 		ID:          13,
 		Title:       "Harry Potter",
 		Author:      "JK Rolling",
 		Description: "HarryPotter and Goblet of Fire",
+		Version:     2,
 	})
 	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
 	m.Suite.Nil(err)
+	m.Suite.Equal(3, version)
+}
+
+func (m *BookRepositoryTestSuite) TestUpdate_ShouldDeriveAgainABylineThatWasDerived() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("byline_derived = ($2 = '' OR (byline_derived AND author = $2))")).
+		WithArgs("Good Omens", "Terry Pratchett & Neil Gaiman", "", "", "", "", 0, 13, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "byline_derived"}).AddRow(3, true))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE books b SET author = COALESCE((")).WithArgs(13).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec("INSERT INTO book_revisions").WithArgs(13, "update", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	_, err := m.bookRepository.Update(context.Background(), book.Book{ID: 13, Title: "Good Omens", Author: "Terry Pratchett & Neil Gaiman", Version: 2})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+}
+
+func (m *BookRepositoryTestSuite) TestUpdate_ShouldReturnVersionConflictWhenVersionMoved() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("UPDATE books").WillReturnError(sql.ErrNoRows)
+	m.sqlMock.ExpectRollback()
+	_, err := m.bookRepository.Update(context.Background(), book.Book{ID: 13, Title: "Harry Potter", Version: 2})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrVersionConflict, err)
+}
+
+func (m *BookRepositoryTestSuite) TestUpsert_ShouldInsertAtTheGivenIdAndAdvanceTheSequence() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("ON CONFLICT (id) DO UPDATE")).
+		WithArgs(100, "Dune", "Frank Herbert", "", "", "", "", 0, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "inserted", "byline_derived"}).AddRow(1, true, false))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("SELECT setval(seq, GREATEST($1, pg_sequence_last_value(seq::regclass))) FROM pg_get_serial_sequence('books', 'id') AS seq")).WithArgs(100).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM book_authors WHERE book_id = $1")).WithArgs(100).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectExec("INSERT INTO book_revisions").WithArgs(100, "create", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	created, version, err := m.bookRepository.Upsert(context.Background(), book.Book{
+		ID:      100,
+		Title:   "Dune",
+		Author:  "Frank Herbert",
+		Authors: []book.BookAuthor{},
+	})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.True(created)
+	m.Suite.Equal(1, version)
+}
+
+func (m *BookRepositoryTestSuite) TestUpsert_ShouldReplaceTheBookAtItsVersion() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("WHERE b.version = $9")).
+		WithArgs(13, "Dune", "Frank Herbert", "", "", "", "", 0, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "inserted", "byline_derived"}).AddRow(3, false, false))
+	m.sqlMock.ExpectExec("INSERT INTO book_revisions").WithArgs(13, "update", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	created, version, err := m.bookRepository.Upsert(context.Background(), book.Book{ID: 13, Title: "Dune", Author: "Frank Herbert", Version: 2})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.False(created)
+	m.Suite.Equal(3, version)
+}
+
+func (m *BookRepositoryTestSuite) TestUpsert_ShouldReturnVersionConflictWhenTheBookIsAtAnotherVersion() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("INSERT INTO books").WillReturnError(sql.ErrNoRows)
+	m.sqlMock.ExpectRollback()
+	_, _, err := m.bookRepository.Upsert(context.Background(), book.Book{ID: 13, Title: "Dune"})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrVersionConflict, err)
+}
+
+func (m *BookRepositoryTestSuite) TestListTrash_ShouldListTrashedBooksMostRecentFirst() {
+	deletedAt := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "title", "author", "description", "isbn", "created_at", "genre", "language", "published_year", "version", "deleted_at", "total_count"}).
+		AddRow(12, "Dune", "Frank Herbert", "", "", time.Now(), "", "", 0, 4, deletedAt, 1)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("WHERE b.deleted_at IS NOT NULL ORDER BY b.deleted_at DESC, b.id LIMIT $1 OFFSET $2")).
+		WithArgs(10, 0).WillReturnRows(rows)
+	m.sqlMock.ExpectQuery("FROM book_authors").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "id", "name", "role", "position"}))
+	m.sqlMock.ExpectQuery("FROM copies").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "total", "available", "next_due_on"}))
+	books, total, err := m.bookRepository.ListTrash(context.Background(), 10, 0)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(1, total)
+	m.Suite.Equal(deletedAt, *books[0].DeletedAt)
+}
+
+func (m *BookRepositoryTestSuite) TestRestore_ShouldReturnNotFoundUnlessTheBookIsTrashed() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("UPDATE books SET deleted_at=NULL, version=version+1 WHERE id=$1 AND deleted_at IS NOT NULL")).
+		WithArgs(12).WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	m.sqlMock.ExpectRollback()
+	_, err := m.bookRepository.Restore(context.Background(), 12)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrNotFound, err)
+}
+
+func (m *BookRepositoryTestSuite) TestRestore_ShouldReturnDuplicateISBNWhenTheIsbnWasTakenAgain() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("UPDATE books SET deleted_at=NULL").WithArgs(12).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "books_isbn_key"})
+	m.sqlMock.ExpectRollback()
+	_, err := m.bookRepository.Restore(context.Background(), 12)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrDuplicateISBN, err)
+}
+
+func (m *BookRepositoryTestSuite) TestPurge_ShouldDeleteBooksTrashedBeforeTheCutoff() {
+	before := time.Date(2026, 9, 17, 0, 0, 0, 0, time.UTC)
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("WHERE b.deleted_at < $1 AND NOT EXISTS (SELECT 1 FROM copies c JOIN loans l ON l.copy_id = c.id WHERE c.book_id = b.id) AND NOT EXISTS (SELECT 1 FROM holds h WHERE h.book_id = b.id AND h.status IN ('waiting', 'ready'))")).WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	n, err := m.bookRepository.Purge(context.Background(), before)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(2, n)
+}
+
+func (m *BookRepositoryTestSuite) TestDelete_ShouldOnlyDeleteTheGivenVersion() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books b WHERE b.id = $1 AND b.deleted_at IS NULL FOR UPDATE")).WithArgs(13).
+		WillReturnRows(sqlmock.NewRows([]string{"circulating"}).AddRow(false))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE books SET deleted_at=now(), version=version+1 WHERE id=$1 AND version=$2 AND deleted_at IS NULL")).WithArgs(13, 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectRollback()
+	err := m.bookRepository.Delete(context.Background(), 13, 2)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrVersionConflict, err)
+}
+
+func (m *BookRepositoryTestSuite) TestDelete_ShouldRefuseABookWithOpenLoansOrHolds() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books b WHERE b.id = $1 AND b.deleted_at IS NULL FOR UPDATE")).WithArgs(13).
+		WillReturnRows(sqlmock.NewRows([]string{"circulating"}).AddRow(true))
+	m.sqlMock.ExpectRollback()
+	err := m.bookRepository.Delete(context.Background(), 13, 2)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrBookInCirculation, err)
+}
+
+func (m *BookRepositoryTestSuite) TestDelete_ShouldRecordARevisionInTheSameTransaction() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books b WHERE b.id = $1 AND b.deleted_at IS NULL FOR UPDATE")).WithArgs(13).
+		WillReturnRows(sqlmock.NewRows([]string{"circulating"}).AddRow(false))
+	m.sqlMock.ExpectExec("UPDATE books SET deleted_at=now()").WithArgs(13, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec("INSERT INTO book_revisions").WithArgs(13, "delete", "").
+		WillReturnError(errors.New("unable to connect"))
+	m.sqlMock.ExpectRollback()
+	err := m.bookRepository.Delete(context.Background(), 13, 2)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.EqualError(err, "unable to connect")
+}
+
+func (m *BookRepositoryTestSuite) TestListRevisions_ShouldDecodeTheSnapshotsNewestFirst() {
+	changedAt := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"book_id", "revision", "action", "snapshot", "changed_by", "changed_at", "total_count"}).
+		AddRow(12, 2, "update", []byte(`{"title":"Dune Messiah","author":"Frank Herbert","authors":[{"authorId":3,"role":"author"}]}`), "alice", changedAt, 2).
+		AddRow(12, 1, "create", []byte(`{"title":"Dune","author":"Frank Herbert","authors":[]}`), "", changedAt, 2)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM book_revisions WHERE book_id = $1 ORDER BY revision DESC LIMIT $2 OFFSET $3")).
+		WithArgs(12, 10, 0).WillReturnRows(rows)
+	revisions, total, err := m.bookRepository.ListRevisions(context.Background(), 12, 10, 0)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(2, total)
+	m.Suite.Equal("Dune Messiah", revisions[0].Snapshot.Title)
+	m.Suite.Equal([]book.BookAuthorRequest{{AuthorID: 3, Role: "author"}}, revisions[0].Snapshot.Authors)
+	m.Suite.Equal("alice", revisions[0].ChangedBy)
+}
+
+func (m *BookRepositoryTestSuite) TestGetRevision_ShouldReturnRevisionNotFound() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM book_revisions WHERE book_id = $1 AND revision = $2")).
+		WithArgs(12, 9).WillReturnError(sql.ErrNoRows)
+	_, err := m.bookRepository.GetRevision(context.Background(), 12, 9)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrRevisionNotFound, err)
+}
+
+func (m *BookRepositoryTestSuite) TestApplyBatch_ShouldStopAnAtomicBatchAtTheFirstFailure() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("INSERT INTO books").WithArgs("Dune", "Frank Herbert", "", "", "", "", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	m.sqlMock.ExpectExec("INSERT INTO book_revisions").WithArgs(10, "create", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books b WHERE b.id = $1 AND b.deleted_at IS NULL FOR UPDATE")).WithArgs(13).
+		WillReturnRows(sqlmock.NewRows([]string{"circulating"}).AddRow(false))
+	m.sqlMock.ExpectExec("UPDATE books SET deleted_at=now()").WithArgs(13, 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectRollback()
+	outcomes, err := m.bookRepository.ApplyBatch(context.Background(), []book.BatchWrite{
+		{Action: book.BatchCreate, Book: book.Book{Title: "Dune", Author: "Frank Herbert"}},
+		{Action: book.BatchDelete, Book: book.Book{ID: 13, Version: 2}},
+		{Action: book.BatchDelete, Book: book.Book{ID: 14, Version: 1}},
+	}, true)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal([]book.BatchOutcome{{ID: 10, Version: 1}, {ID: 13, Err: book.ErrVersionConflict}, {}}, outcomes)
+}
+
+func (m *BookRepositoryTestSuite) TestApplyBatch_ShouldCommitEachWriteOnItsOwnInBestEffortMode() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("INSERT INTO books").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "books_isbn_key"})
+	m.sqlMock.ExpectRollback()
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books b WHERE b.id = $1 AND b.deleted_at IS NULL FOR UPDATE")).WithArgs(13).
+		WillReturnRows(sqlmock.NewRows([]string{"circulating"}).AddRow(false))
+	m.sqlMock.ExpectExec("UPDATE books SET deleted_at=now()").WithArgs(13, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec("INSERT INTO book_revisions").WithArgs(13, "delete", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	outcomes, err := m.bookRepository.ApplyBatch(context.Background(), []book.BatchWrite{
+		{Action: book.BatchCreate, Book: book.Book{Title: "Dune", ISBN: "9780441013593"}},
+		{Action: book.BatchDelete, Book: book.Book{ID: 13, Version: 2}},
+	}, false)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal([]book.BatchOutcome{{Version: 1, Err: book.ErrDuplicateISBN}, {ID: 13}}, outcomes)
 }
 
 func (m *BookRepositoryTestSuite) TestUpdate_ShouldReturnErrorWhenUpdateFails() {
-	m.sqlMock.ExpectExec("UPDATE books").WillReturnError(errors.New("unable to connect"))
-	err := m.bookRepository.Update(context.Background(), book.Book{
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("UPDATE books").WillReturnError(errors.New("unable to connect"))
+	m.sqlMock.ExpectRollback()
+	_, err := m.bookRepository.Update(context.Background(), book.Book{
 		ID:          13,
 		Title:       "Harry Potter",
 		Author:      "JK Rolling",
@@ -146,3 +565,145 @@ func (m *BookRepositoryTestSuite) TestUpdate_ShouldReturnErrorWhenUpdateFails()
 	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
 	m.Suite.EqualError(err, "unable to connect")
 }
+
+func (m *BookRepositoryTestSuite) TestCreate_ShouldReturnDuplicateISBNErrorOnUniqueViolation() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("INSERT INTO books").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "books_isbn_key"})
+	m.sqlMock.ExpectRollback()
+	_, err := m.bookRepository.Create(context.Background(), book.Book{
+		Title:  "Harry Potter",
+		Author: "JK Rolling",
+		ISBN:   "9780747532699",
+	})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrDuplicateISBN, err)
+}
+
+func (m *BookRepositoryTestSuite) TestGetByISBN_ShouldReturnBookWithTheProvidedISBN() {
+	rows := sqlmock.NewRows([]string{"id", "title", "author", "description", "isbn", "created_at", "genre", "language", "published_year", "version"}).
+		AddRow(12, "Harry Potter", "JK Rolling", "HarryPotter and Chambers of Secret", "9780747532699", time.Now(), "", "", 0, 1)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books WHERE isbn = $1 AND deleted_at IS NULL")).WithArgs("9780747532699").WillReturnRows(rows)
+	m.sqlMock.ExpectQuery("FROM book_authors").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "id", "name", "role", "position"}))
+	m.sqlMock.ExpectQuery("FROM copies").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "total", "available", "next_due_on"}))
+	b, err := m.bookRepository.GetByISBN(context.Background(), "9780747532699")
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal("9780747532699", b.ISBN)
+}
+
+func (m *BookRepositoryTestSuite) TestGetByISBN_ShouldReturnNotFoundErrorIfNoBookPresentForGivenISBN() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books WHERE isbn = $1 AND deleted_at IS NULL")).WillReturnError(sql.ErrNoRows)
+	_, err := m.bookRepository.GetByISBN(context.Background(), "9780747532699")
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrNotFound, err)
+}
+
+func (m *BookRepositoryTestSuite) TestCreate_ShouldLinkAuthorsInTheSameTransaction() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("INSERT INTO books").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM book_authors WHERE book_id = $1")).WithArgs(10).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectExec("INSERT INTO book_authors").WithArgs(10, 3, "author", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec("INSERT INTO book_authors").WithArgs(10, 4, "illustrator", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec("INSERT INTO book_revisions").WithArgs(10, "create", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	bId, err := m.bookRepository.Create(context.Background(), book.Book{
+		Title:  "Harry Potter",
+		Author: "JK Rolling",
+		Authors: []book.BookAuthor{
+			{AuthorID: 3, Role: "author", Position: 1},
+			{AuthorID: 4, Role: "illustrator", Position: 2},
+		},
+	})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(10), bId)
+}
+
+func (m *BookRepositoryTestSuite) TestCreate_ShouldReturnAuthorNotFoundWhenLinkedAuthorDoesNotExist() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("INSERT INTO books").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	m.sqlMock.ExpectExec("DELETE FROM book_authors").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectExec("INSERT INTO book_authors").
+		WillReturnError(&pq.Error{Code: "23503", Constraint: "book_authors_author_id_fkey"})
+	m.sqlMock.ExpectRollback()
+	_, err := m.bookRepository.Create(context.Background(), book.Book{
+		Title:   "Harry Potter",
+		Author:  "JK Rolling",
+		Authors: []book.BookAuthor{{AuthorID: 99, Role: "author", Position: 1}},
+	})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(book.ErrAuthorNotFound, err)
+}
+
+func (m *BookRepositoryTestSuite) TestSearch_ShouldReturnRankedMatchesWithHighlights() {
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books b, to_tsquery('english', $1) q WHERE b.deleted_at IS NULL AND b.search_vector @@ q")).WithArgs("potter & pott:*", 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "description", "isbn", "created_at", "genre", "language", "published_year", "rank", "title_hl", "description_hl", "total_count"}).
+			AddRow(12, "Harry Potter", "JK Rolling", "harry potter and his friends", "", created, "", "", 0, 0.6, "Harry <mark>Potter</mark>", "harry <mark>potter</mark> and his friends", 1))
+	m.sqlMock.ExpectQuery("FROM book_authors").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "id", "name", "role", "position"}))
+	m.sqlMock.ExpectQuery("FROM copies").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "total", "available", "next_due_on"}).AddRow(12, 2, 1, nil))
+	results, total, err := m.bookRepository.Search(context.Background(), "potter & pott:*", book.BookFilter{}, 10, 0)
+	m.Suite.Nil(err)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(1, total)
+	m.Suite.Equal([]book.SearchResult{{
+		Book: book.Book{
+			ID:              12,
+			Title:           "Harry Potter",
+			Author:          "JK Rolling",
+			Description:     "harry potter and his friends",
+			CreatedAt:       created,
+			Authors:         []book.BookAuthor{},
+			TotalCopies:     2,
+			AvailableCopies: 1,
+		},
+		Rank:                 0.6,
+		TitleHighlight:       "Harry <mark>Potter</mark>",
+		DescriptionHighlight: "harry <mark>potter</mark> and his friends",
+	}}, results)
+}
+
+func (m *BookRepositoryTestSuite) TestList_ShouldFilterAndSortThroughWhitelistedClauses() {
+	available := true
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books b WHERE b.deleted_at IS NULL AND b.title ILIKE '%' || $1 || '%' AND ")).
+		WithArgs("potter", "rowling", 7, true, after, 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "description", "isbn", "created_at", "genre", "language", "published_year", "total_count"}))
+	_, _, err := m.bookRepository.List(context.Background(), book.BookFilter{
+		Title:        "potter",
+		Author:       "rowling",
+		AuthorID:     7,
+		Available:    &available,
+		CreatedAfter: &after,
+	}, []book.SortField{{Field: "title"}, {Field: "createdAt", Desc: true}}, 10, 0)
+	m.Suite.Nil(err)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+}
+
+func (m *BookRepositoryTestSuite) TestList_ShouldOrderBySortFieldsAndBreakTiesById() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM books b WHERE b.deleted_at IS NULL ORDER BY lower(b.title), b.created_at DESC, b.id LIMIT $1 OFFSET $2")).
+		WithArgs(10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "description", "isbn", "created_at", "genre", "language", "published_year", "total_count"}))
+	_, _, err := m.bookRepository.List(context.Background(), book.BookFilter{},
+		[]book.SortField{{Field: "title"}, {Field: "createdAt", Desc: true}}, 10, 0)
+	m.Suite.Nil(err)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+}
+
+func (m *BookRepositoryTestSuite) TestList_ShouldRefuseSortFieldOutsideTheWhitelist() {
+	_, _, err := m.bookRepository.List(context.Background(), book.BookFilter{},
+		[]book.SortField{{Field: "title; DROP TABLE books"}}, 10, 0)
+	m.Suite.True(errors.Is(err, book.ErrInvalidSort))
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,94 @@
+package book
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// ActorHeader names whoever makes a change. The API has no authentication,
+// so the name is recorded on the revisions as given.
+const ActorHeader = "X-User"
+
+// maxActorLength is the most characters of an actor kept, as many as the
+// columns recording it hold.
+const maxActorLength = 100
+
+type actorKey struct{}
+
+// WithActor returns a context that records changes as made by actor.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, or "".
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// ActorMiddleware puts the ActorHeader of each request on its context.
+func ActorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := strings.TrimSpace(strings.ToValidUTF8(r.Header.Get(ActorHeader), "\uFFFD"))
+		if actor != "" {
+			r = r.WithContext(WithActor(r.Context(), truncateRunes(actor, maxActorLength)))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// truncateRunes cuts s to at most n characters, never inside one.
+func truncateRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+// FieldChange is a field whose value differs between two revisions.
+type FieldChange struct {
+	Field string
+	From  interface{}
+	To    interface{}
+}
+
+// revisionFields lists the snapshot fields in the order a diff reports them.
+var revisionFields = []string{"title", "author", "description", "isbn", "genre", "language", "publishedYear", "authors"}
+
+// DiffSnapshots compares two snapshots field by field, with the values as
+// they appear in JSON.
+func DiffSnapshots(from, to CreateOrUpdateBookRequest) ([]FieldChange, error) {
+	a, err := snapshotFields(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := snapshotFields(to)
+	if err != nil {
+		return nil, err
+	}
+	changes := []FieldChange{}
+	for _, f := range revisionFields {
+		if !reflect.DeepEqual(a[f], b[f]) {
+			changes = append(changes, FieldChange{Field: f, From: a[f], To: b[f]})
+		}
+	}
+	return changes, nil
+}
+
+func snapshotFields(s CreateOrUpdateBookRequest) (map[string]interface{}, error) {
+	if s.Authors == nil {
+		s.Authors = []BookAuthorRequest{}
+	}
+	doc, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	return fields, json.Unmarshal(doc, &fields)
+}
@@ -0,0 +1,61 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffSnapshots_ShouldListChangedFieldsInOrder(t *testing.T) {
+	from := book.CreateOrUpdateBookRequest{Title: "Dune", Author: "Frank Herbert", PublishedYear: 1965}
+	to := book.CreateOrUpdateBookRequest{
+		Title:   "Dune Messiah",
+		Author:  "Frank Herbert",
+		Authors: []book.BookAuthorRequest{{AuthorID: 3, Role: "author"}},
+	}
+	changes, err := book.DiffSnapshots(from, to)
+	require.NoError(t, err)
+	require.Equal(t, []book.FieldChange{
+		{Field: "title", From: "Dune", To: "Dune Messiah"},
+		{Field: "publishedYear", From: float64(1965), To: float64(0)},
+		{Field: "authors", From: []interface{}{}, To: []interface{}{map[string]interface{}{"authorId": float64(3), "role": "author"}}},
+	}, changes)
+}
+
+func TestDiffSnapshots_ShouldTreatMissingAuthorsAsNone(t *testing.T) {
+	changes, err := book.DiffSnapshots(
+		book.CreateOrUpdateBookRequest{Title: "Dune"},
+		book.CreateOrUpdateBookRequest{Title: "Dune", Authors: []book.BookAuthorRequest{}})
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestActorMiddleware_ShouldPutTheUserOnTheContext(t *testing.T) {
+	var actor string
+	h := book.ActorMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor = book.ActorFromContext(r.Context())
+	}))
+	r := httptest.NewRequest("PUT", "/books/12", nil)
+	r.Header.Set(book.ActorHeader, "  alice ")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	require.Equal(t, "alice", actor)
+
+	r.Header.Set(book.ActorHeader, strings.Repeat("a", 150))
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	require.Len(t, actor, 100)
+
+	r.Header.Set(book.ActorHeader, strings.Repeat("é", 150))
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	require.Equal(t, strings.Repeat("é", 100), actor)
+
+	r.Header.Set(book.ActorHeader, "bob\xff")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	require.Equal(t, "bob\uFFFD", actor)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("PUT", "/books/12", nil))
+	require.Empty(t, actor)
+}
@@ -0,0 +1,55 @@
+package book
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var ErrEmptyQuery = errors.New("search query has no words")
+
+// SearchResult is a book matched by a full-text search, with its relevance
+// and the matching parts of the title and description highlighted.
+type SearchResult struct {
+	Book
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
+
+// ParseSearchQuery turns user input into a Postgres tsquery. Words must all
+// match; "double quoted" words must appear next to each other in order, and a
+// word ending in * matches any word starting with it. Everything other than
+// letters and digits separates words, so the input can never break the
+// tsquery syntax.
+func ParseSearchQuery(q string) (string, error) {
+	var terms []string
+	for i, part := range strings.Split(q, `"`) {
+		if i%2 == 1 {
+			if words := searchWords(part); len(words) > 0 {
+				terms = append(terms, "("+strings.Join(words, " <-> ")+")")
+			}
+			continue
+		}
+		for _, field := range strings.Fields(part) {
+			words := searchWords(field)
+			if len(words) == 0 {
+				continue
+			}
+			if strings.HasSuffix(field, "*") {
+				words[len(words)-1] += ":*"
+			}
+			terms = append(terms, words...)
+		}
+	}
+	if len(terms) == 0 {
+		return "", ErrEmptyQuery
+	}
+	return strings.Join(terms, " & "), nil
+}
+
+func searchWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,37 @@
+package book_test
+
+import (
+	"book-store/internal/book"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want string
+	}{
+		{"potter", "potter"},
+		{"Harry  Potter", "harry & potter"},
+		{`"half blood" prince`, "(half <-> blood) & prince"},
+		{"pott*", "pott:*"},
+		{`wizard "philosopher's stone" phil*`, "wizard & (philosopher <-> s <-> stone) & phil:*"},
+		{"o'brien", "o & brien"},
+		{"a|b & !c:*", "a & b & c:*"},
+		{`"unterminated phrase`, "(unterminated <-> phrase)"},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := book.ParseSearchQuery(tc.in)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseSearchQuery_ShouldRejectQueryWithoutWords(t *testing.T) {
+	for _, in := range []string{"", "   ", `""`, "&|!*"} {
+		_, err := book.ParseSearchQuery(in)
+		require.Equal(t, book.ErrEmptyQuery, err)
+	}
+}
@@ -2,33 +2,89 @@ package book
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
 	"github.com/sirupsen/logrus"
 )
 
 type BookService interface {
 	Create(ctx context.Context, req CreateOrUpdateBookRequest) (int64, *ErrorResponse)
 	Get(ctx context.Context, id int) (Book, *ErrorResponse)
-	List(ctx context.Context,limit, offset int) ([]Book,int, *ErrorResponse)
-	CreateOrUpdate(ctx context.Context, id int, req CreateOrUpdateBookRequest) (int64, *ErrorResponse)
-	Delete(ctx context.Context, id int) *ErrorResponse
+	GetByISBN(ctx context.Context, isbn string) (Book, *ErrorResponse)
+	// GetMany returns the books with the ids in the order given, each once.
+	// It fails when any of them is not found.
+	GetMany(ctx context.Context, ids []int) ([]Book, *ErrorResponse)
+	List(ctx context.Context, f BookFilter, sort []SortField, limit, offset int) ([]Book, int, *ErrorResponse)
+	// Scroll returns the page after the cursor, or the first page for an
+	// empty cursor. A nil sort continues in the order of the cursor.
+	Scroll(ctx context.Context, f BookFilter, sort []SortField, cursor string, limit int, withTotal bool) (BookScroll, *ErrorResponse)
+	// Export calls fn with every book matching the filter in the sort
+	// order, a batch at a time. An error returned by fn stops the export.
+	Export(ctx context.Context, f BookFilter, sort []SortField, fn func([]Book) error) *ErrorResponse
+	Search(ctx context.Context, q string, f BookFilter, limit, offset int) ([]SearchResult, int, *ErrorResponse)
+	// Facets counts the books matching the filter, and the search q unless it
+	// is empty, per facet value.
+	Facets(ctx context.Context, f BookFilter, q string) (Facets, *ErrorResponse)
+	Suggest(ctx context.Context, q string, limit int) ([]Suggestion, *ErrorResponse)
+	// CreateOrUpdate creates the book at the given id when it does not exist,
+	// or else replaces it provided ifMatch matches its current version. It
+	// returns the book as stored and whether it was created.
+	CreateOrUpdate(ctx context.Context, id int, req CreateOrUpdateBookRequest, ifMatch *Precondition) (Book, bool, *ErrorResponse)
+	// Patch applies a merge patch or JSON patch, per contentType, to the book
+	// provided ifMatch matches its current version, validates the result like
+	// a PUT body and returns the new version.
+	Patch(ctx context.Context, id int, contentType string, patch []byte, ifMatch *Precondition) (int, *ErrorResponse)
+	// Delete moves the book to the trash provided ifMatch matches its current
+	// version.
+	Delete(ctx context.Context, id int, ifMatch *Precondition) *ErrorResponse
+	ListTrash(ctx context.Context, limit, offset int) ([]Book, int, *ErrorResponse)
+	// Restore takes the book out of the trash and returns it.
+	Restore(ctx context.Context, id int) (Book, *ErrorResponse)
+	// PurgeTrash removes the books that have been in the trash for longer
+	// than retention and returns how many it removed.
+	PurgeTrash(ctx context.Context, retention time.Duration) (int, error)
+	// Batch applies a list of creates, updates and deletes, all or nothing
+	// when atomic, and reports on each.
+	Batch(ctx context.Context, ops []BatchOperation, atomic bool) []BatchResult
+	// ListRevisions pages through the revisions of the book, newest first.
+	ListRevisions(ctx context.Context, id, limit, offset int) ([]Revision, int, *ErrorResponse)
+	GetRevision(ctx context.Context, id, rev int) (Revision, *ErrorResponse)
+	// DiffRevisions lists the fields that changed from one revision of the
+	// book to another.
+	DiffRevisions(ctx context.Context, id, from, to int) ([]FieldChange, *ErrorResponse)
+	// Revert replaces the book with the snapshot of the given revision,
+	// provided ifMatch matches its current version, and returns the new
+	// version.
+	Revert(ctx context.Context, id, rev int, ifMatch *Precondition) (int, *ErrorResponse)
 }
 
 type bookService struct {
 	repository BookRepository
+	cursors    CursorCodec
+	val        *validator.Validate
 }
 
-func NewBookService(r BookRepository) BookService {
-	return &bookService{repository: r}
+func NewBookService(r BookRepository, cursors CursorCodec) BookService {
+	return &bookService{repository: r, cursors: cursors, val: NewValidator()}
 }
 
 func (s *bookService) Create(ctx context.Context, req CreateOrUpdateBookRequest) (int64, *ErrorResponse) {
-	b := Book{
-		Title:       req.Title,
-		Author:      req.Author,
-		Description: req.Description,
+	var b Book
+	if errResp := applyRequest(&b, req); errResp != nil {
+		return 0, errResp
 	}
 	id, err := s.repository.Create(ctx, b)
 	if err != nil {
+		if errResp := writeErrorResponse(err); errResp != nil {
+			return 0, errResp
+		}
 		logrus.Error("error while creatin book. error is ",err)
 		return 0, GetErrorResponseByCode(InternalServerError)
 	}
@@ -48,49 +104,538 @@ func (s *bookService) Get(ctx context.Context, id int) (Book, *ErrorResponse) {
 	return book, nil
 }
 
-func (s *bookService) List(ctx context.Context,limit, offset int) ([]Book,int, *ErrorResponse) {
-	books,totalCount, err := s.repository.List(ctx, limit, offset)
+func (s *bookService) GetByISBN(ctx context.Context, isbn string) (Book, *ErrorResponse) {
+	normalized, err := NormalizeISBN(isbn)
+	if err != nil {
+		logrus.Error("invalid isbn provided ",isbn)
+		return Book{}, GetErrorResponse(BadRequest, err.Error(), http.StatusBadRequest)
+	}
+	book, err := s.repository.GetByISBN(ctx, normalized)
+	if err != nil {
+		if err == ErrNotFound {
+			logrus.Error("no record found for given isbn ",normalized)
+			return Book{}, GetErrorResponseByCode(BookNotFound)
+		}
+		logrus.Error("error while fetching the record for isbn ",normalized," error is ",err)
+		return Book{}, GetErrorResponseByCode(InternalServerError)
+	}
+	return book, nil
+}
+
+func (s *bookService) GetMany(ctx context.Context, ids []int) ([]Book, *ErrorResponse) {
+	unique := make([]int, 0, len(ids))
+	seen := map[int]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	found, _, err := s.repository.List(ctx, BookFilter{IDs: unique}, nil, len(unique), 0)
+	if err != nil {
+		logrus.Error("error while fetching the records for ids ", unique, " error is ", err)
+		return nil, GetErrorResponseByCode(InternalServerError)
+	}
+	byID := make(map[int]Book, len(found))
+	for _, b := range found {
+		byID[b.ID] = b
+	}
+	books := make([]Book, 0, len(unique))
+	var missing []string
+	for _, id := range unique {
+		b, ok := byID[id]
+		if !ok {
+			missing = append(missing, strconv.Itoa(id))
+			continue
+		}
+		books = append(books, b)
+	}
+	if len(missing) > 0 {
+		logrus.Error("no record found for given ids ", missing)
+		return nil, GetErrorResponse(BookNotFound, "no book found for ids "+strings.Join(missing, ", "), http.StatusNotFound)
+	}
+	return books, nil
+}
+
+func (s *bookService) List(ctx context.Context, f BookFilter, sort []SortField, limit, offset int) ([]Book, int, *ErrorResponse) {
+	books,totalCount, err := s.repository.List(ctx, f, sort, limit, offset)
 	if err != nil {
+		if errors.Is(err, ErrInvalidSort) {
+			logrus.Error("invalid sort provided ", sort)
+			return nil, 0, GetErrorResponse(BadRequest, err.Error(), http.StatusBadRequest)
+		}
 		logrus.Error("error while fetching all the records error is ",err)
 		return nil,0, GetErrorResponseByCode(InternalServerError)
 	}
 	return books,totalCount, nil
 }
 
-func (s *bookService) CreateOrUpdate(ctx context.Context, id int, req CreateOrUpdateBookRequest) (int64, *ErrorResponse) {
-	b, err := s.Get(ctx, id)
+func (s *bookService) Scroll(ctx context.Context, f BookFilter, sort []SortField, cursor string, limit int, withTotal bool) (BookScroll, *ErrorResponse) {
+	fingerprint := filterFingerprint(f)
+	var after []string
+	if cursor != "" {
+		cur, err := s.cursors.Decode(cursor)
+		if err != nil {
+			logrus.Error("invalid cursor provided ", cursor)
+			return BookScroll{}, GetErrorResponse(BadRequest, "invalid cursor", http.StatusBadRequest)
+		}
+		if sort == nil {
+			sort, err = ParseSort(cur.Sort)
+			if err != nil {
+				logrus.Error("cursor carries an invalid sort ", cur.Sort)
+				return BookScroll{}, GetErrorResponse(BadRequest, "invalid cursor", http.StatusBadRequest)
+			}
+		}
+		if FormatSort(sort) != cur.Sort || fingerprint != cur.Filter {
+			logrus.Error("cursor was issued for a different query")
+			return BookScroll{}, GetErrorResponse(BadRequest, "cursor does not match the filters and sort of the query", http.StatusBadRequest)
+		}
+		after = cur.Keys
+	}
+	books, err := s.repository.ListAfter(ctx, f, sort, after, limit+1)
+	if err != nil {
+		if errors.Is(err, ErrInvalidSort) {
+			logrus.Error("invalid sort or cursor provided. error is ", err)
+			return BookScroll{}, GetErrorResponse(BadRequest, err.Error(), http.StatusBadRequest)
+		}
+		logrus.Error("error while scrolling books. error is ", err)
+		return BookScroll{}, GetErrorResponseByCode(InternalServerError)
+	}
+	page := BookScroll{Books: books}
+	if len(books) > limit {
+		page.Books = books[:limit]
+		page.NextCursor = s.cursors.Encode(Cursor{
+			Sort:   FormatSort(sort),
+			Filter: fingerprint,
+			Keys:   sortKeys(sort, books[limit-1]),
+		})
+	}
+	if withTotal {
+		total, err := s.repository.Count(ctx, f)
+		if err != nil {
+			logrus.Error("error while counting books. error is ", err)
+			return BookScroll{}, GetErrorResponseByCode(InternalServerError)
+		}
+		page.Total = &total
+	}
+	return page, nil
+}
+
+func (s *bookService) Export(ctx context.Context, f BookFilter, sort []SortField, fn func([]Book) error) *ErrorResponse {
+	if err := s.repository.Export(ctx, f, sort, fn); err != nil {
+		logrus.Error("error while exporting the books. error is ", err)
+		return GetErrorResponseByCode(InternalServerError)
+	}
+	return nil
+}
+
+func (s *bookService) Search(ctx context.Context, q string, f BookFilter, limit, offset int) ([]SearchResult, int, *ErrorResponse) {
+	tsquery, err := ParseSearchQuery(q)
 	if err != nil {
-		if err == GetErrorResponseByCode(BookNotFound) {
-			logrus.Info("no record exist for given id  ",id," creating the record")
-			id, createErr := s.Create(ctx, req)
-			if createErr != nil {
-				logrus.Error("error while fetching creating the record. error is ",err)
-				return 0, GetErrorResponseByCode(InternalServerError)
+		logrus.Error("invalid search query provided ", q)
+		return nil, 0, GetErrorResponse(BadRequest, "q must contain at least one word", http.StatusBadRequest)
+	}
+	results, totalCount, err := s.repository.Search(ctx, tsquery, f, limit, offset)
+	if err != nil {
+		logrus.Error("error while searching books for ", q, " error is ", err)
+		return nil, 0, GetErrorResponseByCode(InternalServerError)
+	}
+	return results, totalCount, nil
+}
+
+func (s *bookService) Facets(ctx context.Context, f BookFilter, q string) (Facets, *ErrorResponse) {
+	var tsquery string
+	if q != "" {
+		var err error
+		if tsquery, err = ParseSearchQuery(q); err != nil {
+			logrus.Error("invalid search query provided ", q)
+			return Facets{}, GetErrorResponse(BadRequest, "q must contain at least one word", http.StatusBadRequest)
+		}
+	}
+	facets, err := s.repository.Facets(ctx, f, tsquery)
+	if err != nil {
+		logrus.Error("error while counting facets. error is ", err)
+		return Facets{}, GetErrorResponseByCode(InternalServerError)
+	}
+	return facets, nil
+}
+
+func (s *bookService) Suggest(ctx context.Context, q string, limit int) ([]Suggestion, *ErrorResponse) {
+	q, ok := normalizeSuggestQuery(q)
+	if !ok {
+		logrus.Error("suggest query too short ", q)
+		return nil, GetErrorResponse(BadRequest, fmt.Sprintf("q must be at least %d characters", MinSuggestQueryLength), http.StatusBadRequest)
+	}
+	ctx, cancel := context.WithTimeout(ctx, suggestTimeout)
+	defer cancel()
+	suggestions, err := s.repository.Suggest(ctx, q, limit)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			logrus.Error("suggestions for ", q, " took longer than ", suggestTimeout)
+			return nil, GetErrorResponseByCode(SuggestTimeout)
+		}
+		logrus.Error("error while suggesting books for ", q, " error is ", err)
+		return nil, GetErrorResponseByCode(InternalServerError)
+	}
+	return suggestions, nil
+}
+
+func (s *bookService) CreateOrUpdate(ctx context.Context, id int, req CreateOrUpdateBookRequest, ifMatch *Precondition) (Book, bool, *ErrorResponse) {
+	b := Book{ID: id}
+	if ifMatch != nil {
+		current, errResp := s.Get(ctx, id)
+		if errResp != nil {
+			if errResp == GetErrorResponseByCode(BookNotFound) {
+				if errResp := s.trashConflict(ctx, id); errResp != nil {
+					return Book{}, false, errResp
+				}
+				logrus.Error("if-match given for book ", id, " which does not exist")
+				return Book{}, false, GetErrorResponseByCode(PreconditionFailed)
 			}
-			return id, nil
+			return Book{}, false, errResp
+		}
+		if errResp := checkPrecondition(id, current.Version, ifMatch); errResp != nil {
+			return Book{}, false, errResp
 		}
-		return 0, err
+		b.Version = current.Version
 	}
-	if req.Title != "" {
-		b.Title = req.Title
+	if errResp := applyRequest(&b, req); errResp != nil {
+		return Book{}, false, errResp
 	}
-	if req.Author != "" {
-		b.Author = req.Author
+	if b.Authors == nil {
+		b.Authors = []BookAuthor{}
 	}
-	if req.Description != "" {
-		b.Description = req.Description
+	// Without If-Match b.Version is 0, which no stored book has, so an
+	// existing book is left alone and reported as a conflict.
+	created, _, err := s.repository.Upsert(ctx, b)
+	if err != nil {
+		if err == ErrVersionConflict && ifMatch == nil {
+			if errResp := s.trashConflict(ctx, id); errResp != nil {
+				return Book{}, false, errResp
+			}
+			logrus.Error("no if-match given for replacing book ", id)
+			return Book{}, false, GetErrorResponseByCode(PreconditionRequired)
+		}
+		if errResp := writeErrorResponse(err); errResp != nil {
+			return Book{}, false, errResp
+		}
+		logrus.Error("error while upserting book ", id, ". error is ", err)
+		return Book{}, false, GetErrorResponseByCode(InternalServerError)
 	}
-	if err := s.repository.Update(ctx, b); err != nil {
-		logrus.Error("error while updating the record. error is ",err)
+	b, errResp := s.Get(ctx, id)
+	if errResp != nil {
+		return Book{}, false, errResp
+	}
+	return b, created, nil
+}
+
+// trashConflict refuses to replace a book in the trash, which no If-Match
+// could name since the book cannot be read, and tells to restore it instead.
+func (s *bookService) trashConflict(ctx context.Context, id int) *ErrorResponse {
+	trashed, err := s.repository.InTrash(ctx, id)
+	if err != nil {
+		logrus.Error("error while looking for book ", id, " in the trash. error is ", err)
+		return GetErrorResponseByCode(InternalServerError)
+	}
+	if trashed {
+		logrus.Error("book ", id, " to replace is in the trash")
+		return GetErrorResponseByCode(BookInTrash)
+	}
+	return nil
+}
+
+func (s *bookService) Patch(ctx context.Context, id int, contentType string, patch []byte, ifMatch *Precondition) (int, *ErrorResponse) {
+	b, errResp := s.Get(ctx, id)
+	if errResp != nil {
+		if errResp == GetErrorResponseByCode(BookNotFound) && ifMatch != nil {
+			return 0, GetErrorResponseByCode(PreconditionFailed)
+		}
+		return 0, errResp
+	}
+	if errResp := checkPrecondition(id, b.Version, ifMatch); errResp != nil {
+		return 0, errResp
+	}
+	doc, err := json.Marshal(toBookRequest(b))
+	if err != nil {
+		logrus.Error("error while encoding book ", id, " for patching. error is ", err)
 		return 0, GetErrorResponseByCode(InternalServerError)
 	}
-	return 0, nil
+	patched, err := applyPatch(contentType, doc, patch)
+	if err != nil {
+		return 0, patchErrorResponse(err)
+	}
+	req, err := decodePatchedRequest(patched)
+	if err != nil {
+		return 0, patchErrorResponse(err)
+	}
+	if err := s.val.Struct(&req); err != nil {
+		return 0, validationErrorResponse(err)
+	}
+	return s.replace(ctx, b, req)
 }
 
-func (s *bookService) Delete(ctx context.Context, id int) *ErrorResponse {
-	err := s.repository.Delete(ctx, id)
+// replace overwrites every field of the book with the request, so whatever
+// the request leaves empty, the authors included, is cleared.
+func (s *bookService) replace(ctx context.Context, b Book, req CreateOrUpdateBookRequest) (int, *ErrorResponse) {
+	if errResp := applyRequest(&b, req); errResp != nil {
+		return 0, errResp
+	}
+	if b.Authors == nil {
+		b.Authors = []BookAuthor{}
+	}
+	version, err := s.repository.Update(ctx, b)
 	if err != nil {
+		if errResp := writeErrorResponse(err); errResp != nil {
+			return 0, errResp
+		}
+		logrus.Error("error while updating the record. error is ",err)
+		return 0, GetErrorResponseByCode(InternalServerError)
+	}
+	return version, nil
+}
+
+func (s *bookService) Delete(ctx context.Context, id int, ifMatch *Precondition) *ErrorResponse {
+	b, errResp := s.Get(ctx, id)
+	if errResp != nil {
+		return errResp
+	}
+	if errResp := checkPrecondition(id, b.Version, ifMatch); errResp != nil {
+		return errResp
+	}
+	if err := s.repository.Delete(ctx, id, b.Version); err != nil {
+		if errResp := writeErrorResponse(err); errResp != nil {
+			return errResp
+		}
+		logrus.Error("error while deleting the record. error is ", err)
 		return GetErrorResponseByCode(InternalServerError)
 	}
 	return nil
 }
+
+func (s *bookService) ListTrash(ctx context.Context, limit, offset int) ([]Book, int, *ErrorResponse) {
+	books, total, err := s.repository.ListTrash(ctx, limit, offset)
+	if err != nil {
+		logrus.Error("error while fetching the trashed books. error is ", err)
+		return nil, 0, GetErrorResponseByCode(InternalServerError)
+	}
+	return books, total, nil
+}
+
+func (s *bookService) Restore(ctx context.Context, id int) (Book, *ErrorResponse) {
+	if _, err := s.repository.Restore(ctx, id); err != nil {
+		if err == ErrNotFound {
+			logrus.Error("no trashed book found for given id ", id)
+			return Book{}, GetErrorResponseByCode(BookNotFound)
+		}
+		if errResp := writeErrorResponse(err); errResp != nil {
+			return Book{}, errResp
+		}
+		logrus.Error("error while restoring book ", id, ". error is ", err)
+		return Book{}, GetErrorResponseByCode(InternalServerError)
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *bookService) PurgeTrash(ctx context.Context, retention time.Duration) (int, error) {
+	return s.repository.Purge(ctx, time.Now().UTC().Add(-retention))
+}
+
+func (s *bookService) ListRevisions(ctx context.Context, id, limit, offset int) ([]Revision, int, *ErrorResponse) {
+	revisions, total, err := s.repository.ListRevisions(ctx, id, limit, offset)
+	if err != nil {
+		logrus.Error("error while fetching the revisions of book ", id, ". error is ", err)
+		return nil, 0, GetErrorResponseByCode(InternalServerError)
+	}
+	// Every book has a revision from the moment it is created.
+	if len(revisions) == 0 && offset == 0 {
+		logrus.Error("no revisions found for book ", id)
+		return nil, 0, GetErrorResponseByCode(BookNotFound)
+	}
+	return revisions, total, nil
+}
+
+func (s *bookService) GetRevision(ctx context.Context, id, rev int) (Revision, *ErrorResponse) {
+	revision, err := s.repository.GetRevision(ctx, id, rev)
+	if err != nil {
+		if err == ErrRevisionNotFound {
+			logrus.Error("no revision ", rev, " found for book ", id)
+			return Revision{}, GetErrorResponseByCode(RevisionNotFound)
+		}
+		logrus.Error("error while fetching revision ", rev, " of book ", id, ". error is ", err)
+		return Revision{}, GetErrorResponseByCode(InternalServerError)
+	}
+	return revision, nil
+}
+
+func (s *bookService) DiffRevisions(ctx context.Context, id, from, to int) ([]FieldChange, *ErrorResponse) {
+	a, errResp := s.GetRevision(ctx, id, from)
+	if errResp != nil {
+		return nil, errResp
+	}
+	b, errResp := s.GetRevision(ctx, id, to)
+	if errResp != nil {
+		return nil, errResp
+	}
+	changes, err := DiffSnapshots(a.Snapshot, b.Snapshot)
+	if err != nil {
+		logrus.Error("error while comparing revisions ", from, " and ", to, " of book ", id, ". error is ", err)
+		return nil, GetErrorResponseByCode(InternalServerError)
+	}
+	return changes, nil
+}
+
+// Revert goes through the same validation as a PUT, since the rules may have
+// tightened or the authors gone away since the revision was taken.
+func (s *bookService) Revert(ctx context.Context, id, rev int, ifMatch *Precondition) (int, *ErrorResponse) {
+	b, errResp := s.Get(ctx, id)
+	if errResp != nil {
+		return 0, errResp
+	}
+	if errResp := checkPrecondition(id, b.Version, ifMatch); errResp != nil {
+		return 0, errResp
+	}
+	revision, errResp := s.GetRevision(ctx, id, rev)
+	if errResp != nil {
+		return 0, errResp
+	}
+	if err := s.val.Struct(&revision.Snapshot); err != nil {
+		return 0, validationErrorResponse(err)
+	}
+	return s.replace(ctx, b, revision.Snapshot)
+}
+
+// RunTrashPurge purges the trash right away and then every interval until
+// ctx is done, removing the books deleted more than retention ago.
+func RunTrashPurge(ctx context.Context, svc BookService, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := svc.PurgeTrash(ctx, retention)
+		if err != nil && ctx.Err() == nil {
+			logrus.Error("error while purging the trash. error is ", err)
+		}
+		if n > 0 {
+			logrus.Info("purged ", n, " books from the trash")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkPrecondition requires an If-Match naming the current version before an
+// existing book is changed, so stale edits cannot overwrite newer ones.
+func checkPrecondition(id, version int, ifMatch *Precondition) *ErrorResponse {
+	if ifMatch == nil {
+		logrus.Error("no if-match given for changing book ", id)
+		return GetErrorResponseByCode(PreconditionRequired)
+	}
+	if !ifMatch.Matches(version) {
+		logrus.Error("if-match does not match version ", version, " of book ", id)
+		return GetErrorResponseByCode(PreconditionFailed)
+	}
+	return nil
+}
+
+// applyRequest copies the fields of the request onto the book, normalizing
+// the isbn, genre and language.
+func applyRequest(b *Book, req CreateOrUpdateBookRequest) *ErrorResponse {
+	if req.Author == "" && len(req.Authors) == 0 {
+		logrus.Error("neither an author nor linked authors provided")
+		return GetErrorResponse(BadRequest, "author is required unless authors are linked", http.StatusBadRequest)
+	}
+	b.Title = req.Title
+	b.Author = req.Author
+	b.Description = req.Description
+	b.ISBN = ""
+	if req.ISBN != "" {
+		isbn, err := NormalizeISBN(req.ISBN)
+		if err != nil {
+			logrus.Error("invalid isbn provided ",req.ISBN)
+			return GetErrorResponse(BadRequest, err.Error(), http.StatusBadRequest)
+		}
+		b.ISBN = isbn
+	}
+	b.Genre = strings.TrimSpace(req.Genre)
+	b.Language = strings.ToLower(req.Language)
+	b.PublishedYear = req.PublishedYear
+	authors, errResp := toBookAuthors(req.Authors)
+	if errResp != nil {
+		return errResp
+	}
+	b.Authors = authors
+	return nil
+}
+
+// toBookRequest is the inverse of applyRequest: the document a patch is
+// applied to.
+func toBookRequest(b Book) CreateOrUpdateBookRequest {
+	authors := make([]BookAuthorRequest, 0, len(b.Authors))
+	for _, a := range b.Authors {
+		authors = append(authors, BookAuthorRequest{AuthorID: a.AuthorID, Role: a.Role})
+	}
+	return CreateOrUpdateBookRequest{
+		Title:         b.Title,
+		Author:        b.Author,
+		Description:   b.Description,
+		ISBN:          b.ISBN,
+		Authors:       authors,
+		Genre:         b.Genre,
+		Language:      b.Language,
+		PublishedYear: b.PublishedYear,
+	}
+}
+
+// patchErrorResponse maps a patch that cannot be applied to 400, or to 409
+// when one of its test operations did not hold.
+func patchErrorResponse(err error) *ErrorResponse {
+	logrus.Error("error while applying the patch. error is ", err)
+	if errors.Is(err, ErrPatchTestFailed) {
+		return GetErrorResponse(PatchTestFailed, err.Error(), http.StatusConflict)
+	}
+	return GetErrorResponse(BadRequest, err.Error(), http.StatusBadRequest)
+}
+
+// toBookAuthors turns the requested authors into links ordered as given.
+// A nil request yields nil.
+func toBookAuthors(req []BookAuthorRequest) ([]BookAuthor, *ErrorResponse) {
+	if req == nil {
+		return nil, nil
+	}
+	authors := make([]BookAuthor, len(req))
+	seen := make(map[BookAuthorRequest]bool, len(req))
+	for i, a := range req {
+		if a.Role == "" {
+			a.Role = RoleAuthor
+		}
+		if seen[a] {
+			logrus.Error("author ",a.AuthorID," listed twice with role ",a.Role)
+			return nil, GetErrorResponse(BadRequest, fmt.Sprintf("author %d is listed twice as %s", a.AuthorID, a.Role), http.StatusBadRequest)
+		}
+		seen[a] = true
+		authors[i] = BookAuthor{AuthorID: a.AuthorID, Role: a.Role, Position: i + 1}
+	}
+	return authors, nil
+}
+
+// writeErrorResponse maps the repository errors a write can hit because of
+// bad input. It returns nil for anything that is a server-side failure.
+func writeErrorResponse(err error) *ErrorResponse {
+	switch err {
+	case ErrDuplicateISBN:
+		logrus.Error("book with the given isbn already exists")
+		return GetErrorResponseByCode(IsbnAlreadyExists)
+	case ErrAuthorNotFound:
+		logrus.Error("book references an author that does not exist")
+		return GetErrorResponse(BadRequest, "one or more authors do not exist", http.StatusBadRequest)
+	case ErrVersionConflict:
+		logrus.Error("book was changed concurrently")
+		return GetErrorResponseByCode(PreconditionFailed)
+	case ErrBookInCirculation:
+		logrus.Error("book has open loans or holds")
+		return GetErrorResponseByCode(BookInCirculation)
+	}
+	return nil
+}
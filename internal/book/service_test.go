@@ -5,7 +5,9 @@ import (
 	mock_book "book-store/internal/mocks"
 	"context"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
@@ -15,6 +17,7 @@ type BookServiceTestSuite struct {
 	suite.Suite
 	bookService book.BookService
 	mockRepo    *mock_book.MockBookRepository
+	cursors     book.CursorCodec
 	ctrl        *gomock.Controller
 }
 
@@ -25,7 +28,8 @@ func TestBookServiceTestSuite(t *testing.T) {
 func (m *BookServiceTestSuite) SetupSuite() {
 	m.ctrl = gomock.NewController(m.Suite.T())
 	m.mockRepo = mock_book.NewMockBookRepository(m.ctrl)
-	m.bookService = book.NewBookService(m.mockRepo)
+	m.cursors = book.NewCursorCodec([]byte("secret"))
+	m.bookService = book.NewBookService(m.mockRepo, m.cursors)
 }
 
 func (m *BookServiceTestSuite) TearDownTest() {
@@ -93,7 +97,7 @@ func (m *BookServiceTestSuite) TestGet_ShouldReturnInternalServerErrorIfReposito
 }
 
 func (m *BookServiceTestSuite) TestList_ShouldReturnAllBooksForCurrentPage() {
-	m.mockRepo.EXPECT().List(context.Background(), 10, 2).Return([]book.Book{{
+	m.mockRepo.EXPECT().List(context.Background(), book.BookFilter{}, nil, 10, 2).Return([]book.Book{{
 		ID:          12,
 		Title:       "Harry Potter",
 		Author:      "JK Rolling",
@@ -105,7 +109,7 @@ func (m *BookServiceTestSuite) TestList_ShouldReturnAllBooksForCurrentPage() {
 		Description: "HarryPotter and Chambers of Secret 2",
 	},
 	},1, nil)
-	b, totalCount, err := m.bookService.List(context.Background(), 10, 2)
+	b, totalCount, err := m.bookService.List(context.Background(), book.BookFilter{}, nil, 10, 2)
 	m.Suite.Nil(err)
 	m.Suite.Equal(1,totalCount)
 	m.Suite.Equal(b, []book.Book{{
@@ -123,49 +127,83 @@ func (m *BookServiceTestSuite) TestList_ShouldReturnAllBooksForCurrentPage() {
 }
 
 func (m *BookServiceTestSuite) TestList_ShouldReturnErrorWhenRepositoryFails() {
-	m.mockRepo.EXPECT().List(context.Background(), 10, 2).Return(nil,0, errors.New("unable to connect"))
-	b,totalCount, err := m.bookService.List(context.Background(), 10, 2)
+	m.mockRepo.EXPECT().List(context.Background(), book.BookFilter{}, nil, 10, 2).Return(nil,0, errors.New("unable to connect"))
+	b,totalCount, err := m.bookService.List(context.Background(), book.BookFilter{}, nil, 10, 2)
 	m.Suite.Nil(b)
 	m.Suite.Zero(totalCount)
 	m.Suite.Equal(book.GetErrorResponseByCode(book.InternalServerError),err)
 }
 
+func (m *BookServiceTestSuite) TestGetMany_ShouldReturnTheBooksInTheOrderOfTheIDs() {
+	m.mockRepo.EXPECT().List(context.Background(), book.BookFilter{IDs: []int{13, 12}}, nil, 2, 0).
+		Return([]book.Book{{ID: 12, Title: "Dune"}, {ID: 13, Title: "Emma"}}, 2, nil)
+	b, err := m.bookService.GetMany(context.Background(), []int{13, 12, 13})
+	m.Suite.Nil(err)
+	m.Suite.Equal([]book.Book{{ID: 13, Title: "Emma"}, {ID: 12, Title: "Dune"}}, b)
+}
+
+func (m *BookServiceTestSuite) TestGetMany_ShouldReturnNotFoundNamingTheMissingIDs() {
+	m.mockRepo.EXPECT().List(context.Background(), book.BookFilter{IDs: []int{12, 98, 99}}, nil, 3, 0).
+		Return([]book.Book{{ID: 12, Title: "Dune"}}, 1, nil)
+	b, err := m.bookService.GetMany(context.Background(), []int{12, 98, 99})
+	m.Suite.Nil(b)
+	m.Suite.Equal(book.GetErrorResponse(book.BookNotFound, "no book found for ids 98, 99", http.StatusNotFound), err)
+}
+
+func (m *BookServiceTestSuite) TestGetMany_ShouldReturnErrorWhenRepositoryFails() {
+	m.mockRepo.EXPECT().List(context.Background(), book.BookFilter{IDs: []int{12}}, nil, 1, 0).Return(nil, 0, errors.New("unable to connect"))
+	b, err := m.bookService.GetMany(context.Background(), []int{12})
+	m.Suite.Nil(b)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.InternalServerError), err)
+}
+
 func (m *BookServiceTestSuite) TestUpdate() {
 	b := book.Book{
 		ID:          12,
 		Title:       "Harry Potter",
 		Author:      "JK Rolling",
 		Description: "HarryPotter and Chambers of Secret",
+		Version:     3,
 	}
-	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(b, nil)
-	m.mockRepo.EXPECT().Update(context.Background(), book.Book{
+	updated := book.Book{
 		ID:          12,
 		Title:       "Harry Potter 4",
 		Author:      "JKR",
 		Description: "HarryPotter and Goblet Of Fire",
-	}).Return(nil)
-	bId, err := m.bookService.CreateOrUpdate(context.Background(), 12, book.CreateOrUpdateBookRequest{
+		Authors:     []book.BookAuthor{},
+		Version:     3,
+	}
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(b, nil)
+	m.mockRepo.EXPECT().Upsert(context.Background(), updated).Return(false, 4, nil)
+	updated.Version = 4
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(updated, nil)
+	got, created, err := m.bookService.CreateOrUpdate(context.Background(), 12, book.CreateOrUpdateBookRequest{
 		Title:       "Harry Potter 4",
 		Author:      "JKR",
 		Description: "HarryPotter and Goblet Of Fire",
-	})
-	m.Suite.Zero(bId)
+	}, book.ParseIfMatch(`"3"`))
+	m.Suite.False(created)
+	m.Suite.Equal(updated, got)
 	m.Suite.Nil(err)
 }
 
-func (m *BookServiceTestSuite) TestUpdate_ShouldCreateNewBookWhenBookWithGivenIdDoesNotExit() {
-	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{}, book.ErrNotFound)
-	m.mockRepo.EXPECT().Create(context.Background(), book.Book{
+func (m *BookServiceTestSuite) TestUpdate_ShouldCreateNewBookAtTheGivenIdWhenItDoesNotExist() {
+	m.mockRepo.EXPECT().Upsert(context.Background(), book.Book{
+		ID:          100,
 		Title:       "Harry Potter 4",
 		Author:      "JKR",
 		Description: "HarryPotter and Goblet Of Fire",
-	}).Return(int64(1), nil)
-	bId, err := m.bookService.CreateOrUpdate(context.Background(), 12, book.CreateOrUpdateBookRequest{
+		Authors:     []book.BookAuthor{},
+	}).Return(true, 1, nil)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 100).Return(book.Book{ID: 100, Title: "Harry Potter 4", Version: 1}, nil)
+	got, created, err := m.bookService.CreateOrUpdate(context.Background(), 100, book.CreateOrUpdateBookRequest{
 		Title:       "Harry Potter 4",
 		Author:      "JKR",
 		Description: "HarryPotter and Goblet Of Fire",
-	})
-	m.Suite.Equal(int64(1), bId)
+	}, nil)
+	m.Suite.True(created)
+	m.Suite.Equal(100, got.ID)
+	m.Suite.Equal(1, got.Version)
 	m.Suite.Nil(err)
 }
 
@@ -175,31 +213,533 @@ func (m *BookServiceTestSuite) TestUpdate_ShouldReturnInternalServerErrorWhenUpd
 		Title:       "Harry Potter",
 		Author:      "JK Rolling",
 		Description: "HarryPotter and Chambers of Secret",
+		Version:     3,
 	}
 	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(b, nil)
-	m.mockRepo.EXPECT().Update(context.Background(), book.Book{
+	m.mockRepo.EXPECT().Upsert(context.Background(), book.Book{
 		ID:          12,
 		Title:       "Harry Potter 4",
 		Author:      "JKR",
 		Description: "HarryPotter and Goblet Of Fire",
-	}).Return(errors.New("unable to connect"))
-	bId, err := m.bookService.CreateOrUpdate(context.Background(), 12, book.CreateOrUpdateBookRequest{
+		Authors:     []book.BookAuthor{},
+		Version:     3,
+	}).Return(false, 0, errors.New("unable to connect"))
+	_, _, err := m.bookService.CreateOrUpdate(context.Background(), 12, book.CreateOrUpdateBookRequest{
 		Title:       "Harry Potter 4",
 		Author:      "JKR",
 		Description: "HarryPotter and Goblet Of Fire",
-	})
-	m.Suite.Zero(bId)
+	}, book.ParseIfMatch("*"))
 	m.Suite.Equal(err, book.GetErrorResponseByCode(book.InternalServerError))
 }
 
 func (m *BookServiceTestSuite) TestDelete() {
-	m.mockRepo.EXPECT().Delete(context.Background(), 12).Return(nil)
-	err := m.bookService.Delete(context.Background(), 12)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12, Version: 3}, nil)
+	m.mockRepo.EXPECT().Delete(context.Background(), 12, 3).Return(nil)
+	err := m.bookService.Delete(context.Background(), 12, book.ParseIfMatch(`"3"`))
 	m.Suite.Nil(err)
 }
 
 func (m *BookServiceTestSuite) TestDelete_ShouldReturnInternalServerErrorWhenUpdateFails() {
-	m.mockRepo.EXPECT().Delete(context.Background(), 12).Return(errors.New("unable to connect"))
-	err := m.bookService.Delete(context.Background(), 12)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12, Version: 3}, nil)
+	m.mockRepo.EXPECT().Delete(context.Background(), 12, 3).Return(errors.New("unable to connect"))
+	err := m.bookService.Delete(context.Background(), 12, book.ParseIfMatch(`"3"`))
 	m.Suite.Equal(err, book.GetErrorResponseByCode(book.InternalServerError))
 }
+
+func (m *BookServiceTestSuite) TestDelete_ShouldReturnConflictForABookInCirculation() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12, Version: 3}, nil)
+	m.mockRepo.EXPECT().Delete(context.Background(), 12, 3).Return(book.ErrBookInCirculation)
+	err := m.bookService.Delete(context.Background(), 12, book.ParseIfMatch(`"3"`))
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BookInCirculation), err)
+}
+
+func (m *BookServiceTestSuite) TestDelete_ShouldRequireMatchingIfMatch() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12, Version: 3}, nil).Times(2)
+	err := m.bookService.Delete(context.Background(), 12, nil)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.PreconditionRequired), err)
+
+	err = m.bookService.Delete(context.Background(), 12, book.ParseIfMatch(`"2", W/"3"`))
+	m.Suite.Equal(book.GetErrorResponseByCode(book.PreconditionFailed), err)
+}
+
+func (m *BookServiceTestSuite) TestDelete_ShouldReturnNotFoundForMissingOrTrashedBook() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{}, book.ErrNotFound)
+	err := m.bookService.Delete(context.Background(), 12, book.ParseIfMatch(`"3"`))
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BookNotFound), err)
+}
+
+func (m *BookServiceTestSuite) TestRestore() {
+	m.mockRepo.EXPECT().Restore(context.Background(), 12).Return(5, nil)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12, Version: 5}, nil)
+	b, err := m.bookService.Restore(context.Background(), 12)
+	m.Suite.Nil(err)
+	m.Suite.Equal(5, b.Version)
+}
+
+func (m *BookServiceTestSuite) TestRestore_ShouldMapRepositoryErrors() {
+	m.mockRepo.EXPECT().Restore(context.Background(), 12).Return(0, book.ErrNotFound)
+	_, err := m.bookService.Restore(context.Background(), 12)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BookNotFound), err)
+
+	m.mockRepo.EXPECT().Restore(context.Background(), 12).Return(0, book.ErrDuplicateISBN)
+	_, err = m.bookService.Restore(context.Background(), 12)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.IsbnAlreadyExists), err)
+}
+
+func (m *BookServiceTestSuite) TestListRevisions_ShouldReturnNotFoundForBooksWithoutRevisions() {
+	m.mockRepo.EXPECT().ListRevisions(context.Background(), 12, 10, 0).Return([]book.Revision{}, 0, nil)
+	_, _, err := m.bookService.ListRevisions(context.Background(), 12, 10, 0)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BookNotFound), err)
+}
+
+func (m *BookServiceTestSuite) TestDiffRevisions() {
+	m.mockRepo.EXPECT().GetRevision(context.Background(), 12, 1).Return(book.Revision{Revision: 1, Snapshot: book.CreateOrUpdateBookRequest{Title: "Dune", Author: "Frank Herbert"}}, nil)
+	m.mockRepo.EXPECT().GetRevision(context.Background(), 12, 2).Return(book.Revision{Revision: 2, Snapshot: book.CreateOrUpdateBookRequest{Title: "Dune Messiah", Author: "Frank Herbert"}}, nil)
+	changes, err := m.bookService.DiffRevisions(context.Background(), 12, 1, 2)
+	m.Suite.Nil(err)
+	m.Suite.Equal([]book.FieldChange{{Field: "title", From: "Dune", To: "Dune Messiah"}}, changes)
+
+	m.mockRepo.EXPECT().GetRevision(context.Background(), 12, 9).Return(book.Revision{}, book.ErrRevisionNotFound)
+	_, err = m.bookService.DiffRevisions(context.Background(), 12, 9, 2)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.RevisionNotFound), err)
+}
+
+func (m *BookServiceTestSuite) TestRevert_ShouldReplaceTheBookWithTheSnapshot() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12, Title: "Dune Messiah", Version: 3}, nil)
+	m.mockRepo.EXPECT().GetRevision(context.Background(), 12, 1).Return(book.Revision{Revision: 1, Snapshot: book.CreateOrUpdateBookRequest{
+		Title:   "Dune",
+		Author:  "Frank Herbert",
+		ISBN:    "9780441013593",
+		Authors: []book.BookAuthorRequest{{AuthorID: 3, Role: "author"}},
+	}}, nil)
+	m.mockRepo.EXPECT().Update(context.Background(), book.Book{
+		ID:      12,
+		Title:   "Dune",
+		Author:  "Frank Herbert",
+		ISBN:    "9780441013593",
+		Authors: []book.BookAuthor{{AuthorID: 3, Role: "author", Position: 1}},
+		Version: 3,
+	}).Return(4, nil)
+	version, err := m.bookService.Revert(context.Background(), 12, 1, book.ParseIfMatch(`"3"`))
+	m.Suite.Nil(err)
+	m.Suite.Equal(4, version)
+}
+
+func (m *BookServiceTestSuite) TestRevert_ShouldRequireIfMatch() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12, Version: 3}, nil)
+	_, err := m.bookService.Revert(context.Background(), 12, 1, nil)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.PreconditionRequired), err)
+}
+
+func (m *BookServiceTestSuite) TestPurgeTrash_ShouldPurgeBooksDeletedBeforeTheRetention() {
+	m.mockRepo.EXPECT().Purge(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int, error) {
+		m.Suite.WithinDuration(time.Now().Add(-48*time.Hour), before, time.Minute)
+		return 3, nil
+	})
+	n, err := m.bookService.PurgeTrash(context.Background(), 48*time.Hour)
+	m.Suite.Nil(err)
+	m.Suite.Equal(3, n)
+}
+
+func (m *BookServiceTestSuite) TestUpdate_ShouldReturnPreconditionFailedForStaleVersion() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12, Title: "Dune", Version: 4}, nil)
+	_, _, err := m.bookService.CreateOrUpdate(context.Background(), 12, book.CreateOrUpdateBookRequest{Title: "Dune Messiah"}, book.ParseIfMatch(`"3"`))
+	m.Suite.Equal(book.GetErrorResponseByCode(book.PreconditionFailed), err)
+}
+
+func (m *BookServiceTestSuite) TestUpdate_ShouldReturnPreconditionFailedWhenChangedConcurrently() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12, Title: "Dune", Version: 3}, nil)
+	m.mockRepo.EXPECT().Upsert(context.Background(), book.Book{ID: 12, Title: "Dune Messiah", Author: "Frank Herbert", Authors: []book.BookAuthor{}, Version: 3}).Return(false, 0, book.ErrVersionConflict)
+	_, _, err := m.bookService.CreateOrUpdate(context.Background(), 12, book.CreateOrUpdateBookRequest{Title: "Dune Messiah", Author: "Frank Herbert"}, book.ParseIfMatch(`"3"`))
+	m.Suite.Equal(book.GetErrorResponseByCode(book.PreconditionFailed), err)
+}
+
+func (m *BookServiceTestSuite) TestUpdate_ShouldRequireIfMatchForExistingBook() {
+	m.mockRepo.EXPECT().Upsert(context.Background(), book.Book{ID: 12, Title: "Dune Messiah", Author: "Frank Herbert", Authors: []book.BookAuthor{}}).Return(false, 0, book.ErrVersionConflict)
+	m.mockRepo.EXPECT().InTrash(context.Background(), 12).Return(false, nil)
+	_, _, err := m.bookService.CreateOrUpdate(context.Background(), 12, book.CreateOrUpdateBookRequest{Title: "Dune Messiah", Author: "Frank Herbert"}, nil)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.PreconditionRequired), err)
+}
+
+func (m *BookServiceTestSuite) TestUpdate_ShouldNotCreateBookWhenIfMatchIsGiven() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{}, book.ErrNotFound)
+	m.mockRepo.EXPECT().InTrash(context.Background(), 12).Return(false, nil)
+	_, _, err := m.bookService.CreateOrUpdate(context.Background(), 12, book.CreateOrUpdateBookRequest{Title: "Dune", Author: "Frank Herbert"}, book.ParseIfMatch("*"))
+	m.Suite.Equal(book.GetErrorResponseByCode(book.PreconditionFailed), err)
+}
+
+func (m *BookServiceTestSuite) TestUpdate_ShouldReturnConflictForABookInTheTrash() {
+	m.mockRepo.EXPECT().Upsert(context.Background(), book.Book{ID: 12, Title: "Dune", Author: "Frank Herbert", Authors: []book.BookAuthor{}}).Return(false, 0, book.ErrVersionConflict)
+	m.mockRepo.EXPECT().InTrash(context.Background(), 12).Return(true, nil).Times(2)
+	_, _, err := m.bookService.CreateOrUpdate(context.Background(), 12, book.CreateOrUpdateBookRequest{Title: "Dune", Author: "Frank Herbert"}, nil)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BookInTrash), err)
+
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{}, book.ErrNotFound)
+	_, _, err = m.bookService.CreateOrUpdate(context.Background(), 12, book.CreateOrUpdateBookRequest{Title: "Dune", Author: "Frank Herbert"}, book.ParseIfMatch(`"3"`))
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BookInTrash), err)
+}
+
+func (m *BookServiceTestSuite) TestCreate_ShouldNormalizeISBNTo13Digits() {
+	m.mockRepo.EXPECT().Create(context.Background(), book.Book{
+		Title:  "Harry Potter",
+		Author: "JK Rolling",
+		ISBN:   "9780747532699",
+	}).Return(int64(12), nil)
+	bId, err := m.bookService.Create(context.Background(), book.CreateOrUpdateBookRequest{
+		Title:  "Harry Potter",
+		Author: "JK Rolling",
+		ISBN:   "0-7475-3269-9",
+	})
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(12), bId)
+}
+
+func (m *BookServiceTestSuite) TestCreate_ShouldReturnConflictWhenISBNAlreadyExists() {
+	m.mockRepo.EXPECT().Create(context.Background(), book.Book{
+		Title:  "Harry Potter",
+		Author: "JK Rolling",
+		ISBN:   "9780747532699",
+	}).Return(int64(0), book.ErrDuplicateISBN)
+	_, err := m.bookService.Create(context.Background(), book.CreateOrUpdateBookRequest{
+		Title:  "Harry Potter",
+		Author: "JK Rolling",
+		ISBN:   "978-0-7475-3269-9",
+	})
+	m.Suite.Equal(book.GetErrorResponseByCode(book.IsbnAlreadyExists), err)
+}
+
+func (m *BookServiceTestSuite) TestGetByISBN_ShouldLookUpNormalizedISBN() {
+	m.mockRepo.EXPECT().GetByISBN(context.Background(), "9780747532699").Return(book.Book{ID: 12, ISBN: "9780747532699"}, nil)
+	b, err := m.bookService.GetByISBN(context.Background(), "0747532699")
+	m.Suite.Nil(err)
+	m.Suite.Equal(12, b.ID)
+}
+
+func (m *BookServiceTestSuite) TestGetByISBN_ShouldReturnNotFoundIfBookWithGivenISBNDoesNotExist() {
+	m.mockRepo.EXPECT().GetByISBN(context.Background(), "9780747532699").Return(book.Book{}, book.ErrNotFound)
+	_, err := m.bookService.GetByISBN(context.Background(), "9780747532699")
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BookNotFound), err)
+}
+
+func (m *BookServiceTestSuite) TestGetByISBN_ShouldReturnBadRequestForInvalidISBN() {
+	_, err := m.bookService.GetByISBN(context.Background(), "9780747532690")
+	m.Suite.Equal(book.BadRequest, err.ErrorCode)
+}
+
+func (m *BookServiceTestSuite) TestCreate_ShouldLinkAuthorsInRequestOrder() {
+	m.mockRepo.EXPECT().Create(context.Background(), book.Book{
+		Title:  "Good Omens",
+		Author: "Pratchett & Gaiman",
+		Authors: []book.BookAuthor{
+			{AuthorID: 7, Role: "author", Position: 1},
+			{AuthorID: 8, Role: "author", Position: 2},
+		},
+	}).Return(int64(12), nil)
+	_, err := m.bookService.Create(context.Background(), book.CreateOrUpdateBookRequest{
+		Title:   "Good Omens",
+		Author:  "Pratchett & Gaiman",
+		Authors: []book.BookAuthorRequest{{AuthorID: 7}, {AuthorID: 8, Role: "author"}},
+	})
+	m.Suite.Nil(err)
+}
+
+func (m *BookServiceTestSuite) TestCreate_ShouldReturnBadRequestWhenAuthorIsListedTwiceWithSameRole() {
+	_, err := m.bookService.Create(context.Background(), book.CreateOrUpdateBookRequest{
+		Title:   "Good Omens",
+		Author:  "Pratchett & Gaiman",
+		Authors: []book.BookAuthorRequest{{AuthorID: 7}, {AuthorID: 7, Role: "author"}},
+	})
+	m.Suite.Equal(book.BadRequest, err.ErrorCode)
+}
+
+func (m *BookServiceTestSuite) TestCreate_ShouldLeaveTheBylineToLinkedAuthors() {
+	m.mockRepo.EXPECT().Create(context.Background(), book.Book{
+		Title:   "Good Omens",
+		Authors: []book.BookAuthor{{AuthorID: 7, Role: "author", Position: 1}},
+	}).Return(int64(12), nil)
+	id, err := m.bookService.Create(context.Background(), book.CreateOrUpdateBookRequest{
+		Title:   "Good Omens",
+		Authors: []book.BookAuthorRequest{{AuthorID: 7}},
+	})
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(12), id)
+
+	_, err = m.bookService.Create(context.Background(), book.CreateOrUpdateBookRequest{Title: "Good Omens", Authors: []book.BookAuthorRequest{}})
+	m.Suite.Equal(book.GetErrorResponse(book.BadRequest, "author is required unless authors are linked", http.StatusBadRequest), err)
+}
+
+func (m *BookServiceTestSuite) TestCreate_ShouldReturnBadRequestWhenAuthorDoesNotExist() {
+	m.mockRepo.EXPECT().Create(context.Background(), gomock.Any()).Return(int64(0), book.ErrAuthorNotFound)
+	_, err := m.bookService.Create(context.Background(), book.CreateOrUpdateBookRequest{
+		Title:   "Good Omens",
+		Author:  "Pratchett & Gaiman",
+		Authors: []book.BookAuthorRequest{{AuthorID: 99}},
+	})
+	m.Suite.Equal(book.BadRequest, err.ErrorCode)
+	m.Suite.Equal("one or more authors do not exist", err.ErrorMessage)
+}
+
+func (m *BookServiceTestSuite) TestUpdate_ShouldClearFieldsTheRequestLeavesOut() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(goodOmens(), nil)
+	m.mockRepo.EXPECT().Upsert(context.Background(), book.Book{
+		ID:      12,
+		Title:   "Good Omens 2",
+		Author:  "Pratchett & Gaiman",
+		Authors: []book.BookAuthor{},
+		Version: 1,
+	}).Return(false, 2, nil)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12, Version: 2}, nil)
+	_, _, err := m.bookService.CreateOrUpdate(context.Background(), 12, book.CreateOrUpdateBookRequest{
+		Title:  "Good Omens 2",
+		Author: "Pratchett & Gaiman",
+	}, book.ParseIfMatch(`"1"`))
+	m.Suite.Nil(err)
+}
+
+func goodOmens() book.Book {
+	return book.Book{
+		ID:          12,
+		Title:       "Good Omens",
+		Author:      "Pratchett & Gaiman",
+		Description: "The apocalypse, nearly",
+		ISBN:        "9780060853983",
+		Genre:       "fantasy",
+		Authors:     []book.BookAuthor{{AuthorID: 7, Name: "Terry Pratchett", Role: "author", Position: 1}},
+		Version:     1,
+	}
+}
+
+func (m *BookServiceTestSuite) TestPatch_ShouldApplyMergePatch() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(goodOmens(), nil)
+	m.mockRepo.EXPECT().Update(context.Background(), book.Book{
+		ID:      12,
+		Title:   "Good Omens",
+		Author:  "Pratchett & Gaiman",
+		ISBN:    "9780060853983",
+		Genre:   "fantasy",
+		Language: "en",
+		Authors: []book.BookAuthor{{AuthorID: 7, Role: "author", Position: 1}},
+		Version: 1,
+	}).Return(2, nil)
+	version, err := m.bookService.Patch(context.Background(), 12, book.MergePatchContentType,
+		[]byte(`{"description": null, "language": "EN"}`), book.ParseIfMatch(`"1"`))
+	m.Suite.Nil(err)
+	m.Suite.Equal(2, version)
+}
+
+func (m *BookServiceTestSuite) TestPatch_ShouldApplyJSONPatch() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(goodOmens(), nil)
+	m.mockRepo.EXPECT().Update(context.Background(), book.Book{
+		ID:          12,
+		Title:       "Good Omens",
+		Author:      "Pratchett & Gaiman",
+		Description: "The apocalypse, nearly",
+		ISBN:        "9780060853983",
+		Genre:       "fantasy",
+		Authors: []book.BookAuthor{
+			{AuthorID: 8, Role: "author", Position: 1},
+			{AuthorID: 7, Role: "author", Position: 2},
+		},
+		Version: 1,
+	}).Return(2, nil)
+	_, err := m.bookService.Patch(context.Background(), 12, book.JSONPatchContentType, []byte(`[
+		{"op": "test", "path": "/authors/0/authorId", "value": 7},
+		{"op": "add", "path": "/authors/0", "value": {"authorId": 8, "role": "author"}}
+	]`), book.ParseIfMatch(`"1"`))
+	m.Suite.Nil(err)
+}
+
+func (m *BookServiceTestSuite) TestPatch_ShouldReturnConflictWhenTestFails() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(goodOmens(), nil)
+	_, err := m.bookService.Patch(context.Background(), 12, book.JSONPatchContentType,
+		[]byte(`[{"op": "test", "path": "/title", "value": "Bad Omens"}]`), book.ParseIfMatch(`"1"`))
+	m.Suite.Equal(book.PatchTestFailed, err.ErrorCode)
+	m.Suite.Equal(http.StatusConflict, err.HttpStatusCode)
+}
+
+func (m *BookServiceTestSuite) TestPatch_ShouldValidateThePatchedBook() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(goodOmens(), nil).Times(3)
+	_, err := m.bookService.Patch(context.Background(), 12, book.MergePatchContentType,
+		[]byte(`{"title": null}`), book.ParseIfMatch(`"1"`))
+	m.Suite.Equal("Title failed on 'required'", err.ErrorMessage)
+
+	_, err = m.bookService.Patch(context.Background(), 12, book.JSONPatchContentType,
+		[]byte(`[{"op": "replace", "path": "/isbn", "value": "978-0-06-085398-4"}]`), book.ParseIfMatch(`"1"`))
+	m.Suite.Equal("ISBN failed on 'isbn'", err.ErrorMessage)
+
+	_, err = m.bookService.Patch(context.Background(), 12, book.MergePatchContentType,
+		[]byte(`{"subtitle": "A Novel"}`), book.ParseIfMatch(`"1"`))
+	m.Suite.Equal(book.BadRequest, err.ErrorCode)
+}
+
+func (m *BookServiceTestSuite) TestPatch_ShouldRequireIfMatch() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(goodOmens(), nil)
+	_, err := m.bookService.Patch(context.Background(), 12, book.MergePatchContentType, []byte(`{}`), nil)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.PreconditionRequired), err)
+}
+
+func (m *BookServiceTestSuite) TestExport_ShouldReturnInternalServerErrorWhenTheExportFails() {
+	f := book.BookFilter{Genre: "fantasy"}
+	m.mockRepo.EXPECT().Export(context.Background(), f, nil, gomock.Any()).Return(errors.New("connection reset"))
+
+	err := m.bookService.Export(context.Background(), f, nil, func([]book.Book) error { return nil })
+	m.Suite.Equal(book.GetErrorResponseByCode(book.InternalServerError), err)
+}
+
+func (m *BookServiceTestSuite) TestSearch_ShouldPassParsedQueryToRepository() {
+	m.mockRepo.EXPECT().Search(context.Background(), "(half <-> blood) & pott:*", book.BookFilter{}, 10, 0).
+		Return([]book.SearchResult{{Book: book.Book{ID: 12}, Rank: 0.5}}, 1, nil)
+	results, total, err := m.bookService.Search(context.Background(), `"half blood" pott*`, book.BookFilter{}, 10, 0)
+	m.Suite.Nil(err)
+	m.Suite.Equal(1, total)
+	m.Suite.Equal(12, results[0].ID)
+}
+
+func (m *BookServiceTestSuite) TestSearch_ShouldReturnBadRequestForQueryWithoutWords() {
+	_, _, err := m.bookService.Search(context.Background(), "  *  ", book.BookFilter{}, 10, 0)
+	m.Suite.Equal(book.BadRequest, err.ErrorCode)
+}
+
+func (m *BookServiceTestSuite) TestList_ShouldReturnBadRequestForInvalidSort() {
+	sort := []book.SortField{{Field: "isbn"}}
+	m.mockRepo.EXPECT().List(context.Background(), book.BookFilter{}, sort, 10, 0).Return(nil, 0, book.ErrInvalidSort)
+	_, _, err := m.bookService.List(context.Background(), book.BookFilter{}, sort, 10, 0)
+	m.Suite.Equal(book.BadRequest, err.ErrorCode)
+}
+
+func (m *BookServiceTestSuite) TestScroll_ShouldReturnCursorAfterLastBookOfFullPage() {
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	sort := []book.SortField{{Field: "title"}}
+	m.mockRepo.EXPECT().ListAfter(context.Background(), book.BookFilter{}, sort, []string(nil), 3).Return([]book.Book{
+		{ID: 12, Title: "Dune", CreatedAt: created},
+		{ID: 13, Title: "Emma", CreatedAt: created},
+		{ID: 14, Title: "Ulysses", CreatedAt: created},
+	}, nil)
+	page, err := m.bookService.Scroll(context.Background(), book.BookFilter{}, sort, "", 2, false)
+	m.Suite.Nil(err)
+	m.Suite.Len(page.Books, 2)
+	m.Suite.Nil(page.Total)
+	cur, decodeErr := m.cursors.Decode(page.NextCursor)
+	m.Suite.Nil(decodeErr)
+	m.Suite.Equal("title", cur.Sort)
+	m.Suite.Equal([]string{"Emma", "13"}, cur.Keys)
+
+	m.mockRepo.EXPECT().ListAfter(context.Background(), book.BookFilter{}, sort, []string{"Emma", "13"}, 3).Return([]book.Book{
+		{ID: 14, Title: "Ulysses", CreatedAt: created},
+	}, nil)
+	m.mockRepo.EXPECT().Count(context.Background(), book.BookFilter{}).Return(3, nil)
+	page, err = m.bookService.Scroll(context.Background(), book.BookFilter{}, nil, page.NextCursor, 2, true)
+	m.Suite.Nil(err)
+	m.Suite.Len(page.Books, 1)
+	m.Suite.Empty(page.NextCursor)
+	m.Suite.Equal(3, *page.Total)
+}
+
+func (m *BookServiceTestSuite) TestScroll_ShouldRejectCursorOfDifferentQuery() {
+	cursor := m.cursors.Encode(book.Cursor{Sort: "title", Keys: []string{"Emma", "13"}})
+	_, err := m.bookService.Scroll(context.Background(), book.BookFilter{Title: "potter"}, nil, cursor, 2, false)
+	m.Suite.Equal(book.GetErrorResponse(book.BadRequest, "cursor does not match the filters and sort of the query", http.StatusBadRequest), err)
+
+	_, err = m.bookService.Scroll(context.Background(), book.BookFilter{}, []book.SortField{{Field: "id"}}, cursor, 2, false)
+	m.Suite.Equal(book.GetErrorResponse(book.BadRequest, "cursor does not match the filters and sort of the query", http.StatusBadRequest), err)
+}
+
+func (m *BookServiceTestSuite) TestScroll_ShouldRejectForgedCursor() {
+	cursor := book.NewCursorCodec([]byte("other")).Encode(book.Cursor{Sort: "title", Keys: []string{"Emma", "13"}})
+	_, err := m.bookService.Scroll(context.Background(), book.BookFilter{}, nil, cursor, 2, false)
+	m.Suite.Equal(book.GetErrorResponse(book.BadRequest, "invalid cursor", http.StatusBadRequest), err)
+}
+
+func (m *BookServiceTestSuite) TestSuggest_ShouldCollapseWhitespaceOfQuery() {
+	m.mockRepo.EXPECT().Suggest(gomock.Any(), "hary poter", 5).Return([]book.Suggestion{{ID: 12, Title: "Harry Potter", Score: 0.82}}, nil)
+	s, err := m.bookService.Suggest(context.Background(), "  hary   poter ", 5)
+	m.Suite.Nil(err)
+	m.Suite.Equal([]book.Suggestion{{ID: 12, Title: "Harry Potter", Score: 0.82}}, s)
+}
+
+func (m *BookServiceTestSuite) TestSuggest_ShouldReturnBadRequestForShortQuery() {
+	_, err := m.bookService.Suggest(context.Background(), " h  a ", 5)
+	m.Suite.Equal(book.GetErrorResponse(book.BadRequest, "q must be at least 3 characters", http.StatusBadRequest), err)
+}
+
+func (m *BookServiceTestSuite) TestSuggest_ShouldReturnTimeoutWhenLookupOverrunsItsBudget() {
+	m.mockRepo.EXPECT().Suggest(gomock.Any(), "harry", 5).DoAndReturn(func(ctx context.Context, q string, limit int) ([]book.Suggestion, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	_, err := m.bookService.Suggest(context.Background(), "harry", 5)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.SuggestTimeout), err)
+}
+
+func (m *BookServiceTestSuite) TestFacets_ShouldCountSearchMatchesWhenQueryIsGiven() {
+	f := book.BookFilter{Genre: "fantasy"}
+	m.mockRepo.EXPECT().Facets(context.Background(), f, "pott:*").Return(book.Facets{Genres: []book.FacetValue{{Value: "fantasy", Count: 2}}}, nil)
+	facets, err := m.bookService.Facets(context.Background(), f, "pott*")
+	m.Suite.Nil(err)
+	m.Suite.Equal(book.Facets{Genres: []book.FacetValue{{Value: "fantasy", Count: 2}}}, facets)
+
+	m.mockRepo.EXPECT().Facets(context.Background(), f, "").Return(book.Facets{}, errors.New("unable to connect"))
+	_, err = m.bookService.Facets(context.Background(), f, "")
+	m.Suite.Equal(book.GetErrorResponseByCode(book.InternalServerError), err)
+}
+
+func (m *BookServiceTestSuite) TestCreate_ShouldNormalizeCatalogFields() {
+	m.mockRepo.EXPECT().Create(context.Background(), book.Book{
+		Title:         "Harry Potter",
+		Author:        "JK Rolling",
+		Genre:         "fantasy",
+		Language:      "en",
+		PublishedYear: 1998,
+	}).Return(int64(12), nil)
+	_, err := m.bookService.Create(context.Background(), book.CreateOrUpdateBookRequest{
+		Title:         "Harry Potter",
+		Author:        "JK Rolling",
+		Genre:         " fantasy ",
+		Language:      "EN",
+		PublishedYear: 1998,
+	})
+	m.Suite.Nil(err)
+}
+
+func (m *BookServiceTestSuite) TestBatch_ShouldAbortAnAtomicBatchWhenAnItemIsRejected() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{}, book.ErrNotFound)
+	results := m.bookService.Batch(context.Background(), []book.BatchOperation{
+		{Action: book.BatchCreate, Book: book.CreateOrUpdateBookRequest{Title: "Dune", Author: "Frank Herbert"}},
+		{Action: book.BatchDelete, ID: 12, IfMatch: book.ParseIfMatch(`"1"`)},
+	}, true)
+	m.Suite.Equal([]book.BatchResult{
+		{Status: http.StatusFailedDependency, Err: book.GetErrorResponseByCode(book.BatchAborted)},
+		{Status: http.StatusNotFound, Err: book.GetErrorResponseByCode(book.BookNotFound)},
+	}, results)
+}
+
+func (m *BookServiceTestSuite) TestBatch_ShouldKeepTheWritesThatSucceedInBestEffortMode() {
+	rejected := book.GetErrorResponse(book.BadRequest, "Title failed on 'required'", http.StatusBadRequest)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12, Title: "Dune", Version: 3}, nil)
+	m.mockRepo.EXPECT().ApplyBatch(context.Background(), []book.BatchWrite{
+		{Action: book.BatchCreate, Book: book.Book{Title: "Dune", Author: "Frank Herbert"}},
+		{Action: book.BatchUpdate, Book: book.Book{ID: 12, Title: "Dune Messiah", Author: "Frank Herbert", Authors: []book.BookAuthor{}, Version: 3}},
+	}, false).Return([]book.BatchOutcome{{ID: 13, Version: 1}, {ID: 12, Err: book.ErrDuplicateISBN}}, nil)
+	results := m.bookService.Batch(context.Background(), []book.BatchOperation{
+		{Action: book.BatchCreate, Book: book.CreateOrUpdateBookRequest{Title: "Dune", Author: "Frank Herbert"}},
+		{Action: book.BatchCreate, Rejected: rejected},
+		{Action: book.BatchUpdate, ID: 12, IfMatch: book.ParseIfMatch(`"3"`), Book: book.CreateOrUpdateBookRequest{Title: "Dune Messiah", Author: "Frank Herbert"}},
+	}, false)
+	m.Suite.Equal([]book.BatchResult{
+		{Status: http.StatusCreated, ID: 13, Version: 1},
+		{Status: http.StatusBadRequest, Err: rejected},
+		{Status: http.StatusConflict, Err: book.GetErrorResponseByCode(book.IsbnAlreadyExists)},
+	}, results)
+}
+
+func (m *BookServiceTestSuite) TestBatch_ShouldAbortTheAppliedWritesWhenAnAtomicBatchFails() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 12).Return(book.Book{ID: 12, Version: 3}, nil)
+	m.mockRepo.EXPECT().ApplyBatch(context.Background(), gomock.Len(2), true).
+		Return([]book.BatchOutcome{{ID: 13, Version: 1}, {ID: 12, Err: book.ErrVersionConflict}}, nil)
+	results := m.bookService.Batch(context.Background(), []book.BatchOperation{
+		{Action: book.BatchCreate, Book: book.CreateOrUpdateBookRequest{Title: "Dune", Author: "Frank Herbert"}},
+		{Action: book.BatchDelete, ID: 12, IfMatch: book.ParseIfMatch(`"3"`)},
+	}, true)
+	m.Suite.Equal(http.StatusFailedDependency, results[0].Status)
+	m.Suite.Equal(http.StatusPreconditionFailed, results[1].Status)
+}
@@ -0,0 +1,32 @@
+package book
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MinSuggestQueryLength is the shortest input worth suggesting for; a
+	// trigram index cannot narrow down anything shorter.
+	MinSuggestQueryLength = 3
+	maxSuggestLimit       = 20
+	// suggestTimeout bounds a suggestion lookup, which runs on every keystroke.
+	suggestTimeout = 300 * time.Millisecond
+)
+
+// Suggestion is a book whose title or author resembles the typed input. Score
+// is the trigram word similarity of the better matching of the two, from 0 to 1.
+type Suggestion struct {
+	ID     int
+	Title  string
+	Author string
+	Score  float64
+}
+
+// normalizeSuggestQuery collapses the whitespace of the input and reports
+// whether it has enough characters, not counting spaces, to look up.
+func normalizeSuggestQuery(q string) (string, bool) {
+	words := strings.Fields(q)
+	return strings.Join(words, " "), utf8.RuneCountInString(strings.Join(words, "")) >= MinSuggestQueryLength
+}
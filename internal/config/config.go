@@ -2,7 +2,9 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -13,6 +15,15 @@ type Config interface {
 	GetHost() string
 	GetPort() string
 	GetName() string
+	GetServerAddress() string
+	GetReadTimeout() time.Duration
+	GetWriteTimeout() time.Duration
+	GetIdleTimeout() time.Duration
+	GetMaxHeaderBytes() int
+	GetShutdownTimeout() time.Duration
+	GetFines() FinesConfig
+	GetCursorSecret() string
+	GetTrash() TrashConfig
 }
 
 type DBConfig struct {
@@ -23,8 +34,99 @@ type DBConfig struct {
 	Name     string `json:"name" validate:"required"`
 }
 
+// ServerConfig holds the HTTP server settings. Every field is optional and
+// falls back to the value in defaultServerConfig.
+type ServerConfig struct {
+	Address         string   `json:"address" validate:"required"`
+	ReadTimeout     Duration `json:"readTimeout" validate:"gte=0"`
+	WriteTimeout    Duration `json:"writeTimeout" validate:"gte=0"`
+	IdleTimeout     Duration `json:"idleTimeout" validate:"gte=0"`
+	MaxHeaderBytes  int      `json:"maxHeaderBytes" validate:"gte=0"`
+	ShutdownTimeout Duration `json:"shutdownTimeout" validate:"gte=0"`
+}
+
+var defaultServerConfig = ServerConfig{
+	Address:         ":8080",
+	ReadTimeout:     Duration(15 * time.Second),
+	WriteTimeout:    Duration(15 * time.Second),
+	IdleTimeout:     Duration(60 * time.Second),
+	MaxHeaderBytes:  1 << 20,
+	ShutdownTimeout: Duration(30 * time.Second),
+}
+
+// FinesConfig holds the overdue fine rules. Amounts are in cents. Loans
+// returned at most GraceDays late are not fined; later returns are charged
+// for every day past the due date. Members owing more than
+// BlockThresholdCents cannot check out.
+type FinesConfig struct {
+	GraceDays           int                 `json:"graceDays" validate:"gte=0"`
+	BlockThresholdCents int64               `json:"blockThresholdCents" validate:"gte=0"`
+	Rates               map[string]FineRate `json:"rates" validate:"dive"`
+}
+
+// FineRate is the fine schedule of one membership type. A MaxPerLoanCents of
+// zero leaves the fine of a loan uncapped.
+type FineRate struct {
+	DailyRateCents  int64 `json:"dailyRateCents" validate:"gte=0"`
+	MaxPerLoanCents int64 `json:"maxPerLoanCents" validate:"gte=0"`
+}
+
+// defaultFinesConfig returns a fresh copy so decoding the rates of a config
+// file into it never touches shared state.
+func defaultFinesConfig() FinesConfig {
+	return FinesConfig{
+		GraceDays:           1,
+		BlockThresholdCents: 1000,
+		Rates: map[string]FineRate{
+			"standard": {DailyRateCents: 25, MaxPerLoanCents: 1000},
+			"student":  {DailyRateCents: 10, MaxPerLoanCents: 500},
+			"senior":   {DailyRateCents: 10, MaxPerLoanCents: 500},
+			"staff":    {DailyRateCents: 0, MaxPerLoanCents: 0},
+		},
+	}
+}
+
+// PaginationConfig names the environment variable holding the key that signs
+// list cursors. Every replica must share the key; when it is not set the
+// server picks a random one at startup.
+type PaginationConfig struct {
+	CursorSecret string `json:"cursorSecret"`
+}
+
+// TrashConfig holds how long deleted books stay restorable before the purge
+// job, which runs every PurgeInterval, removes them for good.
+type TrashConfig struct {
+	Retention     Duration `json:"retention" validate:"gt=0"`
+	PurgeInterval Duration `json:"purgeInterval" validate:"gt=0"`
+}
+
+var defaultTrashConfig = TrashConfig{
+	Retention:     Duration(30 * 24 * time.Hour),
+	PurgeInterval: Duration(time.Hour),
+}
+
+// Duration is a time.Duration read from JSON strings such as "15s" or "1m30s".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"15s\": %w", err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
 type config struct {
-	DB DBConfig `json:"db" validate:"required"`
+	DB         DBConfig         `json:"db" validate:"required"`
+	Server     ServerConfig     `json:"server"`
+	Fines      FinesConfig      `json:"fines"`
+	Pagination PaginationConfig `json:"pagination"`
+	Trash      TrashConfig      `json:"trash"`
 }
 
 func (c config) GetUser() string {
@@ -42,6 +144,33 @@ func (c config) GetPort() string {
 func (c config) GetName() string {
 	return c.DB.Name
 }
+func (c config) GetServerAddress() string {
+	return c.Server.Address
+}
+func (c config) GetReadTimeout() time.Duration {
+	return time.Duration(c.Server.ReadTimeout)
+}
+func (c config) GetWriteTimeout() time.Duration {
+	return time.Duration(c.Server.WriteTimeout)
+}
+func (c config) GetIdleTimeout() time.Duration {
+	return time.Duration(c.Server.IdleTimeout)
+}
+func (c config) GetMaxHeaderBytes() int {
+	return c.Server.MaxHeaderBytes
+}
+func (c config) GetShutdownTimeout() time.Duration {
+	return time.Duration(c.Server.ShutdownTimeout)
+}
+func (c config) GetFines() FinesConfig {
+	return c.Fines
+}
+func (c config) GetCursorSecret() string {
+	return c.Pagination.CursorSecret
+}
+func (c config) GetTrash() TrashConfig {
+	return c.Trash
+}
 
 func LoadConfig(path string) (Config, error) {
 	f, err := os.Open(path)
@@ -50,7 +179,7 @@ func LoadConfig(path string) (Config, error) {
 	}
 	defer f.Close()
 
-	var cfg config
+	cfg := config{Server: defaultServerConfig, Fines: defaultFinesConfig(), Trash: defaultTrashConfig}
 	if err := json.NewDecoder(f).Decode(&cfg); err != nil {
 		return nil, err
 	}
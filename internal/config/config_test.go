@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,3 +61,102 @@ func TestLoadConfig_MissingField(t *testing.T) {
 	_, err := config.LoadConfig(path)
 	require.Contains(t,err.Error(),"Error:Field validation for 'User'")
 }
+
+func TestLoadConfig_ShouldApplyServerDefaultsWhenSectionIsAbsent(t *testing.T) {
+	path := writeTempConfig(t, `{"db": {"host": "h", "port": "p", "user": "u", "password": "pw", "name": "n"}}`)
+	cfg, err := config.LoadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, ":8080", cfg.GetServerAddress())
+	require.Equal(t, 15*time.Second, cfg.GetReadTimeout())
+	require.Equal(t, 15*time.Second, cfg.GetWriteTimeout())
+	require.Equal(t, 60*time.Second, cfg.GetIdleTimeout())
+	require.Equal(t, 1<<20, cfg.GetMaxHeaderBytes())
+	require.Equal(t, 30*time.Second, cfg.GetShutdownTimeout())
+}
+
+func TestLoadConfig_ShouldOverrideServerDefaults(t *testing.T) {
+	path := writeTempConfig(t, `{
+  "db": {"host": "h", "port": "p", "user": "u", "password": "pw", "name": "n"},
+  "server": {"address": "127.0.0.1:9090", "readTimeout": "5s", "shutdownTimeout": "1m", "maxHeaderBytes": 4096}
+}`)
+	cfg, err := config.LoadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:9090", cfg.GetServerAddress())
+	require.Equal(t, 5*time.Second, cfg.GetReadTimeout())
+	require.Equal(t, 15*time.Second, cfg.GetWriteTimeout())
+	require.Equal(t, 4096, cfg.GetMaxHeaderBytes())
+	require.Equal(t, time.Minute, cfg.GetShutdownTimeout())
+}
+
+func TestLoadConfig_InvalidServerDuration(t *testing.T) {
+	path := writeTempConfig(t, `{
+  "db": {"host": "h", "port": "p", "user": "u", "password": "pw", "name": "n"},
+  "server": {"readTimeout": "soon"}
+}`)
+	_, err := config.LoadConfig(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid duration")
+}
+
+func TestLoadConfig_ShouldApplyFineDefaultsWhenSectionIsAbsent(t *testing.T) {
+	path := writeTempConfig(t, `{"db": {"host": "h", "port": "p", "user": "u", "password": "pw", "name": "n"}}`)
+	cfg, err := config.LoadConfig(path)
+	require.NoError(t, err)
+	fines := cfg.GetFines()
+	require.Equal(t, 1, fines.GraceDays)
+	require.Equal(t, int64(1000), fines.BlockThresholdCents)
+	require.Equal(t, config.FineRate{DailyRateCents: 25, MaxPerLoanCents: 1000}, fines.Rates["standard"])
+	require.Len(t, fines.Rates, 4)
+}
+
+func TestLoadConfig_ShouldMergeFineRatesIntoDefaults(t *testing.T) {
+	path := writeTempConfig(t, `{
+  "db": {"host": "h", "port": "p", "user": "u", "password": "pw", "name": "n"},
+  "fines": {"graceDays": 0, "rates": {"student": {"dailyRateCents": 5, "maxPerLoanCents": 200}}}
+}`)
+	cfg, err := config.LoadConfig(path)
+	require.NoError(t, err)
+	fines := cfg.GetFines()
+	require.Equal(t, 0, fines.GraceDays)
+	require.Equal(t, int64(1000), fines.BlockThresholdCents)
+	require.Equal(t, config.FineRate{DailyRateCents: 5, MaxPerLoanCents: 200}, fines.Rates["student"])
+	require.Equal(t, config.FineRate{DailyRateCents: 25, MaxPerLoanCents: 1000}, fines.Rates["standard"])
+}
+
+func TestLoadConfig_NegativeFineRate(t *testing.T) {
+	path := writeTempConfig(t, `{
+  "db": {"host": "h", "port": "p", "user": "u", "password": "pw", "name": "n"},
+  "fines": {"rates": {"standard": {"dailyRateCents": -1}}}
+}`)
+	_, err := config.LoadConfig(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "DailyRateCents")
+}
+
+func TestLoadConfig_ShouldReadCursorSecretName(t *testing.T) {
+	path := writeTempConfig(t, `{
+  "db": {"host": "H", "port": "P", "user": "U", "password": "PW", "name": "N"},
+  "pagination": {"cursorSecret": "CURSOR_SECRET"}
+}`)
+	cfg, err := config.LoadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, "CURSOR_SECRET", cfg.GetCursorSecret())
+}
+
+func TestLoadConfig_ShouldApplyTrashDefaultsWhenSectionIsAbsent(t *testing.T) {
+	path := writeTempConfig(t, `{"db": {"host": "h", "port": "p", "user": "u", "password": "pw", "name": "n"}}`)
+	cfg, err := config.LoadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, config.Duration(30*24*time.Hour), cfg.GetTrash().Retention)
+	require.Equal(t, config.Duration(time.Hour), cfg.GetTrash().PurgeInterval)
+}
+
+func TestLoadConfig_ShouldRejectZeroTrashRetention(t *testing.T) {
+	path := writeTempConfig(t, `{
+  "db": {"host": "h", "port": "p", "user": "u", "password": "pw", "name": "n"},
+  "trash": {"retention": "0s"}
+}`)
+	_, err := config.LoadConfig(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Retention")
+}
@@ -0,0 +1,30 @@
+package fine
+
+type PaymentRequest struct {
+	AmountCents int64  `json:"amountCents" validate:"required,gt=0" example:"250"`
+	Note        string `json:"note" validate:"omitempty,max=500" example:"Paid at the front desk"`
+}
+
+type WaiverRequest struct {
+	AmountCents int64  `json:"amountCents" validate:"required,gt=0" example:"100"`
+	Note        string `json:"note" validate:"required,min=1,max=500" example:"Book was returned late due to hospital stay"`
+}
+
+type EntryResponse struct {
+	ID           int    `json:"id" example:"1"`
+	Kind         string `json:"kind" example:"charge"`
+	AmountCents  int64  `json:"amountCents" example:"250"`
+	LoanID       int    `json:"loanId,omitempty" example:"9"`
+	Note         string `json:"note" example:"overdue fine for loan 9"`
+	CreatedAt    string `json:"createdAt" example:"2026-10-17T09:30:00Z"`
+	BalanceCents int64  `json:"balanceCents" example:"250"`
+}
+
+type LedgerResponse struct {
+	BalanceCents int64           `json:"balanceCents" example:"250"`
+	Page         int             `json:"page" example:"1"`
+	Limit        int             `json:"limit" example:"10"`
+	Total        int             `json:"total" example:"3"`
+	TotalPages   int             `json:"totalPages" example:"1"`
+	Data         []EntryResponse `json:"data"`
+}
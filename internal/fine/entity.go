@@ -0,0 +1,24 @@
+package fine
+
+import "time"
+
+// Entry is one line of a member's ledger. Amounts are in cents and always
+// positive; the kind decides whether an entry adds to the balance or pays it
+// off.
+type Entry struct {
+	ID          int       `sql:"id"`
+	MemberID    int       `sql:"member_id"`
+	LoanID      *int      `sql:"loan_id"`
+	Kind        string    `sql:"kind"`
+	AmountCents int64     `sql:"amount_cents"`
+	Note        string    `sql:"note"`
+	CreatedAt   time.Time `sql:"created_at"`
+	// BalanceCents is what the member owed right after this entry.
+	BalanceCents int64
+}
+
+const (
+	KindCharge  = "charge"
+	KindPayment = "payment"
+	KindWaiver  = "waiver"
+)
@@ -0,0 +1,28 @@
+package fine
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/member"
+	"net/http"
+)
+
+const (
+	AmountExceedsBalance book.ErrorCode = "AMOUNT_EXCEEDS_BALANCE"
+)
+
+var errorResponseMap = map[book.ErrorCode]*book.ErrorResponse{
+	AmountExceedsBalance: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      AmountExceedsBalance,
+		ErrorMessage:   "amount exceeds the outstanding balance",
+	},
+}
+
+// GetErrorResponseByCode resolves fine specific codes and falls back to the
+// member codes and the codes shared with the book package.
+func GetErrorResponseByCode(errCode book.ErrorCode) *book.ErrorResponse {
+	if errResponse, ok := errorResponseMap[errCode]; ok {
+		return errResponse
+	}
+	return member.GetErrorResponseByCode(errCode)
+}
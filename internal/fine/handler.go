@@ -0,0 +1,198 @@
+package fine
+
+import (
+	"book-store/internal/book"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+var maxLimit int = 100
+
+type FineHandler struct {
+	svc FineService
+	val validator.Validate
+}
+
+func NewFineHandler(s FineService) *FineHandler {
+	return &FineHandler{svc: s, val: *validator.New()}
+}
+
+// Ledger godoc
+// @Summary      Get a member's ledger
+// @Description  Returns the outstanding balance and a page of fines, payments and waivers, newest first. Amounts are in cents
+// @Tags         fines
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int   true   "Member ID"
+// @Param        page   query     int   false  "Page number (default 1)"    default(1)
+// @Param        limit  query     int   false  "Page size (1–100, default 10)" default(10)
+// @Success      200    {object}  LedgerResponse
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Router       /members/{id}/ledger [get]
+func (h *FineHandler) Ledger(w http.ResponseWriter, r *http.Request) {
+	memberID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	page, limit := 1, 10
+	if v := q.Get("page"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil {
+			logrus.Error("invalid page number provided ", v)
+			sendError(w, *GetErrorResponseByCode(book.BadRequest))
+			return
+		}
+		page = max(p, 1)
+	}
+	if v := q.Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil {
+			logrus.Error("invalid limit number provided ", v)
+			sendError(w, *GetErrorResponseByCode(book.BadRequest))
+			return
+		}
+		if l >= 1 {
+			limit = min(l, maxLimit)
+		}
+	}
+	offset := (page - 1) * limit
+
+	entries, totalCount, owed, err := h.svc.Ledger(r.Context(), memberID, limit, offset)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	out := make([]EntryResponse, len(entries))
+	for i, e := range entries {
+		out[i] = toEntryResponse(e)
+	}
+	json.NewEncoder(w).Encode(LedgerResponse{
+		BalanceCents: owed,
+		Page:         page,
+		Limit:        limit,
+		Total:        totalCount,
+		TotalPages:   int(math.Ceil(float64(totalCount) / float64(limit))),
+		Data:         out,
+	})
+}
+
+// Pay godoc
+// @Summary      Record a payment
+// @Description  Record a payment towards the member's outstanding fines. The amount, in cents, may not exceed the balance
+// @Tags         fines
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int             true  "Member ID"
+// @Param        payment  body      PaymentRequest  true  "Amount paid"
+// @Success      201    {object}  EntryResponse
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Failure      409    {object}  book.ErrorResponse
+// @Router       /members/{id}/payments [post]
+func (h *FineHandler) Pay(w http.ResponseWriter, r *http.Request) {
+	memberID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req PaymentRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+	e, err := h.svc.Pay(r.Context(), memberID, req)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toEntryResponse(e))
+}
+
+// Waive godoc
+// @Summary      Waive fines
+// @Description  Forgive part or all of the member's outstanding fines. The amount, in cents, may not exceed the balance and a reason is required
+// @Tags         fines
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int            true  "Member ID"
+// @Param        waiver  body      WaiverRequest  true  "Amount waived and reason"
+// @Success      201    {object}  EntryResponse
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Failure      409    {object}  book.ErrorResponse
+// @Router       /members/{id}/waivers [post]
+func (h *FineHandler) Waive(w http.ResponseWriter, r *http.Request) {
+	memberID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req WaiverRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+	e, err := h.svc.Waive(r.Context(), memberID, req)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toEntryResponse(e))
+}
+
+func (h *FineHandler) decode(w http.ResponseWriter, r *http.Request, req any) bool {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		sendError(w, *GetErrorResponseByCode(book.BadRequest))
+		return false
+	}
+	if err := h.val.Struct(req); err != nil {
+		var errs []string
+		for _, fe := range err.(validator.ValidationErrors) {
+			errs = append(errs, fmt.Sprintf("%s failed on '%s'", fe.Field(), fe.Tag()))
+		}
+		logrus.Error("error while validating the request. error is ", errs)
+		sendError(w, *book.GetErrorResponse(book.BadRequest, strings.Join(errs, "; "), http.StatusBadRequest))
+		return false
+	}
+	return true
+}
+
+func pathID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		logrus.Error("invalid member id provided ", mux.Vars(r)["id"])
+		sendError(w, *GetErrorResponseByCode(book.BadRequest))
+		return 0, false
+	}
+	return id, true
+}
+
+func toEntryResponse(e Entry) EntryResponse {
+	resp := EntryResponse{
+		ID:           e.ID,
+		Kind:         e.Kind,
+		AmountCents:  e.AmountCents,
+		Note:         e.Note,
+		CreatedAt:    e.CreatedAt.UTC().Format(time.RFC3339),
+		BalanceCents: e.BalanceCents,
+	}
+	if e.LoanID != nil {
+		resp.LoanID = *e.LoanID
+	}
+	return resp
+}
+
+func sendError(w http.ResponseWriter, errResponse book.ErrorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errResponse.HttpStatusCode)
+	json.NewEncoder(w).Encode(errResponse)
+}
@@ -0,0 +1,108 @@
+package fine_test
+
+import (
+	"book-store/internal/fine"
+	mock_book "book-store/internal/mocks"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type FineHandlerTestSuite struct {
+	suite.Suite
+	fineHandler *fine.FineHandler
+	mockService *mock_book.MockFineService
+	ctrl        *gomock.Controller
+}
+
+func TestFineHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(FineHandlerTestSuite))
+}
+
+func (m *FineHandlerTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockService = mock_book.NewMockFineService(m.ctrl)
+	m.fineHandler = fine.NewFineHandler(m.mockService)
+}
+
+func (m *FineHandlerTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func (m *FineHandlerTestSuite) TestLedger_ShouldReturnBalanceAndPage() {
+	r, _ := http.NewRequest("GET", "/members/3/ledger?page=2&limit=1", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "3"})
+	w := httptest.NewRecorder()
+	at := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	loanID := 9
+	m.mockService.EXPECT().Ledger(r.Context(), 3, 1, 1).
+		Return([]fine.Entry{{ID: 1, MemberID: 3, LoanID: &loanID, Kind: "charge", AmountCents: 75, Note: "overdue fine for loan 9", CreatedAt: at, BalanceCents: 75}}, 2, int64(25), nil)
+
+	m.fineHandler.Ledger(w, r)
+	m.Suite.Equal(200, w.Result().StatusCode)
+
+	var body fine.LedgerResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal(fine.LedgerResponse{
+		BalanceCents: 25,
+		Page:         2,
+		Limit:        1,
+		Total:        2,
+		TotalPages:   2,
+		Data: []fine.EntryResponse{
+			{ID: 1, Kind: "charge", AmountCents: 75, LoanID: 9, Note: "overdue fine for loan 9", CreatedAt: "2026-10-17T09:30:00Z", BalanceCents: 75},
+		},
+	}, body)
+}
+
+func (m *FineHandlerTestSuite) TestPay_ShouldReturnCreatedEntry() {
+	r, _ := http.NewRequest("POST", "/members/3/payments", bytes.NewReader([]byte(`{"amountCents": 50}`)))
+	r = mux.SetURLVars(r, map[string]string{"id": "3"})
+	w := httptest.NewRecorder()
+	at := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	m.mockService.EXPECT().Pay(r.Context(), 3, fine.PaymentRequest{AmountCents: 50}).
+		Return(fine.Entry{ID: 2, MemberID: 3, Kind: "payment", AmountCents: 50, CreatedAt: at, BalanceCents: 25}, nil)
+
+	m.fineHandler.Pay(w, r)
+	m.Suite.Equal(201, w.Result().StatusCode)
+
+	var body fine.EntryResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal(fine.EntryResponse{ID: 2, Kind: "payment", AmountCents: 50, CreatedAt: "2026-10-17T09:30:00Z", BalanceCents: 25}, body)
+}
+
+func (m *FineHandlerTestSuite) TestPay_ShouldReturnBadRequestForNonPositiveAmount() {
+	r, _ := http.NewRequest("POST", "/members/3/payments", bytes.NewReader([]byte(`{"amountCents": -5}`)))
+	r = mux.SetURLVars(r, map[string]string{"id": "3"})
+	w := httptest.NewRecorder()
+
+	m.fineHandler.Pay(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+}
+
+func (m *FineHandlerTestSuite) TestWaive_ShouldRequireReason() {
+	r, _ := http.NewRequest("POST", "/members/3/waivers", bytes.NewReader([]byte(`{"amountCents": 50}`)))
+	r = mux.SetURLVars(r, map[string]string{"id": "3"})
+	w := httptest.NewRecorder()
+
+	m.fineHandler.Waive(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+}
+
+func (m *FineHandlerTestSuite) TestWaive_ShouldReturnConflictWhenAmountExceedsBalance() {
+	r, _ := http.NewRequest("POST", "/members/3/waivers", bytes.NewReader([]byte(`{"amountCents": 500, "note": "goodwill"}`)))
+	r = mux.SetURLVars(r, map[string]string{"id": "3"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Waive(r.Context(), 3, fine.WaiverRequest{AmountCents: 500, Note: "goodwill"}).
+		Return(fine.Entry{}, fine.GetErrorResponseByCode(fine.AmountExceedsBalance))
+
+	m.fineHandler.Waive(w, r)
+	m.Suite.Equal(409, w.Result().StatusCode)
+}
@@ -0,0 +1,35 @@
+package fine
+
+import (
+	"book-store/internal/config"
+	"time"
+)
+
+// Assess returns the fine in cents for a loan due on dueOn and returned at
+// returnedAt. Returns within the grace period are free; later ones are charged
+// the daily rate of the membership type for every day past the due date, up
+// to the cap of that type. Unknown types pay the standard rate.
+func Assess(c config.FinesConfig, membershipType string, dueOn, returnedAt time.Time) int64 {
+	late := int(today(returnedAt).Sub(dueOn.UTC()).Hours() / 24)
+	if late <= 0 || late <= c.GraceDays {
+		return 0
+	}
+	rate, ok := c.Rates[membershipType]
+	if !ok {
+		rate = c.Rates["standard"]
+	}
+	fine := int64(late) * rate.DailyRateCents
+	if rate.MaxPerLoanCents > 0 && fine > rate.MaxPerLoanCents {
+		fine = rate.MaxPerLoanCents
+	}
+	return fine
+}
+
+// BlocksCheckout reports whether a member owing balance cents may not borrow.
+func BlocksCheckout(c config.FinesConfig, balance int64) bool {
+	return balance > c.BlockThresholdCents
+}
+
+func today(now time.Time) time.Time {
+	return now.UTC().Truncate(24 * time.Hour)
+}
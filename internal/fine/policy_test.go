@@ -0,0 +1,49 @@
+package fine_test
+
+import (
+	"book-store/internal/config"
+	"book-store/internal/fine"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var fines = config.FinesConfig{
+	GraceDays:           2,
+	BlockThresholdCents: 500,
+	Rates: map[string]config.FineRate{
+		"standard": {DailyRateCents: 25, MaxPerLoanCents: 1000},
+		"student":  {DailyRateCents: 10, MaxPerLoanCents: 0},
+		"staff":    {DailyRateCents: 0, MaxPerLoanCents: 0},
+	},
+}
+
+func TestAssess(t *testing.T) {
+	dueOn := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name           string
+		membershipType string
+		returnedAt     time.Time
+		want           int64
+	}{
+		{"on time", "standard", time.Date(2026, 10, 10, 18, 0, 0, 0, time.UTC), 0},
+		{"early", "standard", time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC), 0},
+		{"within grace", "standard", time.Date(2026, 10, 12, 23, 59, 0, 0, time.UTC), 0},
+		{"after grace charges every late day", "standard", time.Date(2026, 10, 13, 8, 0, 0, 0, time.UTC), 75},
+		{"capped", "standard", time.Date(2026, 12, 31, 8, 0, 0, 0, time.UTC), 1000},
+		{"uncapped", "student", time.Date(2026, 12, 31, 8, 0, 0, 0, time.UTC), 820},
+		{"free", "staff", time.Date(2026, 12, 31, 8, 0, 0, 0, time.UTC), 0},
+		{"unknown type pays standard", "visitor", time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC), 100},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, fine.Assess(fines, tc.membershipType, dueOn, tc.returnedAt))
+		})
+	}
+}
+
+func TestBlocksCheckout(t *testing.T) {
+	require.False(t, fine.BlocksCheckout(fines, 0))
+	require.False(t, fine.BlocksCheckout(fines, 500))
+	require.True(t, fine.BlocksCheckout(fines, 501))
+}
@@ -0,0 +1,132 @@
+package fine
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var (
+	ErrMemberNotFound = errors.New("member not found")
+	ErrExceedsBalance = errors.New("amount exceeds balance")
+)
+
+type LedgerRepository interface {
+	// List pages through the entries of a member, newest first.
+	List(ctx context.Context, memberID, limit, offset int) ([]Entry, int, error)
+	Balance(ctx context.Context, memberID int) (int64, error)
+	// Credit records a payment or waiver, provided it does not take the
+	// balance of the member below zero.
+	Credit(ctx context.Context, memberID int, kind string, amount int64, note string) (Entry, error)
+}
+
+type sqlLedgerRepo struct {
+	db *sql.DB
+}
+
+func NewLedgerRepository(db *sql.DB) LedgerRepository {
+	return &sqlLedgerRepo{db: db}
+}
+
+// signedAmount is the effect of an entry on the balance.
+const signedAmount = `CASE WHEN kind = 'charge' THEN amount_cents ELSE -amount_cents END`
+
+func (r *sqlLedgerRepo) List(ctx context.Context, memberID, limit, offset int) ([]Entry, int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, member_id, loan_id, kind, amount_cents, note, created_at, balance, total_count
+        FROM (
+            SELECT id, member_id, loan_id, kind, amount_cents, note, created_at,
+                   SUM(`+signedAmount+`) OVER (ORDER BY id) AS balance,
+                   COUNT(*) OVER() AS total_count
+            FROM ledger_entries
+            WHERE member_id = $1
+        ) e
+        ORDER BY id DESC
+        LIMIT $2 OFFSET $3`, memberID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	entries := []Entry{}
+	var total int
+	for rows.Next() {
+		e := Entry{}
+		var loanID sql.NullInt64
+		if err := rows.Scan(&e.ID, &e.MemberID, &loanID, &e.Kind, &e.AmountCents, &e.Note, &e.CreatedAt, &e.BalanceCents, &total); err != nil {
+			return nil, 0, err
+		}
+		if loanID.Valid {
+			id := int(loanID.Int64)
+			e.LoanID = &id
+		}
+		entries = append(entries, e)
+	}
+	return entries, total, rows.Err()
+}
+
+func (r *sqlLedgerRepo) Balance(ctx context.Context, memberID int) (int64, error) {
+	return balance(ctx, r.db, memberID)
+}
+
+// Credit locks the member row so concurrent payments cannot both fit under
+// the same balance.
+func (r *sqlLedgerRepo) Credit(ctx context.Context, memberID int, kind string, amount int64, note string) (Entry, error) {
+	e := Entry{MemberID: memberID, Kind: kind, AmountCents: amount, Note: note}
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var locked int
+		err := tx.QueryRowContext(ctx, `SELECT id FROM members WHERE id = $1 FOR UPDATE`, memberID).Scan(&locked)
+		if err == sql.ErrNoRows {
+			return ErrMemberNotFound
+		}
+		if err != nil {
+			return err
+		}
+		owed, err := balance(ctx, tx, memberID)
+		if err != nil {
+			return err
+		}
+		if amount > owed {
+			return ErrExceedsBalance
+		}
+		e.BalanceCents = owed - amount
+		return tx.QueryRowContext(ctx, `
+            INSERT INTO ledger_entries (member_id, kind, amount_cents, note) VALUES ($1, $2, $3, $4)
+            RETURNING id, created_at`, memberID, kind, amount, note).Scan(&e.ID, &e.CreatedAt)
+	})
+	if err != nil {
+		return Entry{}, err
+	}
+	return e, nil
+}
+
+// Charge records a fine for a loan. It runs on the transaction that closes
+// the loan so a return and its fine are never recorded apart.
+func Charge(ctx context.Context, tx *sql.Tx, memberID, loanID int, amount int64, note string) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO ledger_entries (member_id, kind, amount_cents, loan_id, note) VALUES ($1, 'charge', $2, $3, $4)`,
+		memberID, amount, loanID, note)
+	return err
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func balance(ctx context.Context, q queryRower, memberID int) (int64, error) {
+	var owed int64
+	err := q.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(`+signedAmount+`), 0) FROM ledger_entries WHERE member_id = $1`, memberID).Scan(&owed)
+	return owed, err
+}
+
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
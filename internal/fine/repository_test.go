@@ -0,0 +1,89 @@
+package fine_test
+
+import (
+	"book-store/internal/fine"
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LedgerRepositoryTestSuite struct {
+	suite.Suite
+	ledgerRepository fine.LedgerRepository
+	sqlMock          sqlmock.Sqlmock
+	db               *sql.DB
+}
+
+func TestLedgerRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(LedgerRepositoryTestSuite))
+}
+
+func (m *LedgerRepositoryTestSuite) SetupTest() {
+	m.db, m.sqlMock, _ = sqlmock.New()
+	m.ledgerRepository = fine.NewLedgerRepository(m.db)
+}
+
+func (m *LedgerRepositoryTestSuite) TestList_ShouldReturnEntriesWithRunningBalance() {
+	at := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SUM(CASE WHEN kind = 'charge' THEN amount_cents ELSE -amount_cents END) OVER (ORDER BY id)")).
+		WithArgs(3, 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "member_id", "loan_id", "kind", "amount_cents", "note", "created_at", "balance", "total_count"}).
+			AddRow(2, 3, nil, "payment", 50, "", at, 25, 2).
+			AddRow(1, 3, 9, "charge", 75, "overdue fine for loan 9", at, 75, 2))
+	entries, total, err := m.ledgerRepository.List(context.Background(), 3, 10, 0)
+	m.Suite.Nil(err)
+	m.Suite.Equal(2, total)
+	loanID := 9
+	m.Suite.Equal([]fine.Entry{
+		{ID: 2, MemberID: 3, Kind: "payment", AmountCents: 50, CreatedAt: at, BalanceCents: 25},
+		{ID: 1, MemberID: 3, LoanID: &loanID, Kind: "charge", AmountCents: 75, Note: "overdue fine for loan 9", CreatedAt: at, BalanceCents: 75},
+	}, entries)
+}
+
+func (m *LedgerRepositoryTestSuite) TestBalance_ShouldSumChargesLessCredits() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(CASE WHEN kind = 'charge' THEN amount_cents ELSE -amount_cents END), 0) FROM ledger_entries WHERE member_id = $1")).
+		WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(125))
+	owed, err := m.ledgerRepository.Balance(context.Background(), 3)
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(125), owed)
+}
+
+func (m *LedgerRepositoryTestSuite) TestCredit_ShouldRecordPaymentAndReturnNewBalance() {
+	at := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM members WHERE id = $1 FOR UPDATE")).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	m.sqlMock.ExpectQuery("FROM ledger_entries").WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(125))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO ledger_entries (member_id, kind, amount_cents, note) VALUES ($1, $2, $3, $4)")).
+		WithArgs(3, "payment", int64(100), "cash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, at))
+	m.sqlMock.ExpectCommit()
+	e, err := m.ledgerRepository.Credit(context.Background(), 3, fine.KindPayment, 100, "cash")
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(fine.Entry{ID: 7, MemberID: 3, Kind: "payment", AmountCents: 100, Note: "cash", CreatedAt: at, BalanceCents: 25}, e)
+}
+
+func (m *LedgerRepositoryTestSuite) TestCredit_ShouldRefuseAmountAboveBalance() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("FROM members").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	m.sqlMock.ExpectQuery("FROM ledger_entries").WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(125))
+	m.sqlMock.ExpectRollback()
+	_, err := m.ledgerRepository.Credit(context.Background(), 3, fine.KindWaiver, 126, "goodwill")
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(fine.ErrExceedsBalance, err)
+}
+
+func (m *LedgerRepositoryTestSuite) TestCredit_ShouldReturnMemberNotFound() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("FROM members").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	m.sqlMock.ExpectRollback()
+	_, err := m.ledgerRepository.Credit(context.Background(), 3, fine.KindPayment, 100, "")
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(fine.ErrMemberNotFound, err)
+}
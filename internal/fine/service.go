@@ -0,0 +1,72 @@
+package fine
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/member"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type FineService interface {
+	// Ledger returns a page of the member's entries and what they owe now.
+	Ledger(ctx context.Context, memberID, limit, offset int) ([]Entry, int, int64, *book.ErrorResponse)
+	Pay(ctx context.Context, memberID int, req PaymentRequest) (Entry, *book.ErrorResponse)
+	Waive(ctx context.Context, memberID int, req WaiverRequest) (Entry, *book.ErrorResponse)
+}
+
+type fineService struct {
+	repository       LedgerRepository
+	memberRepository member.MemberRepository
+}
+
+func NewFineService(r LedgerRepository, mr member.MemberRepository) FineService {
+	return &fineService{repository: r, memberRepository: mr}
+}
+
+func (s *fineService) Ledger(ctx context.Context, memberID, limit, offset int) ([]Entry, int, int64, *book.ErrorResponse) {
+	if _, err := s.memberRepository.GetByID(ctx, memberID); err != nil {
+		if err == member.ErrNotFound {
+			logrus.Error("no member found for given id ", memberID)
+			return nil, 0, 0, GetErrorResponseByCode(member.MemberNotFound)
+		}
+		logrus.Error("error while fetching the member for id ", memberID, " error is ", err)
+		return nil, 0, 0, GetErrorResponseByCode(book.InternalServerError)
+	}
+	entries, total, err := s.repository.List(ctx, memberID, limit, offset)
+	if err != nil {
+		logrus.Error("error while fetching the ledger of member ", memberID, " error is ", err)
+		return nil, 0, 0, GetErrorResponseByCode(book.InternalServerError)
+	}
+	owed, err := s.repository.Balance(ctx, memberID)
+	if err != nil {
+		logrus.Error("error while computing the balance of member ", memberID, " error is ", err)
+		return nil, 0, 0, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return entries, total, owed, nil
+}
+
+func (s *fineService) Pay(ctx context.Context, memberID int, req PaymentRequest) (Entry, *book.ErrorResponse) {
+	return s.credit(ctx, memberID, KindPayment, req.AmountCents, req.Note)
+}
+
+func (s *fineService) Waive(ctx context.Context, memberID int, req WaiverRequest) (Entry, *book.ErrorResponse) {
+	return s.credit(ctx, memberID, KindWaiver, req.AmountCents, req.Note)
+}
+
+func (s *fineService) credit(ctx context.Context, memberID int, kind string, amount int64, note string) (Entry, *book.ErrorResponse) {
+	e, err := s.repository.Credit(ctx, memberID, kind, amount, note)
+	if err != nil {
+		switch err {
+		case ErrMemberNotFound:
+			logrus.Error("no member found for given id ", memberID)
+			return Entry{}, GetErrorResponseByCode(member.MemberNotFound)
+		case ErrExceedsBalance:
+			logrus.Error(kind, " of ", amount, " exceeds the balance of member ", memberID)
+			return Entry{}, GetErrorResponseByCode(AmountExceedsBalance)
+		}
+		logrus.Error("error while recording ", kind, " for member ", memberID, " error is ", err)
+		return Entry{}, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return e, nil
+}
@@ -0,0 +1,74 @@
+package fine_test
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/fine"
+	"book-store/internal/member"
+	mock_book "book-store/internal/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type FineServiceTestSuite struct {
+	suite.Suite
+	fineService    fine.FineService
+	mockRepo       *mock_book.MockLedgerRepository
+	mockMemberRepo *mock_book.MockMemberRepository
+	ctrl           *gomock.Controller
+}
+
+func TestFineServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(FineServiceTestSuite))
+}
+
+func (m *FineServiceTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockRepo = mock_book.NewMockLedgerRepository(m.ctrl)
+	m.mockMemberRepo = mock_book.NewMockMemberRepository(m.ctrl)
+	m.fineService = fine.NewFineService(m.mockRepo, m.mockMemberRepo)
+}
+
+func (m *FineServiceTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func (m *FineServiceTestSuite) TestLedger_ShouldReturnEntriesAndBalance() {
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(member.Member{ID: 3}, nil)
+	m.mockRepo.EXPECT().List(context.Background(), 3, 10, 0).Return([]fine.Entry{{ID: 1}}, 1, nil)
+	m.mockRepo.EXPECT().Balance(context.Background(), 3).Return(int64(75), nil)
+	entries, total, owed, err := m.fineService.Ledger(context.Background(), 3, 10, 0)
+	m.Suite.Nil(err)
+	m.Suite.Equal([]fine.Entry{{ID: 1}}, entries)
+	m.Suite.Equal(1, total)
+	m.Suite.Equal(int64(75), owed)
+}
+
+func (m *FineServiceTestSuite) TestLedger_ShouldReturnNotFoundForUnknownMember() {
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(member.Member{}, member.ErrNotFound)
+	_, _, _, err := m.fineService.Ledger(context.Background(), 3, 10, 0)
+	m.Suite.Equal(fine.GetErrorResponseByCode(member.MemberNotFound), err)
+}
+
+func (m *FineServiceTestSuite) TestPay_ShouldRecordPayment() {
+	m.mockRepo.EXPECT().Credit(context.Background(), 3, fine.KindPayment, int64(100), "cash").
+		Return(fine.Entry{ID: 7, Kind: fine.KindPayment, AmountCents: 100, BalanceCents: 25}, nil)
+	e, err := m.fineService.Pay(context.Background(), 3, fine.PaymentRequest{AmountCents: 100, Note: "cash"})
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(25), e.BalanceCents)
+}
+
+func (m *FineServiceTestSuite) TestWaive_ShouldMapRepositoryErrors() {
+	for err, code := range map[error]book.ErrorCode{
+		fine.ErrMemberNotFound:   member.MemberNotFound,
+		fine.ErrExceedsBalance:   fine.AmountExceedsBalance,
+		errors.New("db is down"): book.InternalServerError,
+	} {
+		m.mockRepo.EXPECT().Credit(context.Background(), 3, fine.KindWaiver, int64(100), "goodwill").Return(fine.Entry{}, err)
+		_, errResp := m.fineService.Waive(context.Background(), 3, fine.WaiverRequest{AmountCents: 100, Note: "goodwill"})
+		m.Suite.Equal(code, errResp.ErrorCode)
+	}
+}
@@ -0,0 +1,7 @@
+package health
+
+import "database/sql"
+
+func DatabaseCheck(db *sql.DB) Check {
+	return Check{Name: "database", Fn: db.PingContext}
+}
@@ -0,0 +1,6 @@
+package health
+
+type StatusResponse struct {
+	Status string            `json:"status" example:"ok"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
@@ -0,0 +1,75 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+)
+
+var checkTimeout = 2 * time.Second
+
+// Check is a named dependency probe run by the readiness endpoint.
+type Check struct {
+	Name string
+	Fn   func(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	checks []Check
+}
+
+func NewHealthHandler(checks ...Check) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+// Liveness godoc
+// @Summary      Liveness probe
+// @Description  Reports that the process is up and serving requests
+// @Tags         health
+// @Produce      json
+// @Success      200    {object}  StatusResponse
+// @Router       /healthz [get]
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	send(w, http.StatusOK, StatusResponse{Status: statusOK})
+}
+
+// Readiness godoc
+// @Summary      Readiness probe
+// @Description  Reports whether the instance can serve traffic, i.e. every dependency check passes
+// @Tags         health
+// @Produce      json
+// @Success      200    {object}  StatusResponse
+// @Failure      503    {object}  StatusResponse
+// @Router       /readyz [get]
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	res := StatusResponse{Status: statusOK, Checks: make(map[string]string, len(h.checks))}
+	statusCode := http.StatusOK
+	for _, c := range h.checks {
+		if err := c.Fn(ctx); err != nil {
+			logrus.Error("readiness check ", c.Name, " failed. error is ", err)
+			res.Checks[c.Name] = err.Error()
+			res.Status = statusUnavailable
+			statusCode = http.StatusServiceUnavailable
+			continue
+		}
+		res.Checks[c.Name] = statusOK
+	}
+	send(w, statusCode, res)
+}
+
+func send(w http.ResponseWriter, statusCode int, res StatusResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(res)
+}
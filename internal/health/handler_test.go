@@ -0,0 +1,78 @@
+package health_test
+
+import (
+	"book-store/internal/health"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestHealthHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthHandlerTestSuite))
+}
+
+func okCheck(name string) health.Check {
+	return health.Check{Name: name, Fn: func(ctx context.Context) error { return nil }}
+}
+
+func (m *HealthHandlerTestSuite) decode(w *httptest.ResponseRecorder) health.StatusResponse {
+	var res health.StatusResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&res))
+	return res
+}
+
+func (m *HealthHandlerTestSuite) TestLiveness_ShouldAlwaysReturnOk() {
+	h := health.NewHealthHandler(health.Check{Name: "database", Fn: func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}})
+	w := httptest.NewRecorder()
+	h.Liveness(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	m.Suite.Equal("ok", m.decode(w).Status)
+}
+
+func (m *HealthHandlerTestSuite) TestReadiness_ShouldReturnOkWhenAllChecksPass() {
+	h := health.NewHealthHandler(okCheck("database"), okCheck("schema"))
+	w := httptest.NewRecorder()
+	h.Readiness(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	m.Suite.Equal(health.StatusResponse{
+		Status: "ok",
+		Checks: map[string]string{"database": "ok", "schema": "ok"},
+	}, m.decode(w))
+}
+
+func (m *HealthHandlerTestSuite) TestReadiness_ShouldReturnServiceUnavailableWhenACheckFails() {
+	h := health.NewHealthHandler(health.Check{Name: "database", Fn: func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}}, okCheck("schema"))
+	w := httptest.NewRecorder()
+	h.Readiness(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	m.Suite.Equal(http.StatusServiceUnavailable, w.Result().StatusCode)
+	m.Suite.Equal(health.StatusResponse{
+		Status: "unavailable",
+		Checks: map[string]string{"database": "connection refused", "schema": "ok"},
+	}, m.decode(w))
+}
+
+func (m *HealthHandlerTestSuite) TestProbe_ShouldSucceedOnlyWhenInstanceIsLive() {
+	live := httptest.NewServer(http.HandlerFunc(health.NewHealthHandler().Liveness))
+	defer live.Close()
+	m.Suite.Nil(health.Probe(live.URL, time.Second))
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	m.Suite.Error(health.Probe(down.URL, time.Second))
+}
@@ -0,0 +1,22 @@
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Probe calls the liveness endpoint of a running instance and returns an
+// error unless it answers 200. It backs the binary's --health flag.
+func Probe(url string, timeout time.Duration) error {
+	client := http.Client{Timeout: timeout}
+	res, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("health probe %s returned status %d", url, res.StatusCode)
+	}
+	return nil
+}
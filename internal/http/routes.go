@@ -1,21 +1,162 @@
 package http
 
 import (
+	"book-store/internal/author"
 	"book-store/internal/book"
+	"book-store/internal/config"
+	"book-store/internal/fine"
+	"book-store/internal/health"
+	"book-store/internal/loan"
+	"book-store/internal/marc"
+	"book-store/internal/member"
+	"book-store/internal/migration"
+	"context"
+	"crypto/rand"
 	"database/sql"
 	"net/http"
+	"os"
+	"sync"
 
 	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
 )
 
-func RegisterRoutes(r *mux.Router, db *sql.DB) {
+// Services are the services behind the routes that the background jobs
+// share, so both work with the same ones.
+type Services struct {
+	Books   book.BookService
+	Imports book.ImportService
+	Holds   loan.HoldService
+}
+
+// RegisterRoutes registers every endpoint on r and returns the services the
+// background jobs need. Work that outlives its request, such as a CSV
+// import, stops when ctx is done and is added to jobs.
+func RegisterRoutes(ctx context.Context, jobs *sync.WaitGroup, r *mux.Router, db *sql.DB, cfg config.Config) Services {
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		logrus.Fatalf("migrations init: %v", err)
+	}
+	healthHandler := health.NewHealthHandler(
+		health.DatabaseCheck(db),
+		health.Check{Name: "migrations", Fn: migrator.Verify},
+	)
+	r.HandleFunc("/healthz", healthHandler.Liveness).Methods(http.MethodGet)
+	r.HandleFunc("/readyz", healthHandler.Readiness).Methods(http.MethodGet)
+
+	// Changes to books are recorded against the X-User of the request.
+	r.Use(book.ActorMiddleware)
+
 	bookRepo := book.NewBookRepository(db)
-	bookService := book.NewBookService(bookRepo)
+	bookService := book.NewBookService(bookRepo, book.NewCursorCodec(cursorSecret(cfg)))
 	handler := book.NewBookHandler(bookService)
 
 	r.HandleFunc("/books", handler.List).Methods(http.MethodGet)
+	r.HandleFunc("/books/search", handler.Search).Methods(http.MethodGet)
+	r.HandleFunc("/books/suggest", handler.Suggest).Methods(http.MethodGet)
+	r.HandleFunc("/books/export", handler.Export).Methods(http.MethodGet)
+	r.HandleFunc("/books/citations", handler.Citations).Methods(http.MethodGet)
+	r.HandleFunc("/books/isbn/{isbn}", handler.GetByISBN).Methods(http.MethodGet)
 	r.HandleFunc("/books/{id}", handler.Get).Methods(http.MethodGet)
 	r.HandleFunc("/books", handler.Create).Methods(http.MethodPost)
+	r.HandleFunc("/books:batch", handler.Batch).Methods(http.MethodPost)
 	r.HandleFunc("/books/{id}", handler.Update).Methods(http.MethodPut)
+	r.HandleFunc("/books/{id}", handler.Patch).Methods(http.MethodPatch)
 	r.HandleFunc("/books/{id}", handler.Delete).Methods(http.MethodDelete)
+	r.HandleFunc("/books/{id}/restore", handler.Restore).Methods(http.MethodPost)
+	r.HandleFunc("/trash/books", handler.ListTrash).Methods(http.MethodGet)
+	r.HandleFunc("/books/{id}/revisions", handler.ListRevisions).Methods(http.MethodGet)
+	r.HandleFunc("/books/{id}/revisions/diff", handler.DiffRevisions).Methods(http.MethodGet)
+	r.HandleFunc("/books/{id}/revisions/{rev}", handler.GetRevision).Methods(http.MethodGet)
+	r.HandleFunc("/books/{id}/revisions/{rev}/revert", handler.Revert).Methods(http.MethodPost)
+
+	copyRepo := book.NewCopyRepository(db, loan.ShelveCopy)
+	copyService := book.NewCopyService(copyRepo, bookRepo)
+	copyHandler := book.NewCopyHandler(copyService)
+
+	r.HandleFunc("/books/{id}/copies", copyHandler.List).Methods(http.MethodGet)
+	r.HandleFunc("/books/{id}/copies/{copyId}", copyHandler.Get).Methods(http.MethodGet)
+	r.HandleFunc("/books/{id}/copies", copyHandler.Create).Methods(http.MethodPost)
+	r.HandleFunc("/books/{id}/copies/{copyId}", copyHandler.Update).Methods(http.MethodPut)
+	r.HandleFunc("/books/{id}/copies/{copyId}", copyHandler.Delete).Methods(http.MethodDelete)
+
+	importService := book.NewImportService(ctx, jobs, book.NewImportRepository(db), bookService)
+	importHandler := book.NewImportHandler(importService)
+
+	r.HandleFunc("/imports", importHandler.Create).Methods(http.MethodPost)
+	r.HandleFunc("/imports/{id}", importHandler.Get).Methods(http.MethodGet)
+	r.HandleFunc("/imports/{id}/errors", importHandler.ErrorReport).Methods(http.MethodGet)
+
+	marcHandler := marc.NewMARCHandler(marc.NewMARCService(bookService))
+
+	// Registered before /books/{id}/marc, which would take export for an id.
+	r.HandleFunc("/books/export/marc", marcHandler.Export).Methods(http.MethodGet)
+	r.HandleFunc("/books/{id}/marc", marcHandler.Get).Methods(http.MethodGet)
+	r.HandleFunc("/imports/marc", marcHandler.Import).Methods(http.MethodPost)
+
+	authorRepo := author.NewAuthorRepository(db)
+	authorService := author.NewAuthorService(authorRepo)
+	authorHandler := author.NewAuthorHandler(authorService)
+
+	r.HandleFunc("/authors", authorHandler.List).Methods(http.MethodGet)
+	r.HandleFunc("/authors/{id}", authorHandler.Get).Methods(http.MethodGet)
+	r.HandleFunc("/authors", authorHandler.Create).Methods(http.MethodPost)
+	r.HandleFunc("/authors/{id}", authorHandler.Update).Methods(http.MethodPut)
+	r.HandleFunc("/authors/{id}", authorHandler.Delete).Methods(http.MethodDelete)
+
+	memberRepo := member.NewMemberRepository(db)
+	memberService := member.NewMemberService(memberRepo)
+	memberHandler := member.NewMemberHandler(memberService)
+
+	r.HandleFunc("/members", memberHandler.List).Methods(http.MethodGet)
+	r.HandleFunc("/members/{id}", memberHandler.Get).Methods(http.MethodGet)
+	r.HandleFunc("/members", memberHandler.Register).Methods(http.MethodPost)
+	r.HandleFunc("/members/{id}", memberHandler.Update).Methods(http.MethodPut)
+	r.HandleFunc("/members/{id}", memberHandler.Delete).Methods(http.MethodDelete)
+	r.HandleFunc("/members/{id}/suspend", memberHandler.Suspend).Methods(http.MethodPost)
+	r.HandleFunc("/members/{id}/reinstate", memberHandler.Reinstate).Methods(http.MethodPost)
+
+	loanRepo := loan.NewLoanRepository(db)
+	holdRepo := loan.NewHoldRepository(db)
+	ledgerRepo := fine.NewLedgerRepository(db)
+	loanService := loan.NewLoanService(loanRepo, holdRepo, memberRepo, ledgerRepo, cfg.GetFines())
+	loanHandler := loan.NewLoanHandler(loanService)
+
+	r.HandleFunc("/loans", loanHandler.Checkout).Methods(http.MethodPost)
+	r.HandleFunc("/loans/{id}", loanHandler.Get).Methods(http.MethodGet)
+	r.HandleFunc("/loans/{id}/return", loanHandler.Return).Methods(http.MethodPost)
+	r.HandleFunc("/loans/{id}/renew", loanHandler.Renew).Methods(http.MethodPost)
+
+	holdService := loan.NewHoldService(holdRepo, memberRepo)
+	holdHandler := loan.NewHoldHandler(holdService)
+
+	r.HandleFunc("/books/{id}/holds", holdHandler.Place).Methods(http.MethodPost)
+	r.HandleFunc("/holds/{id}", holdHandler.Get).Methods(http.MethodGet)
+	r.HandleFunc("/holds/{id}", holdHandler.Cancel).Methods(http.MethodDelete)
+	r.HandleFunc("/members/{id}/holds", holdHandler.ListByMember).Methods(http.MethodGet)
+
+	fineService := fine.NewFineService(ledgerRepo, memberRepo)
+	fineHandler := fine.NewFineHandler(fineService)
+
+	r.HandleFunc("/members/{id}/ledger", fineHandler.Ledger).Methods(http.MethodGet)
+	r.HandleFunc("/members/{id}/payments", fineHandler.Pay).Methods(http.MethodPost)
+	r.HandleFunc("/members/{id}/waivers", fineHandler.Waive).Methods(http.MethodPost)
+
+	return Services{Books: bookService, Imports: importService, Holds: holdService}
+}
+
+// cursorSecret reads the key for list cursors from the environment variable
+// named in the config, or picks a random one when it is not set.
+func cursorSecret(cfg config.Config) []byte {
+	if name := cfg.GetCursorSecret(); name != "" {
+		if s := os.Getenv(name); s != "" {
+			return []byte(s)
+		}
+	}
+	logrus.Warn("cursor secret is not set; list cursors will not survive a restart or work across replicas")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		logrus.Fatalf("cursor secret: %v", err)
+	}
+	return secret
 }
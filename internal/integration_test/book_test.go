@@ -1,9 +1,12 @@
 package integrationtest
 
 import (
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -31,6 +34,9 @@ func TestGetBookById_ShouldBeSuccessful(t *testing.T) {
 		MethodType:                   "GET",
 		ExpectedHttpStatusCode:       http.StatusOK,
 		ExpectedResponseBodyFilePath: "response/get_book_response.json",
+		ExpectedHeaders: map[string]string{
+			"ETag": `"1"`,
+		},
 	}
 	Exec(t, req)
 }
@@ -62,7 +68,30 @@ func TestPut_ShouldUpdateTheExistingBook(t *testing.T) {
 		URL:                    "/books/" + strconv.Itoa(int(id)),
 		MethodType:             "PUT",
 		RequestBodyFilePath:    "./request/update_book_request.json",
+		RequestHeaders: map[string]string{
+			"If-Match": `"1"`,
+		},
 		ExpectedHttpStatusCode: http.StatusNoContent,
+		ExpectedHeaders: map[string]string{
+			"ETag": `"2"`,
+		},
+	}
+	Exec(t, req)
+}
+
+func TestPut_ShouldRefuseStaleETag(t *testing.T) {
+	id, err := insertTestBook(t.Context(), "Test", "Test Author", "Test Desc")
+	if err != nil {
+		logrus.Fatalf("error while inserting data in db %s", err)
+	}
+	req := Request{
+		URL:                 "/books/" + strconv.Itoa(int(id)),
+		MethodType:          "PUT",
+		RequestBodyFilePath: "./request/update_book_request.json",
+		RequestHeaders: map[string]string{
+			"If-Match": `"2"`,
+		},
+		ExpectedHttpStatusCode: http.StatusPreconditionFailed,
 	}
 	Exec(t, req)
 }
@@ -72,9 +101,63 @@ func TestPut_ShouldCreateNewBookWhenBookWithCurrentIdDoesnotExist(t *testing.T)
 		URL:                    "/books/" + strconv.Itoa(int(100)),
 		MethodType:             "PUT",
 		RequestBodyFilePath:    "./request/update_book_request.json",
+		ExpectedHttpStatusCode: http.StatusCreated,
+		ExpectedHeaders: map[string]string{
+			"Location": "/books/100",
+			"ETag":     `"1"`,
+		},
+	}
+	Exec(t, req)
+}
+
+func TestPut_ShouldKeepPostFromReusingTheCreatedId(t *testing.T) {
+	Exec(t, Request{
+		URL:                    "/books/" + strconv.Itoa(int(100)),
+		MethodType:             "PUT",
+		RequestBodyFilePath:    "./request/update_book_request.json",
+		ExpectedHttpStatusCode: http.StatusCreated,
+	})
+	Exec(t, Request{
+		URL:                    "/books",
+		MethodType:             "POST",
+		RequestBodyFilePath:    "./request/create_book_request.json",
+		ExpectedHttpStatusCode: http.StatusCreated,
+		ExpectedHeaders: map[string]string{
+			"Location": "/books/101",
+		},
+	})
+}
+
+func TestPut_ShouldRequireIfMatchForExistingBook(t *testing.T) {
+	id, err := insertTestBook(t.Context(), "Test", "Test Author", "Test Desc")
+	if err != nil {
+		logrus.Fatalf("error while inserting data in db %s", err)
+	}
+	req := Request{
+		URL:                    "/books/" + strconv.Itoa(int(id)),
+		MethodType:             "PUT",
+		RequestBodyFilePath:    "./request/update_book_request.json",
+		ExpectedHttpStatusCode: http.StatusPreconditionRequired,
+	}
+	Exec(t, req)
+}
+
+func TestPatch_ShouldMergeIntoTheExistingBook(t *testing.T) {
+	id, err := insertTestBook(t.Context(), "Test", "Test Author", "Test Desc")
+	if err != nil {
+		logrus.Fatalf("error while inserting data in db %s", err)
+	}
+	req := Request{
+		URL:                 "/books/" + strconv.Itoa(int(id)),
+		MethodType:          "PATCH",
+		RequestBodyFilePath: "./request/patch_book_request.json",
+		RequestHeaders: map[string]string{
+			"Content-Type": "application/merge-patch+json",
+			"If-Match":     `"1"`,
+		},
 		ExpectedHttpStatusCode: http.StatusNoContent,
 		ExpectedHeaders: map[string]string{
-			"Location": "/books/1",
+			"ETag": `"2"`,
 		},
 	}
 	Exec(t, req)
@@ -88,7 +171,244 @@ func TestDelete_ShouldBeSuccessful(t *testing.T) {
 	req := Request{
 		URL:                    "/books/" + strconv.Itoa(int(id)),
 		MethodType:             "DELETE",
+		RequestHeaders: map[string]string{
+			"If-Match": `"1"`,
+		},
 		ExpectedHttpStatusCode: http.StatusNoContent,
 	}
 	Exec(t, req)
 }
+
+func TestDelete_ShouldMoveTheBookToTheTrashUntilRestored(t *testing.T) {
+	id, err := insertTestBook(t.Context(), "Test", "Test Author", "Test Desc")
+	if err != nil {
+		logrus.Fatalf("error while inserting data in db %s", err)
+	}
+	url := "/books/" + strconv.Itoa(int(id))
+	Exec(t, Request{
+		URL:                    url,
+		MethodType:             "DELETE",
+		RequestHeaders:         map[string]string{"If-Match": `"1"`},
+		ExpectedHttpStatusCode: http.StatusNoContent,
+	})
+	Exec(t, Request{
+		URL:                    url,
+		MethodType:             "GET",
+		ExpectedHttpStatusCode: http.StatusNotFound,
+	})
+	Exec(t, Request{
+		URL:                    url,
+		MethodType:             "DELETE",
+		RequestHeaders:         map[string]string{"If-Match": `"2"`},
+		ExpectedHttpStatusCode: http.StatusNotFound,
+	})
+	Exec(t, Request{
+		URL:                    url + "/restore",
+		MethodType:             "POST",
+		ExpectedHttpStatusCode: http.StatusOK,
+		ExpectedHeaders:        map[string]string{"ETag": `"3"`},
+	})
+	Exec(t, Request{
+		URL:                    url,
+		MethodType:             "GET",
+		ExpectedHttpStatusCode: http.StatusOK,
+	})
+}
+
+func TestRevisions_ShouldDiffAndRevertChanges(t *testing.T) {
+	Exec(t, Request{
+		URL:                    "/books",
+		MethodType:             "POST",
+		RequestBodyFilePath:    "./request/create_book_request.json",
+		RequestHeaders:         map[string]string{"X-User": "alice"},
+		ExpectedHttpStatusCode: http.StatusCreated,
+	})
+	Exec(t, Request{
+		URL:                    "/books/1",
+		MethodType:             "PUT",
+		RequestBodyFilePath:    "./request/update_book_request.json",
+		RequestHeaders:         map[string]string{"If-Match": `"1"`, "X-User": "bob"},
+		ExpectedHttpStatusCode: http.StatusNoContent,
+	})
+	Exec(t, Request{
+		URL:                          "/books/1/revisions/diff?from=1&to=2",
+		MethodType:                   "GET",
+		ExpectedResponseBodyFilePath: "./response/get_revision_diff_response.json",
+		ExpectedHttpStatusCode:       http.StatusOK,
+	})
+	Exec(t, Request{
+		URL:                    "/books/1/revisions/1/revert",
+		MethodType:             "POST",
+		RequestHeaders:         map[string]string{"If-Match": `"2"`},
+		ExpectedHttpStatusCode: http.StatusNoContent,
+		ExpectedHeaders:        map[string]string{"ETag": `"3"`},
+	})
+	Exec(t, Request{
+		URL:                    "/books/1/revisions/3",
+		MethodType:             "GET",
+		ExpectedHttpStatusCode: http.StatusOK,
+	})
+	Exec(t, Request{
+		URL:                    "/books/1/revisions/4",
+		MethodType:             "GET",
+		ExpectedHttpStatusCode: http.StatusNotFound,
+	})
+}
+
+func TestBatch_ShouldApplyNothingWhenAnAtomicBatchFails(t *testing.T) {
+	Exec(t, Request{
+		URL:                          "/books:batch",
+		MethodType:                   "POST",
+		RequestBodyFilePath:          "./request/atomic_batch_request.json",
+		ExpectedResponseBodyFilePath: "./response/atomic_batch_response.json",
+		ExpectedHttpStatusCode:       http.StatusOK,
+	})
+	Exec(t, Request{
+		URL:                    "/books/1",
+		MethodType:             "GET",
+		ExpectedHttpStatusCode: http.StatusNotFound,
+	})
+}
+
+func TestBatch_ShouldKeepTheWritesThatSucceedInBestEffortMode(t *testing.T) {
+	Exec(t, Request{
+		URL:                          "/books:batch",
+		MethodType:                   "POST",
+		RequestBodyFilePath:          "./request/best_effort_batch_request.json",
+		ExpectedResponseBodyFilePath: "./response/best_effort_batch_response.json",
+		ExpectedHttpStatusCode:       http.StatusOK,
+	})
+	Exec(t, Request{
+		URL:                    "/books/1",
+		MethodType:             "GET",
+		ExpectedHttpStatusCode: http.StatusOK,
+	})
+}
+
+func TestExport_ShouldStreamTheMatchingBooks(t *testing.T) {
+	Exec(t, Request{
+		URL:                    "/books",
+		MethodType:             "POST",
+		RequestBodyFilePath:    "./request/create_book_request.json",
+		ExpectedHttpStatusCode: http.StatusCreated,
+	})
+	Exec(t, Request{
+		URL:                          "/books/export?format=ndjson&title=potter",
+		MethodType:                   "GET",
+		ExpectedResponseBodyFilePath: "./response/export_books_ndjson_response.json",
+		ExpectedHttpStatusCode:       http.StatusOK,
+		ExpectedHeaders:              map[string]string{"Content-Type": "application/x-ndjson"},
+	})
+	Exec(t, Request{
+		URL:                    "/books/export?format=csv",
+		MethodType:             "GET",
+		ExpectedHttpStatusCode: http.StatusOK,
+		ExpectedHeaders:        map[string]string{"Content-Disposition": `attachment; filename="books.csv"`},
+	})
+}
+
+func TestMARC_ShouldImportRecordsAndExportTheBooks(t *testing.T) {
+	Exec(t, Request{
+		URL:                          "/imports/marc",
+		MethodType:                   "POST",
+		RequestBodyFilePath:          "./request/import_marc_request.xml",
+		RequestHeaders:               map[string]string{"Content-Type": "application/marcxml+xml"},
+		ExpectedResponseBodyFilePath: "./response/import_marc_response.json",
+		ExpectedHttpStatusCode:       http.StatusOK,
+	})
+	Exec(t, Request{
+		URL:                    "/books/1/marc?format=marcxml",
+		MethodType:             "GET",
+		ExpectedHttpStatusCode: http.StatusOK,
+		ExpectedHeaders:        map[string]string{"Content-Type": "application/marcxml+xml"},
+	})
+	Exec(t, Request{
+		URL:                    "/books/export/marc",
+		MethodType:             "GET",
+		ExpectedHttpStatusCode: http.StatusOK,
+		ExpectedHeaders:        map[string]string{"Content-Disposition": `attachment; filename="books.mrc"`},
+	})
+}
+
+func TestCitations_ShouldRenderTheBooksAsCitations(t *testing.T) {
+	Exec(t, Request{
+		URL:                    "/books",
+		MethodType:             "POST",
+		RequestBodyFilePath:    "./request/create_book_request.json",
+		ExpectedHttpStatusCode: http.StatusCreated,
+	})
+	Exec(t, Request{
+		URL:                          "/books/1",
+		MethodType:                   "GET",
+		RequestHeaders:               map[string]string{"Accept": "application/vnd.citationstyles.csl+json"},
+		ExpectedResponseBodyFilePath: "./response/get_book_csl_json_response.json",
+		ExpectedHttpStatusCode:       http.StatusOK,
+		ExpectedHeaders:              map[string]string{"Content-Type": "application/vnd.citationstyles.csl+json; charset=utf-8"},
+	})
+	Exec(t, Request{
+		URL:                    "/books/citations?ids=1&format=ris",
+		MethodType:             "GET",
+		ExpectedHttpStatusCode: http.StatusOK,
+		ExpectedHeaders:        map[string]string{"Content-Disposition": `attachment; filename="books.ris"`},
+	})
+	Exec(t, Request{
+		URL:                    "/books/citations?ids=1,100",
+		MethodType:             "GET",
+		ExpectedHttpStatusCode: http.StatusNotFound,
+	})
+}
+
+func TestImport_ShouldImportTheRowsOfACSVFile(t *testing.T) {
+	Exec(t, Request{
+		URL:                    "/imports",
+		MethodType:             "POST",
+		RequestBodyFilePath:    "./request/import_books_request.txt",
+		RequestHeaders:         map[string]string{"Content-Type": "multipart/form-data; boundary=import-boundary"},
+		ExpectedHttpStatusCode: http.StatusAccepted,
+		ExpectedHeaders:        map[string]string{"Location": "/imports/1"},
+	})
+	awaitImport(t, "/imports/1")
+	Exec(t, Request{
+		URL:                          "/imports/1",
+		MethodType:                   "GET",
+		ExpectedResponseBodyFilePath: "./response/get_finished_import_response.json",
+		ExpectedHttpStatusCode:       http.StatusOK,
+	})
+	Exec(t, Request{
+		URL:                    "/imports/1/errors",
+		MethodType:             "GET",
+		ExpectedHttpStatusCode: http.StatusOK,
+		ExpectedHeaders:        map[string]string{"Content-Type": "text/csv"},
+	})
+	Exec(t, Request{
+		URL:                    "/books/1",
+		MethodType:             "GET",
+		ExpectedHttpStatusCode: http.StatusOK,
+	})
+}
+
+// awaitImport polls an import job until it is no longer running.
+func awaitImport(t *testing.T, url string) {
+	for i := 0; i < 50; i++ {
+		rr := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", url, nil)
+		router.ServeHTTP(rr, r)
+		var job struct{ Status string }
+		json.NewDecoder(rr.Body).Decode(&job)
+		if job.Status != "running" {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("import %s did not finish", url)
+}
+
+func TestDelete_ShouldReturnNotFoundForMissingBook(t *testing.T) {
+	req := Request{
+		URL:                    "/books/" + strconv.Itoa(int(100)),
+		MethodType:             "DELETE",
+		RequestHeaders:         map[string]string{"If-Match": `"1"`},
+		ExpectedHttpStatusCode: http.StatusNotFound,
+	}
+	Exec(t, req)
+}
@@ -6,7 +6,7 @@ import (
 )
 
 func cleanUp(t *testing.T) {
-	_, err := sharedDB.Exec(`TRUNCATE books RESTART IDENTITY CASCADE`)
+	_, err := sharedDB.Exec(`TRUNCATE books, authors, members, import_jobs RESTART IDENTITY CASCADE`)
 	if err != nil {
 		t.Fatalf("cleanup error: %v", err)
 	}
@@ -4,8 +4,11 @@ import (
 	"book-store/internal/config"
 	"book-store/internal/db"
 	appHttp "book-store/internal/http"
+	"book-store/internal/migration"
+	"context"
 	"database/sql"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -16,6 +19,8 @@ import (
 var (
 	router   *mux.Router
 	sharedDB *sql.DB
+	// jobs are the imports still running, which must end before the pool.
+	jobs sync.WaitGroup
 )
 
 func TestMain(m *testing.M) {
@@ -27,9 +32,17 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		logrus.Fatalf("db connect failed: %v", err)
 	}
+	migrator, err := migration.NewMigrator(sharedDB)
+	if err != nil {
+		logrus.Fatalf("migrations init failed: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		logrus.Fatalf("migrations failed: %v", err)
+	}
 	router = mux.NewRouter()
-	appHttp.RegisterRoutes(router, sharedDB)
+	appHttp.RegisterRoutes(context.Background(), &jobs, router, sharedDB, cfg)
 	code := m.Run()
+	jobs.Wait()
 	sharedDB.Close()
 	os.Exit(code)
 }
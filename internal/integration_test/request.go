@@ -4,6 +4,7 @@ type Request struct {
 	URL                          string
 	MethodType                   string
 	RequestBodyFilePath          string
+	RequestHeaders               map[string]string
 	ExpectedResponseBodyFilePath string
 	ExpectedHttpStatusCode       int
 	ExpectedHeaders              map[string]string
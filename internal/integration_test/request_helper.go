@@ -34,6 +34,9 @@ func Exec(t *testing.T, req Request) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	for k, v := range req.RequestHeaders {
+		request.Header.Set(k, v)
+	}
 	router.ServeHTTP(rr, request)
 
 	actualResponse := rr.Body.String()
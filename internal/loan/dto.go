@@ -0,0 +1,36 @@
+package loan
+
+type CheckoutRequest struct {
+	MemberID int    `json:"memberId" validate:"required,gt=0" example:"1"`
+	Barcode  string `json:"barcode" validate:"required,min=1,max=64" example:"LIB-000123"`
+}
+
+type LoanResponse struct {
+	ID         int    `json:"id" example:"1"`
+	MemberID   int    `json:"memberId" example:"1"`
+	CopyID     int    `json:"copyId" example:"4"`
+	BookID     int    `json:"bookId" example:"12"`
+	Barcode    string `json:"barcode" example:"LIB-000123"`
+	LoanedAt   string `json:"loanedAt" example:"2026-10-17T09:30:00Z"`
+	DueOn      string `json:"dueOn" example:"2026-11-07"`
+	ReturnedAt string `json:"returnedAt,omitempty" example:"2026-11-01T16:05:00Z"`
+	Renewals   int    `json:"renewals" example:"0"`
+	Overdue    bool   `json:"overdue" example:"false"`
+	FineCents  int64  `json:"fineCents,omitempty" example:"75"`
+}
+
+type PlaceHoldRequest struct {
+	MemberID int `json:"memberId" validate:"required,gt=0" example:"1"`
+}
+
+type HoldResponse struct {
+	ID       int    `json:"id" example:"1"`
+	BookID   int    `json:"bookId" example:"12"`
+	MemberID int    `json:"memberId" example:"1"`
+	Status   string `json:"status" example:"waiting"`
+	PlacedAt string `json:"placedAt" example:"2026-10-17T09:30:00Z"`
+	Position int    `json:"position,omitempty" example:"2"`
+	CopyID   int    `json:"copyId,omitempty" example:"4"`
+	PickupBy string `json:"pickupBy,omitempty" example:"2026-10-24"`
+	ClosedAt string `json:"closedAt,omitempty" example:"2026-10-20T11:00:00Z"`
+}
@@ -0,0 +1,51 @@
+package loan
+
+import "time"
+
+type Loan struct {
+	ID         int        `sql:"id"`
+	CopyID     int        `sql:"copy_id"`
+	MemberID   int        `sql:"member_id"`
+	LoanedAt   time.Time  `sql:"loaned_at"`
+	DueOn      time.Time  `sql:"due_on"`
+	ReturnedAt *time.Time `sql:"returned_at"`
+	Renewals   int        `sql:"renewals"`
+	// BookID and Barcode describe the borrowed copy.
+	BookID  int    `sql:"book_id"`
+	Barcode string `sql:"barcode"`
+	// FineCents is what the member was charged for returning the loan late.
+	FineCents int64
+}
+
+// Overdue reports whether the loan is still open after its due date.
+func (l Loan) Overdue(now time.Time) bool {
+	return l.ReturnedAt == nil && l.DueOn.Before(today(now))
+}
+
+func today(now time.Time) time.Time {
+	return now.UTC().Truncate(24 * time.Hour)
+}
+
+// Hold is a member's place in the queue for a title. Once a copy comes back
+// it is set aside for the first waiting hold, which turns ready until the
+// member picks it up or the pickup window closes.
+type Hold struct {
+	ID       int        `sql:"id"`
+	BookID   int        `sql:"book_id"`
+	MemberID int        `sql:"member_id"`
+	Status   string     `sql:"status"`
+	PlacedAt time.Time  `sql:"placed_at"`
+	CopyID   *int       `sql:"copy_id"`
+	PickupBy *time.Time `sql:"pickup_by"`
+	ClosedAt *time.Time `sql:"closed_at"`
+	// Position is the 1-based place in the queue of a waiting hold.
+	Position int
+}
+
+const (
+	HoldStatusWaiting   = "waiting"
+	HoldStatusReady     = "ready"
+	HoldStatusFulfilled = "fulfilled"
+	HoldStatusCancelled = "cancelled"
+	HoldStatusExpired   = "expired"
+)
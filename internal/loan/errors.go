@@ -0,0 +1,106 @@
+package loan
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/member"
+	"net/http"
+)
+
+const (
+	LoanNotFound        book.ErrorCode = "LOAN_NOT_FOUND"
+	CopyNotAvailable    book.ErrorCode = "COPY_NOT_AVAILABLE"
+	LoanLimitReached    book.ErrorCode = "LOAN_LIMIT_REACHED"
+	LoanAlreadyReturned book.ErrorCode = "LOAN_ALREADY_RETURNED"
+	RenewalLimitReached book.ErrorCode = "RENEWAL_LIMIT_REACHED"
+	MemberSuspended     book.ErrorCode = "MEMBER_SUSPENDED"
+	MembershipExpired   book.ErrorCode = "MEMBERSHIP_EXPIRED"
+	LoanConflict        book.ErrorCode = "LOAN_CONFLICT"
+	HoldsPending        book.ErrorCode = "HOLDS_PENDING"
+	HoldNotFound        book.ErrorCode = "HOLD_NOT_FOUND"
+	HoldAlreadyPlaced   book.ErrorCode = "HOLD_ALREADY_PLACED"
+	HoldNotNeeded       book.ErrorCode = "HOLD_NOT_NEEDED"
+	HoldClosed          book.ErrorCode = "HOLD_CLOSED"
+	FinesOutstanding    book.ErrorCode = "FINES_OUTSTANDING"
+)
+
+var errorResponseMap = map[book.ErrorCode]*book.ErrorResponse{
+	LoanNotFound: {
+		HttpStatusCode: http.StatusNotFound,
+		ErrorCode:      LoanNotFound,
+		ErrorMessage:   "loan not found",
+	},
+	CopyNotAvailable: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      CopyNotAvailable,
+		ErrorMessage:   "copy is not available for loan",
+	},
+	LoanLimitReached: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      LoanLimitReached,
+		ErrorMessage:   "member has reached the maximum number of loans",
+	},
+	LoanAlreadyReturned: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      LoanAlreadyReturned,
+		ErrorMessage:   "loan has already been returned",
+	},
+	RenewalLimitReached: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      RenewalLimitReached,
+		ErrorMessage:   "loan cannot be renewed any more",
+	},
+	MemberSuspended: {
+		HttpStatusCode: http.StatusForbidden,
+		ErrorCode:      MemberSuspended,
+		ErrorMessage:   "member is suspended",
+	},
+	MembershipExpired: {
+		HttpStatusCode: http.StatusForbidden,
+		ErrorCode:      MembershipExpired,
+		ErrorMessage:   "membership has expired",
+	},
+	LoanConflict: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      LoanConflict,
+		ErrorMessage:   "loan was changed by another request, try again",
+	},
+	HoldsPending: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      HoldsPending,
+		ErrorMessage:   "other members are waiting for this book",
+	},
+	HoldNotFound: {
+		HttpStatusCode: http.StatusNotFound,
+		ErrorCode:      HoldNotFound,
+		ErrorMessage:   "hold not found",
+	},
+	HoldAlreadyPlaced: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      HoldAlreadyPlaced,
+		ErrorMessage:   "member already has a hold on this book",
+	},
+	HoldNotNeeded: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      HoldNotNeeded,
+		ErrorMessage:   "a copy of this book is available for loan",
+	},
+	HoldClosed: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      HoldClosed,
+		ErrorMessage:   "hold is no longer active",
+	},
+	FinesOutstanding: {
+		HttpStatusCode: http.StatusForbidden,
+		ErrorCode:      FinesOutstanding,
+		ErrorMessage:   "member owes more in fines than allowed to borrow",
+	},
+}
+
+// GetErrorResponseByCode resolves loan specific codes and falls back to the
+// member codes and the codes shared with the book package.
+func GetErrorResponseByCode(errCode book.ErrorCode) *book.ErrorResponse {
+	if errResponse, ok := errorResponseMap[errCode]; ok {
+		return errResponse
+	}
+	return member.GetErrorResponseByCode(errCode)
+}
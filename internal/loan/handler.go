@@ -0,0 +1,168 @@
+package loan
+
+import (
+	"book-store/internal/book"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type LoanHandler struct {
+	svc LoanService
+	val validator.Validate
+}
+
+func NewLoanHandler(s LoanService) *LoanHandler {
+	return &LoanHandler{svc: s, val: *validator.New()}
+}
+
+// Checkout godoc
+// @Summary      Check out a copy
+// @Description  Lend the copy with the given barcode to a member. The due date and the number of loans a member may hold depend on the membership type. Members owing more than the fine threshold may not borrow
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Param        loan   body      CheckoutRequest  true  "Member and copy barcode"
+// @Success      201    {object}  LoanResponse
+// @Header       201    {string}  Location  "URL of created loan"
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      403    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Failure      409    {object}  book.ErrorResponse
+// @Router       /loans [post]
+func (h *LoanHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	var req CheckoutRequest
+	if !decode(w, r, &h.val, &req) {
+		return
+	}
+	l, err := h.svc.Checkout(r.Context(), req)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.Header().Set("location", fmt.Sprintf("%s/%d", "/loans", l.ID))
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toLoanResponse(l, time.Now()))
+}
+
+// Get godoc
+// @Summary      Get loan by ID
+// @Description  Retrieve a single loan by its ID
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int   true   "Loan ID"
+// @Success      200    {object}  LoanResponse
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Router       /loans/{id} [get]
+func (h *LoanHandler) Get(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.svc.Get)
+}
+
+// Return godoc
+// @Summary      Return a loan
+// @Description  Close the loan and put the copy back on the shelf. A late return is fined according to the membership type
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int   true   "Loan ID"
+// @Success      200    {object}  LoanResponse
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Failure      409    {object}  book.ErrorResponse
+// @Router       /loans/{id}/return [post]
+func (h *LoanHandler) Return(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.svc.Return)
+}
+
+// Renew godoc
+// @Summary      Renew a loan
+// @Description  Extend the due date by another loan period counted from today. The number of renewals depends on the membership type
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int   true   "Loan ID"
+// @Success      200    {object}  LoanResponse
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      403    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Failure      409    {object}  book.ErrorResponse
+// @Router       /loans/{id}/renew [post]
+func (h *LoanHandler) Renew(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.svc.Renew)
+}
+
+// respond runs fn with the loan id from the path and writes the loan it returns.
+func (h *LoanHandler) respond(w http.ResponseWriter, r *http.Request, fn func(ctx context.Context, id int) (Loan, *book.ErrorResponse)) {
+	id, ok := pathID(w, r, "loan")
+	if !ok {
+		return
+	}
+	l, err := fn(r.Context(), id)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	json.NewEncoder(w).Encode(toLoanResponse(l, time.Now()))
+}
+
+func decode(w http.ResponseWriter, r *http.Request, val *validator.Validate, req any) bool {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		sendError(w, *GetErrorResponseByCode(book.BadRequest))
+		return false
+	}
+	if err := val.Struct(req); err != nil {
+		var errs []string
+		for _, fe := range err.(validator.ValidationErrors) {
+			errs = append(errs, fmt.Sprintf("%s failed on '%s'", fe.Field(), fe.Tag()))
+		}
+		logrus.Error("error while validating the request. error is ", errs)
+		sendError(w, *book.GetErrorResponse(book.BadRequest, strings.Join(errs, "; "), http.StatusBadRequest))
+		return false
+	}
+	return true
+}
+
+func pathID(w http.ResponseWriter, r *http.Request, what string) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		logrus.Error("invalid ", what, " id provided ", mux.Vars(r)["id"])
+		sendError(w, *GetErrorResponseByCode(book.BadRequest))
+		return 0, false
+	}
+	return id, true
+}
+
+func toLoanResponse(l Loan, now time.Time) LoanResponse {
+	resp := LoanResponse{
+		ID:        l.ID,
+		MemberID:  l.MemberID,
+		CopyID:    l.CopyID,
+		BookID:    l.BookID,
+		Barcode:   l.Barcode,
+		LoanedAt:  l.LoanedAt.UTC().Format(time.RFC3339),
+		DueOn:     l.DueOn.Format(time.DateOnly),
+		Renewals:  l.Renewals,
+		Overdue:   l.Overdue(now),
+		FineCents: l.FineCents,
+	}
+	if l.ReturnedAt != nil {
+		resp.ReturnedAt = l.ReturnedAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+func sendError(w http.ResponseWriter, errResponse book.ErrorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errResponse.HttpStatusCode)
+	json.NewEncoder(w).Encode(errResponse)
+}
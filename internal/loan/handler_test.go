@@ -0,0 +1,106 @@
+package loan_test
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/loan"
+	mock_book "book-store/internal/mocks"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoanHandlerTestSuite struct {
+	suite.Suite
+	loanHandler *loan.LoanHandler
+	mockService *mock_book.MockLoanService
+	ctrl        *gomock.Controller
+}
+
+func TestLoanHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(LoanHandlerTestSuite))
+}
+
+func (m *LoanHandlerTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockService = mock_book.NewMockLoanService(m.ctrl)
+	m.loanHandler = loan.NewLoanHandler(m.mockService)
+}
+
+func (m *LoanHandlerTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func (m *LoanHandlerTestSuite) TestCheckout_ShouldReturnCreatedLoan() {
+	req := loan.CheckoutRequest{MemberID: 3, Barcode: "BC-001"}
+	b, _ := json.Marshal(req)
+	r, _ := http.NewRequest("POST", "/loans", bytes.NewReader(b))
+	w := httptest.NewRecorder()
+	loanedAt := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	m.mockService.EXPECT().Checkout(r.Context(), req).Return(loan.Loan{
+		ID: 9, CopyID: 4, MemberID: 3, BookID: 12, Barcode: "BC-001", LoanedAt: loanedAt, DueOn: loanedAt.AddDate(0, 0, 21).Truncate(24 * time.Hour),
+	}, nil)
+
+	m.loanHandler.Checkout(w, r)
+	m.Suite.Equal(201, w.Result().StatusCode)
+	m.Suite.Equal("/loans/9", w.Result().Header.Get("Location"))
+
+	var body loan.LoanResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal(loan.LoanResponse{
+		ID: 9, MemberID: 3, CopyID: 4, BookID: 12, Barcode: "BC-001", LoanedAt: "2026-10-17T09:30:00Z", DueOn: "2026-11-07",
+	}, body)
+}
+
+func (m *LoanHandlerTestSuite) TestCheckout_ShouldReturnBadRequestWhenBarcodeIsMissing() {
+	r, _ := http.NewRequest("POST", "/loans", bytes.NewReader([]byte(`{"memberId": 3}`)))
+	w := httptest.NewRecorder()
+
+	m.loanHandler.Checkout(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+
+	var actualErr book.ErrorResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&actualErr))
+	m.Suite.Equal("Barcode failed on 'required'", actualErr.Error())
+}
+
+func (m *LoanHandlerTestSuite) TestReturn_ShouldReportOverdueFlagAndReturnTime() {
+	r, _ := http.NewRequest("POST", "/loans/9/return", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "9"})
+	w := httptest.NewRecorder()
+	returnedAt := time.Date(2026, 11, 1, 16, 5, 0, 0, time.UTC)
+	m.mockService.EXPECT().Return(r.Context(), 9).Return(loan.Loan{ID: 9, DueOn: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), ReturnedAt: &returnedAt}, nil)
+
+	m.loanHandler.Return(w, r)
+	m.Suite.Equal(200, w.Result().StatusCode)
+
+	var body loan.LoanResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal("2026-11-01T16:05:00Z", body.ReturnedAt)
+	m.Suite.False(body.Overdue)
+}
+
+func (m *LoanHandlerTestSuite) TestRenew_ShouldPassServiceErrorsThrough() {
+	r, _ := http.NewRequest("POST", "/loans/9/renew", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "9"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Renew(r.Context(), 9).Return(loan.Loan{}, loan.GetErrorResponseByCode(loan.RenewalLimitReached))
+
+	m.loanHandler.Renew(w, r)
+	m.Suite.Equal(409, w.Result().StatusCode)
+}
+
+func (m *LoanHandlerTestSuite) TestGet_ShouldThrowErrorWhenLoanIdIsInvalid() {
+	r, _ := http.NewRequest("GET", "/loans/abc", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "abc"})
+	w := httptest.NewRecorder()
+
+	m.loanHandler.Get(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+}
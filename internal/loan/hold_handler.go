@@ -0,0 +1,150 @@
+package loan
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type HoldHandler struct {
+	svc HoldService
+	val validator.Validate
+}
+
+func NewHoldHandler(s HoldService) *HoldHandler {
+	return &HoldHandler{svc: s, val: *validator.New()}
+}
+
+// Place godoc
+// @Summary      Place a hold on a book
+// @Description  Queue a member for the next copy of a book that has no copy available. Holds are served first come, first served; a returned copy is set aside for the first member in the queue, who has 7 days to collect it
+// @Tags         holds
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int               true  "Book ID"
+// @Param        hold   body      PlaceHoldRequest  true  "Member placing the hold"
+// @Success      201    {object}  HoldResponse
+// @Header       201    {string}  Location  "URL of created hold"
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      403    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Failure      409    {object}  book.ErrorResponse
+// @Router       /books/{id}/holds [post]
+func (h *HoldHandler) Place(w http.ResponseWriter, r *http.Request) {
+	bookID, ok := pathID(w, r, "book")
+	if !ok {
+		return
+	}
+	var req PlaceHoldRequest
+	if !decode(w, r, &h.val, &req) {
+		return
+	}
+	hold, err := h.svc.Place(r.Context(), bookID, req)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.Header().Set("location", fmt.Sprintf("%s/%d", "/holds", hold.ID))
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toHoldResponse(hold))
+}
+
+// Get godoc
+// @Summary      Get hold by ID
+// @Description  Retrieve a single hold with its current queue position
+// @Tags         holds
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int   true   "Hold ID"
+// @Success      200    {object}  HoldResponse
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Router       /holds/{id} [get]
+func (h *HoldHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "hold")
+	if !ok {
+		return
+	}
+	hold, err := h.svc.Get(r.Context(), id)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	json.NewEncoder(w).Encode(toHoldResponse(hold))
+}
+
+// Cancel godoc
+// @Summary      Cancel a hold
+// @Description  Leave the queue. A copy already set aside for the hold passes on to the next member
+// @Tags         holds
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int   true   "Hold ID"
+// @Success      204    {object}  nil
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Failure      409    {object}  book.ErrorResponse
+// @Router       /holds/{id} [delete]
+func (h *HoldHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "hold")
+	if !ok {
+		return
+	}
+	if err := h.svc.Cancel(r.Context(), id); err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListByMember godoc
+// @Summary      List a member's holds
+// @Description  Returns the waiting and ready holds of a member, oldest first
+// @Tags         holds
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int   true   "Member ID"
+// @Success      200    {array}   HoldResponse
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Router       /members/{id}/holds [get]
+func (h *HoldHandler) ListByMember(w http.ResponseWriter, r *http.Request) {
+	memberID, ok := pathID(w, r, "member")
+	if !ok {
+		return
+	}
+	holds, err := h.svc.ListByMember(r.Context(), memberID)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	out := make([]HoldResponse, len(holds))
+	for i, hold := range holds {
+		out[i] = toHoldResponse(hold)
+	}
+	json.NewEncoder(w).Encode(out)
+}
+
+func toHoldResponse(h Hold) HoldResponse {
+	resp := HoldResponse{
+		ID:       h.ID,
+		BookID:   h.BookID,
+		MemberID: h.MemberID,
+		Status:   h.Status,
+		PlacedAt: h.PlacedAt.UTC().Format(time.RFC3339),
+		Position: h.Position,
+	}
+	if h.CopyID != nil {
+		resp.CopyID = *h.CopyID
+	}
+	if h.PickupBy != nil {
+		resp.PickupBy = h.PickupBy.Format(time.DateOnly)
+	}
+	if h.ClosedAt != nil {
+		resp.ClosedAt = h.ClosedAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
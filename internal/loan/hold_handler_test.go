@@ -0,0 +1,92 @@
+package loan_test
+
+import (
+	"book-store/internal/loan"
+	mock_book "book-store/internal/mocks"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type HoldHandlerTestSuite struct {
+	suite.Suite
+	holdHandler *loan.HoldHandler
+	mockService *mock_book.MockHoldService
+	ctrl        *gomock.Controller
+}
+
+func TestHoldHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HoldHandlerTestSuite))
+}
+
+func (m *HoldHandlerTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockService = mock_book.NewMockHoldService(m.ctrl)
+	m.holdHandler = loan.NewHoldHandler(m.mockService)
+}
+
+func (m *HoldHandlerTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func (m *HoldHandlerTestSuite) TestPlace_ShouldReturnHoldWithQueuePosition() {
+	r, _ := http.NewRequest("POST", "/books/12/holds", bytes.NewReader([]byte(`{"memberId": 3}`)))
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+	placed := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	m.mockService.EXPECT().Place(r.Context(), 12, loan.PlaceHoldRequest{MemberID: 3}).
+		Return(loan.Hold{ID: 21, BookID: 12, MemberID: 3, Status: "waiting", PlacedAt: placed, Position: 2}, nil)
+
+	m.holdHandler.Place(w, r)
+	m.Suite.Equal(201, w.Result().StatusCode)
+	m.Suite.Equal("/holds/21", w.Result().Header.Get("Location"))
+
+	var body loan.HoldResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal(loan.HoldResponse{ID: 21, BookID: 12, MemberID: 3, Status: "waiting", PlacedAt: "2026-10-17T09:30:00Z", Position: 2}, body)
+}
+
+func (m *HoldHandlerTestSuite) TestPlace_ShouldReturnBadRequestWhenMemberIsMissing() {
+	r, _ := http.NewRequest("POST", "/books/12/holds", bytes.NewReader([]byte(`{}`)))
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+
+	m.holdHandler.Place(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+}
+
+func (m *HoldHandlerTestSuite) TestListByMember_ShouldReturnReadyHoldWithPickupDate() {
+	r, _ := http.NewRequest("GET", "/members/3/holds", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "3"})
+	w := httptest.NewRecorder()
+	copyID := 4
+	pickup := time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC)
+	m.mockService.EXPECT().ListByMember(r.Context(), 3).
+		Return([]loan.Hold{{ID: 22, BookID: 13, MemberID: 3, Status: "ready", CopyID: &copyID, PickupBy: &pickup}}, nil)
+
+	m.holdHandler.ListByMember(w, r)
+	m.Suite.Equal(200, w.Result().StatusCode)
+
+	var body []loan.HoldResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal(4, body[0].CopyID)
+	m.Suite.Equal("2026-10-24", body[0].PickupBy)
+	m.Suite.Zero(body[0].Position)
+}
+
+func (m *HoldHandlerTestSuite) TestCancel_ShouldReturnNoContent() {
+	r, _ := http.NewRequest("DELETE", "/holds/21", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "21"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Cancel(r.Context(), 21).Return(nil)
+
+	m.holdHandler.Cancel(w, r)
+	m.Suite.Equal(204, w.Result().StatusCode)
+}
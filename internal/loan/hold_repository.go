@@ -0,0 +1,289 @@
+package loan
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrHoldNotFound  = errors.New("hold not found")
+	ErrBookNotFound  = errors.New("book not found")
+	ErrHoldExists    = errors.New("member already holds the book")
+	ErrHoldNotNeeded = errors.New("a copy is available")
+	ErrHoldClosed    = errors.New("hold is closed")
+)
+
+const foreignKeyViolation = "23503"
+
+// Every change to the hold queue of a book first locks the book row, so the
+// queue is only ever rearranged by one transaction at a time.
+type HoldRepository interface {
+	// Place queues the member for the book. It is refused while a copy of the
+	// book is available for loan, and for a book in the trash.
+	Place(ctx context.Context, bookID, memberID int) (int64, error)
+	GetByID(ctx context.Context, id int) (Hold, error)
+	// ListByMember returns the waiting and ready holds of the member.
+	ListByMember(ctx context.Context, memberID int) ([]Hold, error)
+	// Cancel closes a waiting or ready hold; a copy set aside for it passes on
+	// to the next member in the queue.
+	Cancel(ctx context.Context, id int, at time.Time) error
+	// Expire closes the ready holds whose pickup window ended before at and
+	// passes their copies on. It returns how many holds were expired.
+	Expire(ctx context.Context, at time.Time) (int, error)
+	WaitingCount(ctx context.Context, bookID int) (int, error)
+}
+
+type sqlHoldRepo struct {
+	db *sql.DB
+}
+
+func NewHoldRepository(db *sql.DB) HoldRepository {
+	return &sqlHoldRepo{db: db}
+}
+
+const holdColumns = `h.id, h.book_id, h.member_id, h.status, h.placed_at, h.copy_id, h.pickup_by, h.closed_at,
+               CASE WHEN h.status = 'waiting' THEN (
+                   SELECT COUNT(*) FROM holds w
+                   WHERE w.book_id = h.book_id AND w.status = 'waiting'
+                     AND (w.placed_at, w.id) <= (h.placed_at, h.id))
+               ELSE 0 END`
+
+func (r *sqlHoldRepo) Place(ctx context.Context, bookID, memberID int) (int64, error) {
+	var id int64
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := lockLendableQueue(ctx, tx, bookID); err != nil {
+			return err
+		}
+		var available int
+		err := tx.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM copies WHERE book_id = $1 AND status = 'available'`, bookID).Scan(&available)
+		if err != nil {
+			return err
+		}
+		if available > 0 {
+			return ErrHoldNotNeeded
+		}
+		return tx.QueryRowContext(ctx,
+			`INSERT INTO holds (book_id, member_id) VALUES ($1, $2) RETURNING id`, bookID, memberID).Scan(&id)
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch {
+			case pqErr.Code == uniqueViolation && pqErr.Constraint == "holds_member_book_open_key":
+				return 0, ErrHoldExists
+			case pqErr.Code == foreignKeyViolation && pqErr.Constraint == "holds_member_id_fkey":
+				return 0, ErrMemberNotFound
+			}
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *sqlHoldRepo) GetByID(ctx context.Context, id int) (Hold, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+holdColumns+` FROM holds h WHERE h.id = $1`, id)
+	if err != nil {
+		return Hold{}, err
+	}
+	holds, err := scanHolds(rows)
+	if err != nil {
+		return Hold{}, err
+	}
+	if len(holds) == 0 {
+		return Hold{}, ErrHoldNotFound
+	}
+	return holds[0], nil
+}
+
+func (r *sqlHoldRepo) ListByMember(ctx context.Context, memberID int) ([]Hold, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT `+holdColumns+`
+        FROM holds h
+        WHERE h.member_id = $1 AND h.status IN ('waiting', 'ready')
+        ORDER BY h.placed_at, h.id`, memberID)
+	if err != nil {
+		return nil, err
+	}
+	return scanHolds(rows)
+}
+
+func (r *sqlHoldRepo) Cancel(ctx context.Context, id int, at time.Time) error {
+	var bookID int
+	err := r.db.QueryRowContext(ctx, `SELECT book_id FROM holds WHERE id = $1`, id).Scan(&bookID)
+	if err == sql.ErrNoRows {
+		return ErrHoldNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := lockQueue(ctx, tx, bookID); err != nil {
+			return err
+		}
+		closed, err := closeHold(ctx, tx, id, "cancelled", bookID, at, func(status string, _ sql.NullTime) bool {
+			return status == "waiting" || status == "ready"
+		})
+		if err == nil && !closed {
+			return ErrHoldClosed
+		}
+		return err
+	})
+}
+
+func (r *sqlHoldRepo) Expire(ctx context.Context, at time.Time) (int, error) {
+	day := today(at)
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, book_id FROM holds WHERE status = 'ready' AND pickup_by < $1 ORDER BY id`, day)
+	if err != nil {
+		return 0, err
+	}
+	type due struct{ id, bookID int }
+	var candidates []due
+	for rows.Next() {
+		var d due
+		if err := rows.Scan(&d.id, &d.bookID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		candidates = append(candidates, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	expired := 0
+	for _, d := range candidates {
+		var closed bool
+		err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+			if err := lockQueue(ctx, tx, d.bookID); err != nil {
+				return err
+			}
+			// The member may have collected the copy since the candidates were read.
+			var err error
+			closed, err = closeHold(ctx, tx, d.id, "expired", d.bookID, at, func(status string, pickupBy sql.NullTime) bool {
+				return status == "ready" && pickupBy.Valid && pickupBy.Time.Before(day)
+			})
+			return err
+		})
+		if err != nil && err != ErrBookNotFound {
+			return expired, err
+		}
+		if err == nil && closed {
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+func (r *sqlHoldRepo) WaitingCount(ctx context.Context, bookID int) (int, error) {
+	var n int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM holds WHERE book_id = $1 AND status = 'waiting'`, bookID).Scan(&n)
+	return n, err
+}
+
+// lockQueue takes the lock that serialises changes to the hold queue of a book.
+func lockQueue(ctx context.Context, tx *sql.Tx, bookID int) error {
+	var locked int
+	err := tx.QueryRowContext(ctx, `SELECT id FROM books WHERE id = $1 FOR UPDATE`, bookID).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return ErrBookNotFound
+	}
+	return err
+}
+
+// lockLendableQueue is lockQueue for lending and holding, which a book in
+// the trash is not open to. Trashing takes the same lock, so the book cannot
+// go to the trash before the caller commits.
+func lockLendableQueue(ctx context.Context, tx *sql.Tx, bookID int) error {
+	var locked int
+	err := tx.QueryRowContext(ctx,
+		`SELECT id FROM books WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, bookID).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return ErrBookNotFound
+	}
+	return err
+}
+
+// closeHold moves the hold to status when open reports that it may be closed,
+// and passes on the copy set aside for it. The caller holds the queue lock.
+func closeHold(ctx context.Context, tx *sql.Tx, id int, status string, bookID int, at time.Time,
+	open func(status string, pickupBy sql.NullTime) bool) (bool, error) {
+	var current string
+	var copyID sql.NullInt64
+	var pickupBy sql.NullTime
+	err := tx.QueryRowContext(ctx,
+		`SELECT status, copy_id, pickup_by FROM holds WHERE id = $1 FOR UPDATE`, id).Scan(&current, &copyID, &pickupBy)
+	if err == sql.ErrNoRows {
+		return false, ErrHoldNotFound
+	}
+	if err != nil || !open(current, pickupBy) {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE holds SET status = $1, closed_at = $2 WHERE id = $3`, status, at, id); err != nil {
+		return false, err
+	}
+	if current != "ready" || !copyID.Valid {
+		return true, nil
+	}
+	return true, ShelveCopy(ctx, tx, bookID, int(copyID.Int64), at)
+}
+
+// ShelveCopy hands a copy that just became free to the first waiting hold of
+// the book, or makes it available when nobody is waiting. The caller holds
+// the queue lock. It is the book.ShelveFunc of the copies catalogued or put
+// back on the shelf by hand.
+func ShelveCopy(ctx context.Context, tx *sql.Tx, bookID, copyID int, at time.Time) error {
+	var holdID int
+	err := tx.QueryRowContext(ctx, `
+        SELECT id FROM holds
+        WHERE book_id = $1 AND status = 'waiting'
+        ORDER BY placed_at, id
+        LIMIT 1`, bookID).Scan(&holdID)
+	if err == sql.ErrNoRows {
+		_, err = tx.ExecContext(ctx, `UPDATE copies SET status = 'available' WHERE id = $1`, copyID)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		`UPDATE holds SET status = 'ready', copy_id = $1, ready_at = $2, pickup_by = $3 WHERE id = $4`,
+		copyID, at, pickupBy(at), holdID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE copies SET status = 'on_hold' WHERE id = $1`, copyID)
+	return err
+}
+
+func scanHolds(rows *sql.Rows) ([]Hold, error) {
+	defer rows.Close()
+	holds := []Hold{}
+	for rows.Next() {
+		h := Hold{}
+		var copyID sql.NullInt64
+		var pickupBy, closedAt sql.NullTime
+		if err := rows.Scan(&h.ID, &h.BookID, &h.MemberID, &h.Status, &h.PlacedAt, &copyID, &pickupBy, &closedAt, &h.Position); err != nil {
+			return nil, err
+		}
+		if copyID.Valid {
+			id := int(copyID.Int64)
+			h.CopyID = &id
+		}
+		if pickupBy.Valid {
+			h.PickupBy = &pickupBy.Time
+		}
+		if closedAt.Valid {
+			h.ClosedAt = &closedAt.Time
+		}
+		holds = append(holds, h)
+	}
+	return holds, rows.Err()
+}
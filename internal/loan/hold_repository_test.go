@@ -0,0 +1,179 @@
+package loan_test
+
+import (
+	"book-store/internal/loan"
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+)
+
+type HoldRepositoryTestSuite struct {
+	suite.Suite
+	holdRepository loan.HoldRepository
+	sqlMock        sqlmock.Sqlmock
+	db             *sql.DB
+}
+
+func TestHoldRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(HoldRepositoryTestSuite))
+}
+
+func (m *HoldRepositoryTestSuite) SetupTest() {
+	m.db, m.sqlMock, _ = sqlmock.New()
+	m.holdRepository = loan.NewHoldRepository(m.db)
+}
+
+func (m *HoldRepositoryTestSuite) expectQueueLocked(bookID int) {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM books WHERE id = $1")).WithArgs(bookID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(bookID))
+}
+
+func (m *HoldRepositoryTestSuite) TestPlace_ShouldQueueMemberWhenNoCopyIsAvailable() {
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM copies WHERE book_id = $1 AND status = 'available'")).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO holds (book_id, member_id) VALUES ($1, $2) RETURNING id")).WithArgs(12, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(21))
+	m.sqlMock.ExpectCommit()
+	id, err := m.holdRepository.Place(context.Background(), 12, 3)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(21), id)
+}
+
+func (m *HoldRepositoryTestSuite) TestPlace_ShouldRefuseABookInTheTrash() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM books WHERE id = $1 AND deleted_at IS NULL FOR UPDATE")).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	m.sqlMock.ExpectRollback()
+	_, err := m.holdRepository.Place(context.Background(), 12, 3)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(loan.ErrBookNotFound, err)
+}
+
+func (m *HoldRepositoryTestSuite) TestPlace_ShouldRefuseWhenACopyIsAvailable() {
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectQuery("FROM copies").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	m.sqlMock.ExpectRollback()
+	_, err := m.holdRepository.Place(context.Background(), 12, 3)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(loan.ErrHoldNotNeeded, err)
+}
+
+func (m *HoldRepositoryTestSuite) TestPlace_ShouldRefuseSecondHoldOfMemberOnTheSameBook() {
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectQuery("FROM copies").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	m.sqlMock.ExpectQuery("INSERT INTO holds").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "holds_member_book_open_key"})
+	m.sqlMock.ExpectRollback()
+	_, err := m.holdRepository.Place(context.Background(), 12, 3)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(loan.ErrHoldExists, err)
+}
+
+func (m *HoldRepositoryTestSuite) TestPlace_ShouldReturnBookNotFoundWhenBookDoesNotExist() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("FROM books").WithArgs(12).WillReturnError(sql.ErrNoRows)
+	m.sqlMock.ExpectRollback()
+	_, err := m.holdRepository.Place(context.Background(), 12, 3)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(loan.ErrBookNotFound, err)
+}
+
+func (m *HoldRepositoryTestSuite) TestListByMember_ShouldReturnQueuePositions() {
+	placed := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	pickup := time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC)
+	m.sqlMock.ExpectQuery("FROM holds h").WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "book_id", "member_id", "status", "placed_at", "copy_id", "pickup_by", "closed_at", "position"}).
+			AddRow(21, 12, 3, "waiting", placed, nil, nil, nil, 2).
+			AddRow(22, 13, 3, "ready", placed, 4, pickup, nil, 0))
+	holds, err := m.holdRepository.ListByMember(context.Background(), 3)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	copyID := 4
+	m.Suite.Equal([]loan.Hold{
+		{ID: 21, BookID: 12, MemberID: 3, Status: "waiting", PlacedAt: placed, Position: 2},
+		{ID: 22, BookID: 13, MemberID: 3, Status: "ready", PlacedAt: placed, CopyID: &copyID, PickupBy: &pickup},
+	}, holds)
+}
+
+func (m *HoldRepositoryTestSuite) TestCancel_ShouldPassSetAsideCopyToNextHold() {
+	at := time.Date(2026, 10, 20, 11, 0, 0, 0, time.UTC)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT book_id FROM holds WHERE id = $1")).WithArgs(21).
+		WillReturnRows(sqlmock.NewRows([]string{"book_id"}).AddRow(12))
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT status, copy_id, pickup_by FROM holds WHERE id = $1 FOR UPDATE")).WithArgs(21).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "copy_id", "pickup_by"}).AddRow("ready", 4, at))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE holds SET status = $1, closed_at = $2 WHERE id = $3")).WithArgs("cancelled", at, 21).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("ORDER BY placed_at, id")).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(22))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE holds SET status = 'ready'")).WithArgs(4, at, sqlmock.AnyArg(), 22).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE copies SET status = 'on_hold'")).WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	err := m.holdRepository.Cancel(context.Background(), 21, at)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+}
+
+func (m *HoldRepositoryTestSuite) TestCancel_ShouldRefuseClosedHold() {
+	m.sqlMock.ExpectQuery("SELECT book_id FROM holds").WillReturnRows(sqlmock.NewRows([]string{"book_id"}).AddRow(12))
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectQuery("FOR UPDATE").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "copy_id", "pickup_by"}).AddRow("fulfilled", 4, nil))
+	m.sqlMock.ExpectRollback()
+	err := m.holdRepository.Cancel(context.Background(), 21, time.Now())
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(loan.ErrHoldClosed, err)
+}
+
+func (m *HoldRepositoryTestSuite) TestExpire_ShouldMakeCopyAvailableWhenQueueIsEmpty() {
+	at := time.Date(2026, 10, 25, 3, 0, 0, 0, time.UTC)
+	pickup := time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id, book_id FROM holds WHERE status = 'ready' AND pickup_by < $1")).
+		WithArgs(time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "book_id"}).AddRow(21, 12))
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectQuery("FOR UPDATE").WithArgs(21).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "copy_id", "pickup_by"}).AddRow("ready", 4, pickup))
+	m.sqlMock.ExpectExec("UPDATE holds SET status = \\$1").WithArgs("expired", at, 21).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("ORDER BY placed_at, id")).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE copies SET status = 'available' WHERE id = $1")).WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	n, err := m.holdRepository.Expire(context.Background(), at)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(1, n)
+}
+
+func (m *HoldRepositoryTestSuite) TestExpire_ShouldSkipHoldCollectedInTheMeantime() {
+	at := time.Date(2026, 10, 25, 3, 0, 0, 0, time.UTC)
+	m.sqlMock.ExpectQuery("SELECT id, book_id FROM holds").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "book_id"}).AddRow(21, 12))
+	m.sqlMock.ExpectBegin()
+	m.expectQueueLocked(12)
+	m.sqlMock.ExpectQuery("FOR UPDATE").WithArgs(21).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "copy_id", "pickup_by"}).AddRow("fulfilled", 4, nil))
+	m.sqlMock.ExpectCommit()
+	n, err := m.holdRepository.Expire(context.Background(), at)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(0, n)
+}
@@ -0,0 +1,125 @@
+package loan
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/member"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type HoldService interface {
+	Place(ctx context.Context, bookID int, req PlaceHoldRequest) (Hold, *book.ErrorResponse)
+	Get(ctx context.Context, id int) (Hold, *book.ErrorResponse)
+	ListByMember(ctx context.Context, memberID int) ([]Hold, *book.ErrorResponse)
+	Cancel(ctx context.Context, id int) *book.ErrorResponse
+	// ExpireUncollected closes the ready holds whose pickup window has passed.
+	ExpireUncollected(ctx context.Context) (int, error)
+}
+
+type holdService struct {
+	repository       HoldRepository
+	memberRepository member.MemberRepository
+}
+
+func NewHoldService(r HoldRepository, mr member.MemberRepository) HoldService {
+	return &holdService{repository: r, memberRepository: mr}
+}
+
+func (s *holdService) Place(ctx context.Context, bookID int, req PlaceHoldRequest) (Hold, *book.ErrorResponse) {
+	if _, errResp := borrower(ctx, s.memberRepository, req.MemberID); errResp != nil {
+		return Hold{}, errResp
+	}
+	id, err := s.repository.Place(ctx, bookID, req.MemberID)
+	if err != nil {
+		switch err {
+		case ErrBookNotFound:
+			logrus.Error("no record found for given id ", bookID)
+			return Hold{}, GetErrorResponseByCode(book.BookNotFound)
+		case ErrMemberNotFound:
+			logrus.Error("no member found for given id ", req.MemberID)
+			return Hold{}, GetErrorResponseByCode(member.MemberNotFound)
+		case ErrHoldExists:
+			logrus.Error("member ", req.MemberID, " already holds book ", bookID)
+			return Hold{}, GetErrorResponseByCode(HoldAlreadyPlaced)
+		case ErrHoldNotNeeded:
+			logrus.Error("book ", bookID, " has copies available, no hold placed")
+			return Hold{}, GetErrorResponseByCode(HoldNotNeeded)
+		}
+		logrus.Error("error while placing hold on book ", bookID, " error is ", err)
+		return Hold{}, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return s.Get(ctx, int(id))
+}
+
+func (s *holdService) Get(ctx context.Context, id int) (Hold, *book.ErrorResponse) {
+	h, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		if err == ErrHoldNotFound {
+			logrus.Error("no hold found for given id ", id)
+			return Hold{}, GetErrorResponseByCode(HoldNotFound)
+		}
+		logrus.Error("error while fetching the hold for id ", id, " error is ", err)
+		return Hold{}, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return h, nil
+}
+
+func (s *holdService) ListByMember(ctx context.Context, memberID int) ([]Hold, *book.ErrorResponse) {
+	if _, err := s.memberRepository.GetByID(ctx, memberID); err != nil {
+		if err == member.ErrNotFound {
+			logrus.Error("no member found for given id ", memberID)
+			return nil, GetErrorResponseByCode(member.MemberNotFound)
+		}
+		logrus.Error("error while fetching the member for id ", memberID, " error is ", err)
+		return nil, GetErrorResponseByCode(book.InternalServerError)
+	}
+	holds, err := s.repository.ListByMember(ctx, memberID)
+	if err != nil {
+		logrus.Error("error while fetching holds of member ", memberID, " error is ", err)
+		return nil, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return holds, nil
+}
+
+func (s *holdService) Cancel(ctx context.Context, id int) *book.ErrorResponse {
+	if err := s.repository.Cancel(ctx, id, time.Now().UTC()); err != nil {
+		switch err {
+		case ErrHoldNotFound:
+			logrus.Error("no hold found for given id ", id)
+			return GetErrorResponseByCode(HoldNotFound)
+		case ErrHoldClosed:
+			logrus.Error("hold ", id, " is no longer active")
+			return GetErrorResponseByCode(HoldClosed)
+		}
+		logrus.Error("error while cancelling hold ", id, " error is ", err)
+		return GetErrorResponseByCode(book.InternalServerError)
+	}
+	return nil
+}
+
+func (s *holdService) ExpireUncollected(ctx context.Context) (int, error) {
+	return s.repository.Expire(ctx, time.Now().UTC())
+}
+
+// RunHoldExpiry expires uncollected holds right away and then every interval
+// until ctx is done, so waiting members move up the queue.
+func RunHoldExpiry(ctx context.Context, svc HoldService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := svc.ExpireUncollected(ctx)
+		if err != nil && ctx.Err() == nil {
+			logrus.Error("error while expiring holds. error is ", err)
+		}
+		if n > 0 {
+			logrus.Info("expired ", n, " uncollected holds")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
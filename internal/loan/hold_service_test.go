@@ -0,0 +1,78 @@
+package loan_test
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/loan"
+	"book-store/internal/member"
+	mock_book "book-store/internal/mocks"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type HoldServiceTestSuite struct {
+	suite.Suite
+	holdService    loan.HoldService
+	mockRepo       *mock_book.MockHoldRepository
+	mockMemberRepo *mock_book.MockMemberRepository
+	ctrl           *gomock.Controller
+}
+
+func TestHoldServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(HoldServiceTestSuite))
+}
+
+func (m *HoldServiceTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockRepo = mock_book.NewMockHoldRepository(m.ctrl)
+	m.mockMemberRepo = mock_book.NewMockMemberRepository(m.ctrl)
+	m.holdService = loan.NewHoldService(m.mockRepo, m.mockMemberRepo)
+}
+
+func (m *HoldServiceTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func (m *HoldServiceTestSuite) TestPlace_ShouldReturnPlacedHold() {
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(activeMember(member.MembershipStandard), nil)
+	m.mockRepo.EXPECT().Place(context.Background(), 12, 3).Return(int64(21), nil)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 21).Return(loan.Hold{ID: 21, Position: 1}, nil)
+	h, err := m.holdService.Place(context.Background(), 12, loan.PlaceHoldRequest{MemberID: 3})
+	m.Suite.Nil(err)
+	m.Suite.Equal(1, h.Position)
+}
+
+func (m *HoldServiceTestSuite) TestPlace_ShouldMapRepositoryErrors() {
+	for err, code := range map[error]book.ErrorCode{
+		loan.ErrBookNotFound:  book.BookNotFound,
+		loan.ErrHoldExists:    loan.HoldAlreadyPlaced,
+		loan.ErrHoldNotNeeded: loan.HoldNotNeeded,
+	} {
+		m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(activeMember(member.MembershipStandard), nil)
+		m.mockRepo.EXPECT().Place(context.Background(), 12, 3).Return(int64(0), err)
+		_, errResp := m.holdService.Place(context.Background(), 12, loan.PlaceHoldRequest{MemberID: 3})
+		m.Suite.Equal(code, errResp.ErrorCode)
+	}
+}
+
+func (m *HoldServiceTestSuite) TestPlace_ShouldRefuseExpiredMembership() {
+	expired := activeMember(member.MembershipStandard)
+	expired.ExpiresOn = today().AddDate(0, 0, -1)
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(expired, nil)
+	_, err := m.holdService.Place(context.Background(), 12, loan.PlaceHoldRequest{MemberID: 3})
+	m.Suite.Equal(loan.GetErrorResponseByCode(loan.MembershipExpired), err)
+}
+
+func (m *HoldServiceTestSuite) TestListByMember_ShouldReturnNotFoundForUnknownMember() {
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(member.Member{}, member.ErrNotFound)
+	_, err := m.holdService.ListByMember(context.Background(), 3)
+	m.Suite.Equal(member.GetErrorResponseByCode(member.MemberNotFound), err)
+}
+
+func (m *HoldServiceTestSuite) TestCancel_ShouldReturnConflictForClosedHold() {
+	m.mockRepo.EXPECT().Cancel(context.Background(), 21, gomock.Any()).Return(loan.ErrHoldClosed)
+	err := m.holdService.Cancel(context.Background(), 21)
+	m.Suite.Equal(loan.GetErrorResponseByCode(loan.HoldClosed), err)
+}
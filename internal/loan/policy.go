@@ -0,0 +1,37 @@
+package loan
+
+import (
+	"book-store/internal/member"
+	"time"
+)
+
+// Policy holds the lending rules of a membership type.
+type Policy struct {
+	LoanDays    int
+	MaxLoans    int
+	MaxRenewals int
+}
+
+var policies = map[string]Policy{
+	member.MembershipStandard: {LoanDays: 21, MaxLoans: 5, MaxRenewals: 2},
+	member.MembershipStudent:  {LoanDays: 14, MaxLoans: 3, MaxRenewals: 1},
+	member.MembershipSenior:   {LoanDays: 28, MaxLoans: 5, MaxRenewals: 2},
+	member.MembershipStaff:    {LoanDays: 42, MaxLoans: 15, MaxRenewals: 3},
+}
+
+// PolicyFor returns the lending rules for the membership type, falling back
+// to the standard ones for unknown types.
+func PolicyFor(membershipType string) Policy {
+	if p, ok := policies[membershipType]; ok {
+		return p
+	}
+	return policies[member.MembershipStandard]
+}
+
+// PickupDays is how long a copy waits on the hold shelf for the member.
+const PickupDays = 7
+
+// pickupBy returns the last day a copy set aside now can be collected.
+func pickupBy(now time.Time) time.Time {
+	return today(now).AddDate(0, 0, PickupDays)
+}
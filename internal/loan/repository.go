@@ -0,0 +1,240 @@
+package loan
+
+import (
+	"book-store/internal/fine"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrNotFound        = errors.New("loan not found")
+	ErrCopyNotFound    = errors.New("copy not found")
+	ErrMemberNotFound  = errors.New("member not found")
+	ErrCopyUnavailable = errors.New("copy is not available")
+	ErrLimitReached    = errors.New("loan limit reached")
+	ErrAlreadyReturned = errors.New("loan already returned")
+	ErrConflict        = errors.New("loan changed concurrently")
+)
+
+const uniqueViolation = "23505"
+
+type LoanRepository interface {
+	// Checkout lends the copy with the given barcode to the member, provided the
+	// copy is available and the member has fewer than maxLoans open loans.
+	Checkout(ctx context.Context, memberID int, barcode string, dueOn time.Time, maxLoans int) (int64, error)
+	GetByID(ctx context.Context, id int) (Loan, error)
+	// Return closes the loan due on dueOn and charges the member fine cents
+	// with it. A loan whose due date moved in the meantime is not returned.
+	Return(ctx context.Context, id int, at time.Time, dueOn time.Time, fine int64) error
+	// Renew moves the due date of an open loan, provided it has been renewed
+	// exactly renewals times so far.
+	Renew(ctx context.Context, id int, renewals int, dueOn time.Time) error
+}
+
+type sqlLoanRepo struct {
+	db *sql.DB
+}
+
+func NewLoanRepository(db *sql.DB) LoanRepository {
+	return &sqlLoanRepo{db: db}
+}
+
+// Checkout locks the member row first so concurrent checkouts of one member
+// cannot both slip under the limit, then the hold queue of the book and the
+// copy so it cannot be lent twice. The partial unique index on open loans backs
+// the latter up. A copy on the hold shelf only goes to the member it is held
+// for, and borrowing a title closes the member's hold on it. Copies of books
+// in the trash are not found.
+func (r *sqlLoanRepo) Checkout(ctx context.Context, memberID int, barcode string, dueOn time.Time, maxLoans int) (int64, error) {
+	var id int64
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var locked int
+		err := tx.QueryRowContext(ctx, `SELECT id FROM members WHERE id = $1 FOR UPDATE`, memberID).Scan(&locked)
+		if err == sql.ErrNoRows {
+			return ErrMemberNotFound
+		}
+		if err != nil {
+			return err
+		}
+		var bookID int
+		err = tx.QueryRowContext(ctx, `
+            SELECT c.book_id FROM copies c JOIN books b ON b.id = c.book_id
+            WHERE c.barcode = $1 AND b.deleted_at IS NULL`, barcode).Scan(&bookID)
+		if err == sql.ErrNoRows {
+			return ErrCopyNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := lockLendableQueue(ctx, tx, bookID); err == ErrBookNotFound {
+			return ErrCopyNotFound
+		} else if err != nil {
+			return err
+		}
+		var copyID int
+		var status string
+		err = tx.QueryRowContext(ctx,
+			`SELECT id, status FROM copies WHERE barcode = $1 FOR UPDATE`, barcode).Scan(&copyID, &status)
+		if err == sql.ErrNoRows {
+			return ErrCopyNotFound
+		}
+		if err != nil {
+			return err
+		}
+		switch status {
+		case "available":
+		case "on_hold":
+			var holdID int
+			err = tx.QueryRowContext(ctx,
+				`SELECT id FROM holds WHERE copy_id = $1 AND member_id = $2 AND status = 'ready'`, copyID, memberID).Scan(&holdID)
+			if err == sql.ErrNoRows {
+				return ErrCopyUnavailable
+			}
+			if err != nil {
+				return err
+			}
+		default:
+			return ErrCopyUnavailable
+		}
+		var open int
+		err = tx.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM loans WHERE member_id = $1 AND returned_at IS NULL`, memberID).Scan(&open)
+		if err != nil {
+			return err
+		}
+		if open >= maxLoans {
+			return ErrLimitReached
+		}
+		err = tx.QueryRowContext(ctx,
+			`INSERT INTO loans (copy_id, member_id, due_on) VALUES ($1, $2, $3) RETURNING id`,
+			copyID, memberID, dueOn).Scan(&id)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE copies SET status = 'on_loan' WHERE id = $1`, copyID); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+            UPDATE holds SET status = 'fulfilled', closed_at = now()
+            WHERE member_id = $1 AND book_id = $2
+              AND (status = 'waiting' OR (status = 'ready' AND copy_id = $3))`, memberID, bookID, copyID)
+		return err
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == "loans_copy_id_open_key" {
+			return 0, ErrCopyUnavailable
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *sqlLoanRepo) GetByID(ctx context.Context, id int) (Loan, error) {
+	l := Loan{}
+	var returnedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, `
+        SELECT l.id, l.copy_id, l.member_id, l.loaned_at, l.due_on, l.returned_at, l.renewals, c.book_id, c.barcode,
+               COALESCE(f.amount_cents, 0)
+        FROM loans l
+        JOIN copies c ON c.id = l.copy_id
+        LEFT JOIN ledger_entries f ON f.loan_id = l.id AND f.kind = 'charge'
+        WHERE l.id = $1`, id).
+		Scan(&l.ID, &l.CopyID, &l.MemberID, &l.LoanedAt, &l.DueOn, &returnedAt, &l.Renewals, &l.BookID, &l.Barcode, &l.FineCents)
+	if err == sql.ErrNoRows {
+		return Loan{}, ErrNotFound
+	}
+	if err != nil {
+		return Loan{}, err
+	}
+	if returnedAt.Valid {
+		l.ReturnedAt = &returnedAt.Time
+	}
+	return l, nil
+}
+
+// Return closes the loan and hands the copy to the first waiting hold, or puts
+// it back on the shelf when nobody is queueing. Copies marked lost or in repair
+// while out keep their status. The fine is recorded in the same transaction.
+func (r *sqlLoanRepo) Return(ctx context.Context, id int, at time.Time, dueOn time.Time, fineCents int64) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var copyID, bookID int
+		var returnedAt sql.NullTime
+		err := tx.QueryRowContext(ctx, `
+            SELECT l.copy_id, c.book_id, l.returned_at
+            FROM loans l
+            JOIN copies c ON c.id = l.copy_id
+            WHERE l.id = $1`, id).Scan(&copyID, &bookID, &returnedAt)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := lockQueue(ctx, tx, bookID); err != nil {
+			return err
+		}
+		var memberID int
+		var lockedDueOn time.Time
+		err = tx.QueryRowContext(ctx,
+			`SELECT returned_at, member_id, due_on FROM loans WHERE id = $1 FOR UPDATE`, id).Scan(&returnedAt, &memberID, &lockedDueOn)
+		if err != nil {
+			return err
+		}
+		if returnedAt.Valid {
+			return ErrAlreadyReturned
+		}
+		if !lockedDueOn.Equal(dueOn) {
+			return ErrConflict
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE loans SET returned_at = $1 WHERE id = $2`, at, id); err != nil {
+			return err
+		}
+		if fineCents > 0 {
+			note := fmt.Sprintf("overdue fine for loan %d", id)
+			if err := fine.Charge(ctx, tx, memberID, id, fineCents, note); err != nil {
+				return err
+			}
+		}
+		var status string
+		err = tx.QueryRowContext(ctx, `SELECT status FROM copies WHERE id = $1 FOR UPDATE`, copyID).Scan(&status)
+		if err != nil || status != "on_loan" {
+			return err
+		}
+		return ShelveCopy(ctx, tx, bookID, copyID, at)
+	})
+}
+
+func (r *sqlLoanRepo) Renew(ctx context.Context, id int, renewals int, dueOn time.Time) error {
+	res, err := r.db.ExecContext(ctx, `
+        UPDATE loans SET due_on = $1, renewals = renewals + 1
+        WHERE id = $2 AND returned_at IS NULL AND renewals = $3`, dueOn, id, renewals)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrConflict
+	}
+	return nil
+}
+
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
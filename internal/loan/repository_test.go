@@ -0,0 +1,216 @@
+package loan_test
+
+import (
+	"book-store/internal/loan"
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoanRepositoryTestSuite struct {
+	suite.Suite
+	loanRepository loan.LoanRepository
+	sqlMock        sqlmock.Sqlmock
+	db             *sql.DB
+}
+
+func TestLoanRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(LoanRepositoryTestSuite))
+}
+
+func (m *LoanRepositoryTestSuite) SetupTest() {
+	m.db, m.sqlMock, _ = sqlmock.New()
+	m.loanRepository = loan.NewLoanRepository(m.db)
+}
+
+var dueOn = time.Date(2026, 11, 7, 0, 0, 0, 0, time.UTC)
+
+func (m *LoanRepositoryTestSuite) TestCheckout_ShouldLendCopyAndMarkItOnLoan() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM members WHERE id = $1 FOR UPDATE")).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT c.book_id FROM copies c JOIN books b ON b.id = c.book_id WHERE c.barcode = $1 AND b.deleted_at IS NULL")).WithArgs("BC-001").
+		WillReturnRows(sqlmock.NewRows([]string{"book_id"}).AddRow(12))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM books WHERE id = $1 AND deleted_at IS NULL FOR UPDATE")).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id, status FROM copies WHERE barcode = $1 FOR UPDATE")).WithArgs("BC-001").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(4, "available"))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM loans WHERE member_id = $1 AND returned_at IS NULL")).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	m.sqlMock.ExpectQuery("INSERT INTO loans").WithArgs(4, 3, dueOn).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE copies SET status = 'on_loan' WHERE id = $1")).WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec("UPDATE holds SET status = 'fulfilled'").WithArgs(3, 12, 4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectCommit()
+	id, err := m.loanRepository.Checkout(context.Background(), 3, "BC-001", dueOn, 5)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(9), id)
+}
+
+func (m *LoanRepositoryTestSuite) TestCheckout_ShouldRefuseCopyThatIsNotAvailable() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("FROM members").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	m.sqlMock.ExpectQuery("SELECT c.book_id FROM copies").WillReturnRows(sqlmock.NewRows([]string{"book_id"}).AddRow(12))
+	m.sqlMock.ExpectQuery("FROM books").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+	m.sqlMock.ExpectQuery("SELECT id, status FROM copies").WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(4, "on_loan"))
+	m.sqlMock.ExpectRollback()
+	_, err := m.loanRepository.Checkout(context.Background(), 3, "BC-001", dueOn, 5)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(loan.ErrCopyUnavailable, err)
+}
+
+func (m *LoanRepositoryTestSuite) TestCheckout_ShouldRefuseWhenMemberIsAtTheLimit() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("FROM members").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	m.sqlMock.ExpectQuery("SELECT c.book_id FROM copies").WillReturnRows(sqlmock.NewRows([]string{"book_id"}).AddRow(12))
+	m.sqlMock.ExpectQuery("FROM books").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+	m.sqlMock.ExpectQuery("SELECT id, status FROM copies").WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(4, "available"))
+	m.sqlMock.ExpectQuery("FROM loans").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	m.sqlMock.ExpectRollback()
+	_, err := m.loanRepository.Checkout(context.Background(), 3, "BC-001", dueOn, 5)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(loan.ErrLimitReached, err)
+}
+
+func (m *LoanRepositoryTestSuite) TestCheckout_ShouldTreatOpenLoanIndexViolationAsUnavailable() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("FROM members").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	m.sqlMock.ExpectQuery("SELECT c.book_id FROM copies").WillReturnRows(sqlmock.NewRows([]string{"book_id"}).AddRow(12))
+	m.sqlMock.ExpectQuery("FROM books").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+	m.sqlMock.ExpectQuery("SELECT id, status FROM copies").WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(4, "available"))
+	m.sqlMock.ExpectQuery("FROM loans").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	m.sqlMock.ExpectQuery("INSERT INTO loans").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "loans_copy_id_open_key"})
+	m.sqlMock.ExpectRollback()
+	_, err := m.loanRepository.Checkout(context.Background(), 3, "BC-001", dueOn, 5)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(loan.ErrCopyUnavailable, err)
+}
+
+var returnDueOn = time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)
+
+func (m *LoanRepositoryTestSuite) expectReturnedLoanLocked(returnedAt any) {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("SELECT l.copy_id, c.book_id, l.returned_at").WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"copy_id", "book_id", "returned_at"}).AddRow(4, 12, returnedAt))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM books WHERE id = $1 FOR UPDATE")).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT returned_at, member_id, due_on FROM loans WHERE id = $1 FOR UPDATE")).WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"returned_at", "member_id", "due_on"}).AddRow(returnedAt, 3, returnDueOn))
+}
+
+func (m *LoanRepositoryTestSuite) TestReturn_ShouldRefuseLoanThatWasAlreadyReturned() {
+	m.expectReturnedLoanLocked(time.Now())
+	m.sqlMock.ExpectRollback()
+	err := m.loanRepository.Return(context.Background(), 9, time.Now(), returnDueOn, 0)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(loan.ErrAlreadyReturned, err)
+}
+
+func (m *LoanRepositoryTestSuite) TestReturn_ShouldShelveCopyWhenNobodyIsWaiting() {
+	at := time.Date(2026, 11, 1, 16, 5, 0, 0, time.UTC)
+	m.expectReturnedLoanLocked(nil)
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE loans SET returned_at = $1 WHERE id = $2")).WithArgs(at, 9).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT status FROM copies WHERE id = $1 FOR UPDATE")).WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("on_loan"))
+	m.sqlMock.ExpectQuery("FROM holds").WithArgs(12).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE copies SET status = 'available' WHERE id = $1")).WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	err := m.loanRepository.Return(context.Background(), 9, at, returnDueOn, 0)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+}
+
+func (m *LoanRepositoryTestSuite) TestReturn_ShouldChargeTheFineWithTheReturn() {
+	at := time.Date(2026, 11, 1, 16, 5, 0, 0, time.UTC)
+	m.expectReturnedLoanLocked(nil)
+	m.sqlMock.ExpectExec("UPDATE loans SET returned_at").WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO ledger_entries (member_id, kind, amount_cents, loan_id, note) VALUES ($1, 'charge', $2, $3, $4)")).
+		WithArgs(3, int64(175), 9, "overdue fine for loan 9").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectQuery("SELECT status FROM copies").WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("lost"))
+	m.sqlMock.ExpectCommit()
+	err := m.loanRepository.Return(context.Background(), 9, at, returnDueOn, 175)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+}
+
+func (m *LoanRepositoryTestSuite) TestReturn_ShouldRefuseWhenTheDueDateMoved() {
+	m.expectReturnedLoanLocked(nil)
+	m.sqlMock.ExpectRollback()
+	err := m.loanRepository.Return(context.Background(), 9, time.Now(), returnDueOn.AddDate(0, 0, -7), 175)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(loan.ErrConflict, err)
+}
+
+func (m *LoanRepositoryTestSuite) TestReturn_ShouldSetCopyAsideForFirstWaitingHold() {
+	at := time.Date(2026, 11, 1, 16, 5, 0, 0, time.UTC)
+	m.expectReturnedLoanLocked(nil)
+	m.sqlMock.ExpectExec("UPDATE loans SET returned_at").WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectQuery("SELECT status FROM copies").WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("on_loan"))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("ORDER BY placed_at, id")).WithArgs(12).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(21))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE holds SET status = 'ready'")).
+		WithArgs(4, at, time.Date(2026, 11, 8, 0, 0, 0, 0, time.UTC), 21).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE copies SET status = 'on_hold' WHERE id = $1")).WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	err := m.loanRepository.Return(context.Background(), 9, at, returnDueOn, 0)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+}
+
+func (m *LoanRepositoryTestSuite) TestReturn_ShouldLeaveCopyMarkedLostAlone() {
+	m.expectReturnedLoanLocked(nil)
+	m.sqlMock.ExpectExec("UPDATE loans SET returned_at").WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectQuery("SELECT status FROM copies").WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("lost"))
+	m.sqlMock.ExpectCommit()
+	err := m.loanRepository.Return(context.Background(), 9, time.Now(), returnDueOn, 0)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+}
+
+func (m *LoanRepositoryTestSuite) TestCheckout_ShouldNotFindCopiesOfTrashedBooks() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("FROM members").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	m.sqlMock.ExpectQuery("SELECT c.book_id FROM copies").WillReturnRows(sqlmock.NewRows([]string{"book_id"}).AddRow(12))
+	m.sqlMock.ExpectQuery("FROM books").WithArgs(12).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	m.sqlMock.ExpectRollback()
+	_, err := m.loanRepository.Checkout(context.Background(), 3, "BC-001", dueOn, 5)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(loan.ErrCopyNotFound, err)
+}
+
+func (m *LoanRepositoryTestSuite) TestCheckout_ShouldOnlyLendHeldCopyToTheMemberItIsHeldFor() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectQuery("FROM members").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	m.sqlMock.ExpectQuery("SELECT c.book_id FROM copies").WillReturnRows(sqlmock.NewRows([]string{"book_id"}).AddRow(12))
+	m.sqlMock.ExpectQuery("FROM books").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+	m.sqlMock.ExpectQuery("SELECT id, status FROM copies").WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(4, "on_hold"))
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM holds WHERE copy_id = $1 AND member_id = $2 AND status = 'ready'")).
+		WithArgs(4, 3).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	m.sqlMock.ExpectRollback()
+	_, err := m.loanRepository.Checkout(context.Background(), 3, "BC-001", dueOn, 5)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(loan.ErrCopyUnavailable, err)
+}
+
+func (m *LoanRepositoryTestSuite) TestRenew_ShouldReturnConflictWhenLoanChanged() {
+	m.sqlMock.ExpectExec("UPDATE loans SET due_on").WithArgs(dueOn, 9, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err := m.loanRepository.Renew(context.Background(), 9, 1, dueOn)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(loan.ErrConflict, err)
+}
@@ -0,0 +1,185 @@
+package loan
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/config"
+	"book-store/internal/fine"
+	"book-store/internal/member"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type LoanService interface {
+	Checkout(ctx context.Context, req CheckoutRequest) (Loan, *book.ErrorResponse)
+	Get(ctx context.Context, id int) (Loan, *book.ErrorResponse)
+	Return(ctx context.Context, id int) (Loan, *book.ErrorResponse)
+	Renew(ctx context.Context, id int) (Loan, *book.ErrorResponse)
+}
+
+type loanService struct {
+	repository       LoanRepository
+	holdRepository   HoldRepository
+	memberRepository member.MemberRepository
+	ledgerRepository fine.LedgerRepository
+	fines            config.FinesConfig
+}
+
+func NewLoanService(r LoanRepository, hr HoldRepository, mr member.MemberRepository, lr fine.LedgerRepository, fines config.FinesConfig) LoanService {
+	return &loanService{repository: r, holdRepository: hr, memberRepository: mr, ledgerRepository: lr, fines: fines}
+}
+
+func (s *loanService) Checkout(ctx context.Context, req CheckoutRequest) (Loan, *book.ErrorResponse) {
+	m, errResp := borrower(ctx, s.memberRepository, req.MemberID)
+	if errResp != nil {
+		return Loan{}, errResp
+	}
+	owed, err := s.ledgerRepository.Balance(ctx, m.ID)
+	if err != nil {
+		logrus.Error("error while computing the balance of member ", m.ID, " error is ", err)
+		return Loan{}, GetErrorResponseByCode(book.InternalServerError)
+	}
+	if fine.BlocksCheckout(s.fines, owed) {
+		logrus.Error("member ", m.ID, " owes ", owed, " cents in fines")
+		return Loan{}, GetErrorResponseByCode(FinesOutstanding)
+	}
+	policy := PolicyFor(m.MembershipType)
+	dueOn := today(time.Now()).AddDate(0, 0, policy.LoanDays)
+	id, err := s.repository.Checkout(ctx, m.ID, req.Barcode, dueOn, policy.MaxLoans)
+	if err != nil {
+		switch err {
+		case ErrMemberNotFound:
+			logrus.Error("no member found for given id ", req.MemberID)
+			return Loan{}, GetErrorResponseByCode(member.MemberNotFound)
+		case ErrCopyNotFound:
+			logrus.Error("no copy found for barcode ", req.Barcode)
+			return Loan{}, GetErrorResponseByCode(book.CopyNotFound)
+		case ErrCopyUnavailable:
+			logrus.Error("copy ", req.Barcode, " is not available")
+			return Loan{}, GetErrorResponseByCode(CopyNotAvailable)
+		case ErrLimitReached:
+			logrus.Error("member ", m.ID, " has reached the limit of ", policy.MaxLoans, " loans")
+			return Loan{}, GetErrorResponseByCode(LoanLimitReached)
+		}
+		logrus.Error("error while checking out copy ", req.Barcode, " error is ", err)
+		return Loan{}, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return s.Get(ctx, int(id))
+}
+
+func (s *loanService) Get(ctx context.Context, id int) (Loan, *book.ErrorResponse) {
+	l, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		if err == ErrNotFound {
+			logrus.Error("no loan found for given id ", id)
+			return Loan{}, GetErrorResponseByCode(LoanNotFound)
+		}
+		logrus.Error("error while fetching the loan for id ", id, " error is ", err)
+		return Loan{}, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return l, nil
+}
+
+// Return closes the loan and fines the member when it comes back after the
+// grace period. Suspended and expired members may still return their loans.
+func (s *loanService) Return(ctx context.Context, id int) (Loan, *book.ErrorResponse) {
+	l, errResp := s.Get(ctx, id)
+	if errResp != nil {
+		return Loan{}, errResp
+	}
+	if l.ReturnedAt != nil {
+		logrus.Error("loan ", id, " has already been returned")
+		return Loan{}, GetErrorResponseByCode(LoanAlreadyReturned)
+	}
+	m, err := s.memberRepository.GetByID(ctx, l.MemberID)
+	if err != nil {
+		logrus.Error("error while fetching the member for id ", l.MemberID, " error is ", err)
+		return Loan{}, GetErrorResponseByCode(book.InternalServerError)
+	}
+	at := time.Now().UTC()
+	fineCents := fine.Assess(s.fines, m.MembershipType, l.DueOn, at)
+	if err := s.repository.Return(ctx, id, at, l.DueOn, fineCents); err != nil {
+		switch err {
+		case ErrNotFound:
+			logrus.Error("no loan found for given id ", id)
+			return Loan{}, GetErrorResponseByCode(LoanNotFound)
+		case ErrAlreadyReturned:
+			logrus.Error("loan ", id, " has already been returned")
+			return Loan{}, GetErrorResponseByCode(LoanAlreadyReturned)
+		case ErrConflict:
+			logrus.Error("loan ", id, " changed while returning")
+			return Loan{}, GetErrorResponseByCode(LoanConflict)
+		}
+		logrus.Error("error while returning loan ", id, " error is ", err)
+		return Loan{}, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return s.Get(ctx, id)
+}
+
+// Renew extends an open loan by another loan period counted from today. The
+// due date never moves backwards, only members in good standing may renew and
+// a loan is not renewed while other members wait for the book.
+func (s *loanService) Renew(ctx context.Context, id int) (Loan, *book.ErrorResponse) {
+	l, errResp := s.Get(ctx, id)
+	if errResp != nil {
+		return Loan{}, errResp
+	}
+	if l.ReturnedAt != nil {
+		logrus.Error("loan ", id, " has already been returned")
+		return Loan{}, GetErrorResponseByCode(LoanAlreadyReturned)
+	}
+	m, errResp := borrower(ctx, s.memberRepository, l.MemberID)
+	if errResp != nil {
+		return Loan{}, errResp
+	}
+	policy := PolicyFor(m.MembershipType)
+	if l.Renewals >= policy.MaxRenewals {
+		logrus.Error("loan ", id, " has been renewed ", l.Renewals, " times already")
+		return Loan{}, GetErrorResponseByCode(RenewalLimitReached)
+	}
+	waiting, err := s.holdRepository.WaitingCount(ctx, l.BookID)
+	if err != nil {
+		logrus.Error("error while counting holds of book ", l.BookID, " error is ", err)
+		return Loan{}, GetErrorResponseByCode(book.InternalServerError)
+	}
+	if waiting > 0 {
+		logrus.Error("loan ", id, " cannot be renewed, ", waiting, " members wait for book ", l.BookID)
+		return Loan{}, GetErrorResponseByCode(HoldsPending)
+	}
+	dueOn := today(time.Now()).AddDate(0, 0, policy.LoanDays)
+	if dueOn.Before(l.DueOn) {
+		dueOn = l.DueOn
+	}
+	if err := s.repository.Renew(ctx, id, l.Renewals, dueOn); err != nil {
+		if err == ErrConflict {
+			logrus.Error("loan ", id, " changed while renewing")
+			return Loan{}, GetErrorResponseByCode(LoanConflict)
+		}
+		logrus.Error("error while renewing loan ", id, " error is ", err)
+		return Loan{}, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return s.Get(ctx, id)
+}
+
+// borrower loads the member and makes sure they may borrow today.
+func borrower(ctx context.Context, mr member.MemberRepository, id int) (member.Member, *book.ErrorResponse) {
+	m, err := mr.GetByID(ctx, id)
+	if err != nil {
+		if err == member.ErrNotFound {
+			logrus.Error("no member found for given id ", id)
+			return member.Member{}, GetErrorResponseByCode(member.MemberNotFound)
+		}
+		logrus.Error("error while fetching the member for id ", id, " error is ", err)
+		return member.Member{}, GetErrorResponseByCode(book.InternalServerError)
+	}
+	switch m.Status(time.Now()) {
+	case member.StatusSuspended:
+		logrus.Error("member ", id, " is suspended")
+		return member.Member{}, GetErrorResponseByCode(MemberSuspended)
+	case member.StatusExpired:
+		logrus.Error("membership of member ", id, " has expired")
+		return member.Member{}, GetErrorResponseByCode(MembershipExpired)
+	}
+	return m, nil
+}
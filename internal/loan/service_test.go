@@ -0,0 +1,195 @@
+package loan_test
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/config"
+	"book-store/internal/loan"
+	"book-store/internal/member"
+	mock_book "book-store/internal/mocks"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoanServiceTestSuite struct {
+	suite.Suite
+	loanService    loan.LoanService
+	mockRepo       *mock_book.MockLoanRepository
+	mockHoldRepo   *mock_book.MockHoldRepository
+	mockMemberRepo *mock_book.MockMemberRepository
+	mockLedgerRepo *mock_book.MockLedgerRepository
+	ctrl           *gomock.Controller
+}
+
+func TestLoanServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(LoanServiceTestSuite))
+}
+
+func (m *LoanServiceTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockRepo = mock_book.NewMockLoanRepository(m.ctrl)
+	m.mockHoldRepo = mock_book.NewMockHoldRepository(m.ctrl)
+	m.mockMemberRepo = mock_book.NewMockMemberRepository(m.ctrl)
+	m.mockLedgerRepo = mock_book.NewMockLedgerRepository(m.ctrl)
+	m.loanService = loan.NewLoanService(m.mockRepo, m.mockHoldRepo, m.mockMemberRepo, m.mockLedgerRepo, fines)
+}
+
+func (m *LoanServiceTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+var fines = config.FinesConfig{
+	GraceDays:           1,
+	BlockThresholdCents: 1000,
+	Rates: map[string]config.FineRate{
+		member.MembershipStandard: {DailyRateCents: 25, MaxPerLoanCents: 1000},
+		member.MembershipStudent:  {DailyRateCents: 10, MaxPerLoanCents: 50},
+	},
+}
+
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+func activeMember(membershipType string) member.Member {
+	return member.Member{ID: 3, MembershipType: membershipType, ExpiresOn: today().AddDate(1, 0, 0)}
+}
+
+func (m *LoanServiceTestSuite) TestCheckout_ShouldUseThePolicyOfTheMembershipType() {
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(activeMember(member.MembershipStudent), nil)
+	m.mockLedgerRepo.EXPECT().Balance(context.Background(), 3).Return(int64(0), nil)
+	m.mockRepo.EXPECT().Checkout(context.Background(), 3, "BC-001", today().AddDate(0, 0, 14), 3).Return(int64(9), nil)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9}, nil)
+	l, err := m.loanService.Checkout(context.Background(), loan.CheckoutRequest{MemberID: 3, Barcode: "BC-001"})
+	m.Suite.Nil(err)
+	m.Suite.Equal(9, l.ID)
+}
+
+func (m *LoanServiceTestSuite) TestCheckout_ShouldRefuseSuspendedMember() {
+	suspended := activeMember(member.MembershipStandard)
+	at := time.Now()
+	suspended.SuspendedAt = &at
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(suspended, nil)
+	_, err := m.loanService.Checkout(context.Background(), loan.CheckoutRequest{MemberID: 3, Barcode: "BC-001"})
+	m.Suite.Equal(loan.GetErrorResponseByCode(loan.MemberSuspended), err)
+}
+
+func (m *LoanServiceTestSuite) TestCheckout_ShouldRefuseExpiredMembership() {
+	expired := activeMember(member.MembershipStandard)
+	expired.ExpiresOn = today().AddDate(0, 0, -1)
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(expired, nil)
+	_, err := m.loanService.Checkout(context.Background(), loan.CheckoutRequest{MemberID: 3, Barcode: "BC-001"})
+	m.Suite.Equal(loan.GetErrorResponseByCode(loan.MembershipExpired), err)
+}
+
+func (m *LoanServiceTestSuite) TestCheckout_ShouldMapRepositoryErrors() {
+	for err, code := range map[error]book.ErrorCode{
+		loan.ErrCopyNotFound:    book.CopyNotFound,
+		loan.ErrCopyUnavailable: loan.CopyNotAvailable,
+		loan.ErrLimitReached:    loan.LoanLimitReached,
+	} {
+		m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(activeMember(member.MembershipStandard), nil)
+		m.mockLedgerRepo.EXPECT().Balance(context.Background(), 3).Return(int64(1000), nil)
+		m.mockRepo.EXPECT().Checkout(context.Background(), 3, "BC-001", gomock.Any(), 5).Return(int64(0), err)
+		_, errResp := m.loanService.Checkout(context.Background(), loan.CheckoutRequest{MemberID: 3, Barcode: "BC-001"})
+		m.Suite.Equal(code, errResp.ErrorCode)
+	}
+}
+
+func (m *LoanServiceTestSuite) TestCheckout_ShouldRefuseMemberOwingMoreThanTheThreshold() {
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(activeMember(member.MembershipStandard), nil)
+	m.mockLedgerRepo.EXPECT().Balance(context.Background(), 3).Return(int64(1001), nil)
+	_, err := m.loanService.Checkout(context.Background(), loan.CheckoutRequest{MemberID: 3, Barcode: "BC-001"})
+	m.Suite.Equal(loan.GetErrorResponseByCode(loan.FinesOutstanding), err)
+}
+
+func (m *LoanServiceTestSuite) TestReturn_ShouldReturnConflictWhenAlreadyReturned() {
+	returned := time.Now()
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9, MemberID: 3, ReturnedAt: &returned}, nil)
+	_, err := m.loanService.Return(context.Background(), 9)
+	m.Suite.Equal(loan.GetErrorResponseByCode(loan.LoanAlreadyReturned), err)
+}
+
+func (m *LoanServiceTestSuite) TestReturn_ShouldNotFineReturnWithinTheGracePeriod() {
+	dueOn := today().AddDate(0, 0, -1)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9, MemberID: 3, DueOn: dueOn}, nil)
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(activeMember(member.MembershipStandard), nil)
+	m.mockRepo.EXPECT().Return(context.Background(), 9, gomock.Any(), dueOn, int64(0)).Return(nil)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9}, nil)
+	_, err := m.loanService.Return(context.Background(), 9)
+	m.Suite.Nil(err)
+}
+
+func (m *LoanServiceTestSuite) TestReturn_ShouldFineEveryDayPastTheDueDate() {
+	dueOn := today().AddDate(0, 0, -3)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9, MemberID: 3, DueOn: dueOn}, nil)
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(activeMember(member.MembershipStandard), nil)
+	m.mockRepo.EXPECT().Return(context.Background(), 9, gomock.Any(), dueOn, int64(75)).Return(nil)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9, FineCents: 75}, nil)
+	l, err := m.loanService.Return(context.Background(), 9)
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(75), l.FineCents)
+}
+
+func (m *LoanServiceTestSuite) TestReturn_ShouldCapTheFineOfTheMembershipType() {
+	dueOn := today().AddDate(0, 0, -30)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9, MemberID: 3, DueOn: dueOn}, nil)
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(activeMember(member.MembershipStudent), nil)
+	m.mockRepo.EXPECT().Return(context.Background(), 9, gomock.Any(), dueOn, int64(50)).Return(nil)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9}, nil)
+	_, err := m.loanService.Return(context.Background(), 9)
+	m.Suite.Nil(err)
+}
+
+func (m *LoanServiceTestSuite) TestReturn_ShouldReturnConflictWhenTheLoanWasRenewedMeanwhile() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9, MemberID: 3, DueOn: today()}, nil)
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(activeMember(member.MembershipStandard), nil)
+	m.mockRepo.EXPECT().Return(context.Background(), 9, gomock.Any(), today(), int64(0)).Return(loan.ErrConflict)
+	_, err := m.loanService.Return(context.Background(), 9)
+	m.Suite.Equal(loan.GetErrorResponseByCode(loan.LoanConflict), err)
+}
+
+func (m *LoanServiceTestSuite) TestRenew_ShouldExtendFromTodayButNeverShortenTheLoan() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9, MemberID: 3, BookID: 12, DueOn: today().AddDate(0, 0, 2)}, nil)
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(activeMember(member.MembershipStandard), nil)
+	m.mockHoldRepo.EXPECT().WaitingCount(context.Background(), 12).Return(0, nil)
+	m.mockRepo.EXPECT().Renew(context.Background(), 9, 0, today().AddDate(0, 0, 21)).Return(nil)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9, Renewals: 1}, nil)
+	l, err := m.loanService.Renew(context.Background(), 9)
+	m.Suite.Nil(err)
+	m.Suite.Equal(1, l.Renewals)
+
+	farDue := today().AddDate(0, 2, 0)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9, MemberID: 3, BookID: 12, DueOn: farDue}, nil)
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(activeMember(member.MembershipStandard), nil)
+	m.mockHoldRepo.EXPECT().WaitingCount(context.Background(), 12).Return(0, nil)
+	m.mockRepo.EXPECT().Renew(context.Background(), 9, 0, farDue).Return(nil)
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9, Renewals: 1}, nil)
+	_, err = m.loanService.Renew(context.Background(), 9)
+	m.Suite.Nil(err)
+}
+
+func (m *LoanServiceTestSuite) TestRenew_ShouldRefuseWhenRenewalLimitIsReached() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9, MemberID: 3, Renewals: 1}, nil)
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(activeMember(member.MembershipStudent), nil)
+	_, err := m.loanService.Renew(context.Background(), 9)
+	m.Suite.Equal(loan.GetErrorResponseByCode(loan.RenewalLimitReached), err)
+}
+
+func (m *LoanServiceTestSuite) TestRenew_ShouldRefuseWhileOtherMembersWaitForTheBook() {
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9, MemberID: 3, BookID: 12}, nil)
+	m.mockMemberRepo.EXPECT().GetByID(context.Background(), 3).Return(activeMember(member.MembershipStandard), nil)
+	m.mockHoldRepo.EXPECT().WaitingCount(context.Background(), 12).Return(2, nil)
+	_, err := m.loanService.Renew(context.Background(), 9)
+	m.Suite.Equal(loan.GetErrorResponseByCode(loan.HoldsPending), err)
+}
+
+func (m *LoanServiceTestSuite) TestRenew_ShouldRefuseReturnedLoan() {
+	returned := time.Now()
+	m.mockRepo.EXPECT().GetByID(context.Background(), 9).Return(loan.Loan{ID: 9, MemberID: 3, ReturnedAt: &returned}, nil)
+	_, err := m.loanService.Renew(context.Background(), 9)
+	m.Suite.Equal(loan.GetErrorResponseByCode(loan.LoanAlreadyReturned), err)
+}
@@ -0,0 +1,23 @@
+package marc
+
+import "book-store/internal/book"
+
+type ImportReportResponse struct {
+	DryRun   bool `json:"dryRun" example:"false"`
+	Records  int  `json:"records" example:"3"`
+	Imported int  `json:"imported" example:"2"`
+	Failed   int  `json:"failed" example:"1"`
+	// DroppedFields counts the records each field or subfield was dropped
+	// from, e.g. {"650": 3, "245$c": 2}.
+	DroppedFields map[string]int         `json:"droppedFields"`
+	Results       []ImportResultResponse `json:"results"`
+}
+
+type ImportResultResponse struct {
+	Index   int                 `json:"index" example:"0"`
+	Status  int                 `json:"status" example:"201"`
+	ID      int64               `json:"id,omitempty" example:"12"`
+	Title   string              `json:"title,omitempty" example:"Dune"`
+	Dropped []string            `json:"dropped"`
+	Error   *book.ErrorResponse `json:"error,omitempty"`
+}
@@ -0,0 +1,283 @@
+package marc
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/stream"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	FormatMARC    = "marc"
+	FormatMARCXML = "marcxml"
+)
+
+const (
+	marcContentType    = "application/marc"
+	marcXMLContentType = "application/marcxml+xml"
+)
+
+// maxImportSize bounds a MARC import, which is read whole before any book
+// is created.
+const maxImportSize = 32 << 20
+
+// maxImportRecords caps the records of one import, as they are imported
+// while the request waits.
+const maxImportRecords = 1000
+
+type MARCHandler struct {
+	svc MARCService
+}
+
+func NewMARCHandler(s MARCService) *MARCHandler {
+	return &MARCHandler{svc: s}
+}
+
+// Get godoc
+// @Summary      Get book as MARC
+// @Description  Returns the book as a MARC 21 bibliographic record, in ISO 2709 (format marc) or as a MARCXML document (format marcxml)
+// @Tags         marc
+// @Produce      application/marc
+// @Produce      application/marcxml+xml
+// @Param        id      path      int     true   "Book ID"
+// @Param        format  query     string  false  "marc (default) or marcxml"
+// @Success      200     {string}  string
+// @Failure      400     {object}  book.ErrorResponse
+// @Failure      404     {object}  book.ErrorResponse
+// @Router       /books/{id}/marc [get]
+func (h *MARCHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		logrus.Error("invalid book id provided ", mux.Vars(r)["id"])
+		sendError(w, *book.GetErrorResponseByCode(book.BadRequest))
+		return
+	}
+	format, errResp := parseFormat(r)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	rec, errResp := h.svc.Get(r.Context(), id)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	var out []byte
+	if format == FormatMARCXML {
+		out, err = MarshalXML(rec)
+	} else {
+		out, err = Marshal(rec)
+	}
+	if err != nil {
+		logrus.Error("error while encoding book ", id, " as MARC. error is ", err)
+		sendError(w, *book.GetErrorResponseByCode(book.InternalServerError))
+		return
+	}
+	setFileHeaders(w, format, fmt.Sprintf("book-%d", id))
+	w.Write(out)
+}
+
+// Export godoc
+// @Summary      Export catalog as MARC
+// @Description  Streams every book in the catalog as MARC 21 records, concatenated in ISO 2709 (format marc) or in one MARCXML collection (format marcxml). Like the book export, all records come from one consistent snapshot and a failure after the download has started breaks off the connection
+// @Tags         marc
+// @Produce      application/marc
+// @Produce      application/marcxml+xml
+// @Param        format  query     string  false  "marc (default) or marcxml"
+// @Success      200     {string}  string
+// @Failure      400     {object}  book.ErrorResponse
+// @Router       /books/export/marc [get]
+func (h *MARCHandler) Export(w http.ResponseWriter, r *http.Request) {
+	format, errResp := parseFormat(r)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	rc := http.NewResponseController(w)
+	stream.ExtendWriteDeadline(r, rc)
+	xw := NewXMLWriter(w)
+	started := false
+	errResp = h.svc.Export(r.Context(), func(records []Record) error {
+		if !started {
+			started = true
+			setFileHeaders(w, format, "books")
+		}
+		for _, rec := range records {
+			if format == FormatMARCXML {
+				if err := xw.Write(rec); err != nil {
+					return err
+				}
+				continue
+			}
+			out, err := Marshal(rec)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(out); err != nil {
+				return err
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return err
+		}
+		stream.ExtendWriteDeadline(r, rc)
+		return nil
+	})
+	if errResp != nil {
+		if started {
+			panic(http.ErrAbortHandler)
+		}
+		sendError(w, *errResp)
+		return
+	}
+	if !started {
+		setFileHeaders(w, format, "books")
+	}
+	if format == FormatMARCXML {
+		xw.Close()
+	}
+}
+
+// Import godoc
+// @Summary      Import books from MARC
+// @Description  Creates a book from every record of a MARC 21 file, sent as the request body in ISO 2709 (Content-Type application/marc) or as MARCXML (application/marcxml+xml). The file may hold up to 1000 records and 32 MB. Each record is validated like a POST body. The response reports the outcome of every record together with the fields and subfields that had no place in a book and were dropped. With dryRun the records are checked but no book is created
+// @Tags         marc
+// @Accept       application/marc
+// @Accept       application/marcxml+xml
+// @Produce      json
+// @Param        dryRun  query     bool  false  "Check the records without creating books"
+// @Success      200     {object}  ImportReportResponse
+// @Failure      400     {object}  book.ErrorResponse
+// @Failure      415     {object}  book.ErrorResponse
+// @Router       /imports/marc [post]
+func (h *MARCHandler) Import(w http.ResponseWriter, r *http.Request) {
+	dryRun := false
+	if v := r.URL.Query().Get("dryRun"); v != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			logrus.Error("invalid dryRun provided ", v)
+			sendError(w, *book.GetErrorResponse(book.BadRequest, "dryRun must be true or false", http.StatusBadRequest))
+			return
+		}
+	}
+	reader, errResp := newRecordReader(w, r)
+	if errResp != nil {
+		sendError(w, *errResp)
+		return
+	}
+	records := []Record{}
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			sendError(w, *readError(len(records), err))
+			return
+		}
+		if len(records) == maxImportRecords {
+			logrus.Error("too many records in MARC import")
+			sendError(w, *book.GetErrorResponse(book.BadRequest,
+				fmt.Sprintf("at most %d records can be imported at a time", maxImportRecords), http.StatusBadRequest))
+			return
+		}
+		records = append(records, rec)
+	}
+	if len(records) == 0 {
+		logrus.Error("no records in MARC import")
+		sendError(w, *book.GetErrorResponse(book.BadRequest, "the file holds no MARC records", http.StatusBadRequest))
+		return
+	}
+	results := h.svc.Import(r.Context(), records, dryRun)
+	json.NewEncoder(w).Encode(toImportReportResponse(results, dryRun))
+}
+
+// recordReader reads the records of a MARC file one at a time.
+type recordReader interface {
+	Read() (Record, error)
+}
+
+// newRecordReader picks the reader for the Content-Type of the body.
+func newRecordReader(w http.ResponseWriter, r *http.Request) (recordReader, *book.ErrorResponse) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+	switch mediaType {
+	case marcContentType:
+		return NewReader(body), nil
+	case marcXMLContentType, "application/xml", "text/xml":
+		return NewXMLReader(body), nil
+	}
+	logrus.Error("unsupported content type for MARC import ", mediaType)
+	return nil, book.GetErrorResponse(book.UnsupportedMediaType,
+		"send the file as application/marc or application/marcxml+xml", http.StatusUnsupportedMediaType)
+}
+
+// readError says which record of an import could not be read.
+func readError(index int, err error) *book.ErrorResponse {
+	logrus.Error("error while reading MARC record ", index, ". error is ", err)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return book.GetErrorResponse(book.BadRequest, fmt.Sprintf("the file is larger than %d MB", maxImportSize>>20), http.StatusBadRequest)
+	}
+	if errors.Is(err, ErrInvalidRecord) {
+		return book.GetErrorResponse(book.BadRequest, fmt.Sprintf("record %d: %s", index, err), http.StatusBadRequest)
+	}
+	return book.GetErrorResponse(book.BadRequest, "the upload was interrupted", http.StatusBadRequest)
+}
+
+func parseFormat(r *http.Request) (string, *book.ErrorResponse) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "", FormatMARC:
+		return FormatMARC, nil
+	case FormatMARCXML:
+		return format, nil
+	default:
+		logrus.Error("invalid MARC format provided ", format)
+		return "", book.GetErrorResponse(book.BadRequest, "format must be marc or marcxml", http.StatusBadRequest)
+	}
+}
+
+// setFileHeaders describes a MARC download of the format named after name.
+func setFileHeaders(w http.ResponseWriter, format, name string) {
+	contentType, ext := marcContentType, "mrc"
+	if format == FormatMARCXML {
+		contentType, ext = marcXMLContentType, "xml"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, ext))
+}
+
+func toImportReportResponse(results []ImportResult, dryRun bool) ImportReportResponse {
+	resp := ImportReportResponse{
+		DryRun:        dryRun,
+		Records:       len(results),
+		DroppedFields: map[string]int{},
+		Results:       make([]ImportResultResponse, len(results)),
+	}
+	for i, res := range results {
+		if res.Err == nil {
+			resp.Imported++
+		} else {
+			resp.Failed++
+		}
+		for _, name := range res.Dropped {
+			resp.DroppedFields[name]++
+		}
+		resp.Results[i] = ImportResultResponse{Index: i, Status: res.Status, ID: res.ID, Title: res.Title, Dropped: res.Dropped, Error: res.Err}
+	}
+	return resp
+}
+
+func sendError(w http.ResponseWriter, errResponse book.ErrorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errResponse.HttpStatusCode)
+	json.NewEncoder(w).Encode(errResponse)
+}
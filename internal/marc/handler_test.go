@@ -0,0 +1,133 @@
+package marc_test
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/marc"
+	mock_book "book-store/internal/mocks"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type MARCHandlerTestSuite struct {
+	suite.Suite
+	marcHandler *marc.MARCHandler
+	mockService *mock_book.MockMARCService
+	ctrl        *gomock.Controller
+}
+
+func TestMARCHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(MARCHandlerTestSuite))
+}
+
+func (m *MARCHandlerTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockService = mock_book.NewMockMARCService(m.ctrl)
+	m.marcHandler = marc.NewMARCHandler(m.mockService)
+}
+
+func (m *MARCHandlerTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func (m *MARCHandlerTestSuite) TestGet_ShouldReturnTheRecordInTheRequestedFormat() {
+	rec := record("Dune", "Frank Herbert", "")
+	for format, contentType := range map[string]string{"": "application/marc", "marcxml": "application/marcxml+xml"} {
+		r, _ := http.NewRequest("GET", "/books/12/marc?format="+format, nil)
+		r = mux.SetURLVars(r, map[string]string{"id": "12"})
+		w := httptest.NewRecorder()
+		m.mockService.EXPECT().Get(r.Context(), 12).Return(rec, nil)
+
+		m.marcHandler.Get(w, r)
+		m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+		m.Suite.Equal(contentType, w.Result().Header.Get("Content-Type"))
+		var back marc.Record
+		var err error
+		if format == "marcxml" {
+			back, err = marc.NewXMLReader(w.Body).Read()
+		} else {
+			back, err = marc.Unmarshal(w.Body.Bytes())
+		}
+		m.Suite.Nil(err)
+		m.Suite.Equal(rec.Fields, back.Fields)
+	}
+}
+
+func (m *MARCHandlerTestSuite) TestGet_ShouldReturnBadRequestForUnknownFormat() {
+	r, _ := http.NewRequest("GET", "/books/12/marc?format=unimarc", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "12"})
+	w := httptest.NewRecorder()
+
+	m.marcHandler.Get(w, r)
+	m.Suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func (m *MARCHandlerTestSuite) TestExport_ShouldStreamTheCatalogAsAMARCXMLCollection() {
+	r, _ := http.NewRequest("GET", "/books/export/marc?format=marcxml", nil)
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Export(r.Context(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func([]marc.Record) error) *book.ErrorResponse {
+			m.Suite.Nil(fn([]marc.Record{record("Dune", "Frank Herbert", "")}))
+			m.Suite.Nil(fn([]marc.Record{record("Emma", "Jane Austen", "")}))
+			return nil
+		})
+
+	m.marcHandler.Export(w, r)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	m.Suite.Equal(`attachment; filename="books.xml"`, w.Result().Header.Get("Content-Disposition"))
+	m.Suite.True(strings.HasSuffix(w.Body.String(), "</collection>\n"))
+	reader := marc.NewXMLReader(w.Body)
+	for _, title := range []string{"Dune", "Emma"} {
+		rec, err := reader.Read()
+		m.Suite.Nil(err)
+		m.Suite.Equal(title, rec.Fields[2].Subfield('a'))
+	}
+}
+
+func (m *MARCHandlerTestSuite) TestImport_ShouldReportOnEveryRecord() {
+	var body bytes.Buffer
+	for _, rec := range []marc.Record{record("Dune", "Herbert, Frank", ""), record("", "Nobody", "")} {
+		out, _ := marc.Marshal(rec)
+		body.Write(out)
+	}
+	r, _ := http.NewRequest("POST", "/imports/marc?dryRun=true", &body)
+	r.Header.Set("Content-Type", "application/marc")
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Import(r.Context(), gomock.Len(2), true).Return([]marc.ImportResult{
+		{Status: http.StatusOK, Title: "Dune", Dropped: []string{"001", "245$c"}},
+		{Status: http.StatusBadRequest, Dropped: []string{"001"}, Err: book.GetErrorResponse(book.BadRequest, "Title failed on 'required'", http.StatusBadRequest)},
+	})
+
+	m.marcHandler.Import(w, r)
+	m.Suite.Equal(http.StatusOK, w.Result().StatusCode)
+	var resp marc.ImportReportResponse
+	m.Suite.Nil(json.NewDecoder(w.Body).Decode(&resp))
+	m.Suite.Equal(2, resp.Records)
+	m.Suite.Equal(1, resp.Imported)
+	m.Suite.Equal(1, resp.Failed)
+	m.Suite.Equal(map[string]int{"001": 2, "245$c": 1}, resp.DroppedFields)
+	m.Suite.Equal("Title failed on 'required'", resp.Results[1].Error.ErrorMessage)
+}
+
+func (m *MARCHandlerTestSuite) TestImport_ShouldRejectAFileItCannotRead() {
+	for contentType, want := range map[string]int{
+		"application/marc":        http.StatusBadRequest,
+		"application/marcxml+xml": http.StatusBadRequest,
+		"text/csv":                http.StatusUnsupportedMediaType,
+	} {
+		r, _ := http.NewRequest("POST", "/imports/marc", strings.NewReader("00099nam"))
+		r.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+
+		m.marcHandler.Import(w, r)
+		m.Suite.Equal(want, w.Result().StatusCode, contentType)
+	}
+}
@@ -0,0 +1,213 @@
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// The delimiters ISO 2709 separates subfields, fields and records with.
+const (
+	subfieldDelimiter = 0x1F
+	fieldTerminator   = 0x1E
+	recordTerminator  = 0x1D
+)
+
+// maxRecordLength is the largest record the five digits of the record
+// length in the leader can describe.
+const maxRecordLength = 99999
+
+// stripDelimiters removes the ISO 2709 delimiters from a value, which would
+// otherwise end its subfield early.
+var stripDelimiters = strings.NewReplacer("\x1d", "", "\x1e", "", "\x1f", "")
+
+// Marshal encodes a record in ISO 2709, the exchange format of MARC 21. The
+// record is always written as UTF-8.
+func Marshal(r Record) ([]byte, error) {
+	var dir, data bytes.Buffer
+	for _, f := range r.Fields {
+		if !validTag(f.Tag) {
+			return nil, fmt.Errorf("%w: tag %q", ErrInvalidRecord, f.Tag)
+		}
+		start := data.Len()
+		if f.IsControl() {
+			data.WriteString(stripDelimiters.Replace(f.Value))
+		} else {
+			data.WriteByte(indicator(f.Ind1))
+			data.WriteByte(indicator(f.Ind2))
+			for _, sf := range f.Subfields {
+				data.WriteByte(subfieldDelimiter)
+				data.WriteByte(sf.Code)
+				data.WriteString(stripDelimiters.Replace(sf.Value))
+			}
+		}
+		data.WriteByte(fieldTerminator)
+		length := data.Len() - start
+		if length > 9999 {
+			return nil, fmt.Errorf("%w: field %s is longer than 9999 bytes", ErrInvalidRecord, f.Tag)
+		}
+		fmt.Fprintf(&dir, "%s%04d%05d", f.Tag, length, start)
+	}
+	dir.WriteByte(fieldTerminator)
+	base := leaderLength + dir.Len()
+	total := base + data.Len() + 1
+	if total > maxRecordLength {
+		return nil, fmt.Errorf("%w: record is longer than %d bytes", ErrInvalidRecord, maxRecordLength)
+	}
+	leader := []byte(r.leader())
+	copy(leader[0:5], fmt.Sprintf("%05d", total))
+	leader[9] = 'a'
+	copy(leader[10:12], "22")
+	copy(leader[12:17], fmt.Sprintf("%05d", base))
+	copy(leader[20:24], "4500")
+
+	out := make([]byte, 0, total)
+	out = append(out, leader...)
+	out = append(out, dir.Bytes()...)
+	out = append(out, data.Bytes()...)
+	return append(out, recordTerminator), nil
+}
+
+// indicator returns the indicator, a blank standing in for an unset one.
+func indicator(b byte) byte {
+	if b == 0 {
+		return ' '
+	}
+	return b
+}
+
+// Unmarshal decodes a single ISO 2709 record. Records not coded in UCS
+// (leader position 9) are read only when they are plain ASCII, as MARC-8
+// is not supported.
+func Unmarshal(data []byte) (Record, error) {
+	invalid := func(format string, args ...any) (Record, error) {
+		return Record{}, fmt.Errorf("%w: %s", ErrInvalidRecord, fmt.Sprintf(format, args...))
+	}
+	if len(data) < leaderLength+2 {
+		return invalid("record is too short")
+	}
+	length, ok := digits(data[0:5])
+	if !ok || length != len(data) {
+		return invalid("record length %q does not match the %d bytes of the record", data[0:5], len(data))
+	}
+	if data[len(data)-1] != recordTerminator {
+		return invalid("record does not end with a record terminator")
+	}
+	base, ok := digits(data[12:17])
+	if !ok || base <= leaderLength || base >= len(data) || data[base-1] != fieldTerminator {
+		return invalid("base address of data %q is not valid", data[12:17])
+	}
+	if data[9] != 'a' {
+		for _, c := range data[leaderLength:] {
+			if c >= 0x80 {
+				return invalid("MARC-8 encoded records are not supported, convert the file to UTF-8")
+			}
+		}
+	}
+	dir := data[leaderLength : base-1]
+	if len(dir)%12 != 0 {
+		return invalid("directory is not made of 12 byte entries")
+	}
+	content := data[base : len(data)-1]
+	r := Record{Leader: string(data[:leaderLength])}
+	for i := 0; i < len(dir); i += 12 {
+		entry := dir[i : i+12]
+		tag := string(entry[0:3])
+		flen, ok1 := digits(entry[3:7])
+		start, ok2 := digits(entry[7:12])
+		if !validTag(tag) || !ok1 || !ok2 || flen < 1 || start+flen > len(content) {
+			return invalid("directory entry %q is not valid", entry)
+		}
+		raw := content[start : start+flen]
+		if raw[len(raw)-1] != fieldTerminator {
+			return invalid("field %s does not end with a field terminator", tag)
+		}
+		raw = raw[:len(raw)-1]
+		if !utf8.Valid(raw) {
+			return invalid("field %s is not valid UTF-8", tag)
+		}
+		f := Field{Tag: tag}
+		if f.IsControl() {
+			f.Value = string(raw)
+			r.Fields = append(r.Fields, f)
+			continue
+		}
+		if len(raw) < 2 {
+			return invalid("field %s has no indicators", tag)
+		}
+		f.Ind1, f.Ind2 = raw[0], raw[1]
+		parts := bytes.Split(raw[2:], []byte{subfieldDelimiter})
+		if len(parts[0]) != 0 {
+			return invalid("field %s has data before its first subfield", tag)
+		}
+		for _, p := range parts[1:] {
+			if len(p) == 0 {
+				return invalid("field %s has a subfield without a code", tag)
+			}
+			f.Subfields = append(f.Subfields, Subfield{Code: p[0], Value: string(p[1:])})
+		}
+		r.Fields = append(r.Fields, f)
+	}
+	return r, nil
+}
+
+// digits reads a number written in ASCII digits only, as the lengths and
+// offsets of a record are. A sign or a space makes it invalid.
+func digits(b []byte) (int, bool) {
+	n := 0
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, len(b) > 0
+}
+
+// Reader reads consecutive ISO 2709 records, as found in a .mrc file. Line
+// breaks between records are skipped.
+type Reader struct {
+	r *bufio.Reader
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next record, or io.EOF after the last one. A record that
+// is cut off or whose length cannot be read leaves the rest of the stream
+// unreadable.
+func (r *Reader) Read() (Record, error) {
+	for {
+		b, err := r.r.Peek(1)
+		if err != nil {
+			return Record{}, err
+		}
+		if b[0] != '\r' && b[0] != '\n' {
+			break
+		}
+		r.r.Discard(1)
+	}
+	head, err := r.r.Peek(5)
+	if err == io.EOF {
+		return Record{}, fmt.Errorf("%w: file ends inside a record", ErrInvalidRecord)
+	}
+	if err != nil {
+		return Record{}, err
+	}
+	length, ok := digits(head)
+	if !ok || length < leaderLength+2 {
+		return Record{}, fmt.Errorf("%w: record length %q is not valid", ErrInvalidRecord, head)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return Record{}, fmt.Errorf("%w: file ends inside a record", ErrInvalidRecord)
+		}
+		return Record{}, err
+	}
+	return Unmarshal(data)
+}
@@ -0,0 +1,105 @@
+package marc_test
+
+import (
+	"book-store/internal/marc"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	r := marc.Record{}
+	r.ControlField("001", "12")
+	r.DataField("245", '1', '0', "a", "Dune", "b", "")
+
+	out, err := marc.Marshal(r)
+	require.NoError(t, err)
+	require.Equal(t, "00062nam a2200049   4500"+
+		"001000300000"+"245000900003"+"\x1e"+
+		"12\x1e"+"10\x1faDune\x1e"+"\x1d", string(out))
+}
+
+func TestMarshal_ShouldStripDelimitersFromValues(t *testing.T) {
+	r := marc.Record{}
+	r.DataField("245", '1', '0', "a", "Du\x1fne\x1d")
+
+	out, err := marc.Marshal(r)
+	require.NoError(t, err)
+	back, err := marc.Unmarshal(out)
+	require.NoError(t, err)
+	require.Equal(t, "Dune", back.Fields[0].Subfield('a'))
+}
+
+func TestUnmarshal_ShouldReadWhatMarshalWrote(t *testing.T) {
+	r := marc.Record{Leader: "00000cam a2200000 i 4500"}
+	r.ControlField("001", "12")
+	r.ControlField("008", "261001s1965    xx            000 0 eng d")
+	r.DataField("100", '1', ' ', "a", "Herbert, Frank,", "d", "1920-1986.")
+	r.DataField("245", '1', '0', "a", "Dune /", "c", "Frank Herbert.")
+	r.DataField("650", ' ', '0', "a", "Science fiction — Ökologie")
+
+	out, err := marc.Marshal(r)
+	require.NoError(t, err)
+	back, err := marc.Unmarshal(out)
+	require.NoError(t, err)
+	require.Equal(t, r.Fields, back.Fields)
+	require.Equal(t, "cam a22", back.Leader[5:12])
+}
+
+func TestUnmarshal_ShouldRejectBrokenRecords(t *testing.T) {
+	r := marc.Record{}
+	r.DataField("245", '1', '0', "a", "Dune")
+	good, err := marc.Marshal(r)
+	require.NoError(t, err)
+
+	broken := map[string][]byte{
+		"short":                  good[:20],
+		"wrong length":           append([]byte("00099"), good[5:]...),
+		"no terminator":          append(append([]byte{}, good[:len(good)-1]...), ' '),
+		"bad base":               append(append(append([]byte{}, good[:12]...), "00010"...), good[17:]...),
+		"signed base":            append(append(append([]byte{}, good[:12]...), "+0037"...), good[17:]...),
+		"signed length":          append([]byte("+0"), good[2:]...),
+		"negative start":         append(append(append([]byte{}, good[:31]...), "-0001"...), good[36:]...),
+		"signed length of field": append(append(append([]byte{}, good[:27]...), "+009"...), good[31:]...),
+		"marc-8 content":         bytes.Replace(good, []byte("Dune"), []byte("D\xe8ne"), 1),
+	}
+	marc8 := broken["marc-8 content"]
+	marc8[9] = ' '
+	for name, data := range broken {
+		_, err := marc.Unmarshal(data)
+		require.ErrorIs(t, err, marc.ErrInvalidRecord, name)
+	}
+}
+
+func TestReader_ShouldReadConsecutiveRecords(t *testing.T) {
+	var file bytes.Buffer
+	for _, title := range []string{"Dune", "Emma"} {
+		r := marc.Record{}
+		r.DataField("245", '1', '0', "a", title)
+		out, err := marc.Marshal(r)
+		require.NoError(t, err)
+		file.Write(out)
+		file.WriteString("\n")
+	}
+
+	reader := marc.NewReader(&file)
+	for _, title := range []string{"Dune", "Emma"} {
+		r, err := reader.Read()
+		require.NoError(t, err)
+		require.Equal(t, title, r.Fields[0].Subfield('a'))
+	}
+	_, err := reader.Read()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestReader_ShouldReportACutOffRecord(t *testing.T) {
+	r := marc.Record{}
+	r.DataField("245", '1', '0', "a", "Dune")
+	out, err := marc.Marshal(r)
+	require.NoError(t, err)
+
+	_, err = marc.NewReader(bytes.NewReader(out[:40])).Read()
+	require.ErrorIs(t, err, marc.ErrInvalidRecord)
+}
@@ -0,0 +1,201 @@
+package marc
+
+import (
+	"book-store/internal/book"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// marcLanguages maps the two-letter ISO 639-1 codes of common languages to
+// the MARC language codes used in field 008. Three-letter codes are taken
+// to be MARC codes already.
+var marcLanguages = map[string]string{
+	"ar": "ara", "cs": "cze", "da": "dan", "de": "ger", "el": "gre", "en": "eng", "es": "spa", "fi": "fin",
+	"fr": "fre", "he": "heb", "hi": "hin", "hu": "hun", "it": "ita", "ja": "jpn", "ko": "kor", "la": "lat",
+	"nl": "dut", "no": "nor", "pl": "pol", "pt": "por", "ru": "rus", "sv": "swe", "tr": "tur", "zh": "chi",
+}
+
+// isoLanguages is the inverse of marcLanguages.
+var isoLanguages = func() map[string]string {
+	m := make(map[string]string, len(marcLanguages))
+	for iso, code := range marcLanguages {
+		m[code] = iso
+	}
+	return m
+}()
+
+// yearPattern finds a year in a date statement such as "c1965." or "[1998]".
+var yearPattern = regexp.MustCompile(`\b\d{4}\b`)
+
+// FromBook maps a book to a MARC 21 bibliographic record:
+//
+//	001     id
+//	008     date added, year of publication and language
+//	020 $a  isbn
+//	100 $a  author, as the byline is written
+//	245 $a  title
+//	264 $c  year of publication
+//	520 $a  description
+//	655 $a  genre
+//	700 $a  linked authors, with their role in $e
+func FromBook(b book.Book) Record {
+	r := Record{Leader: defaultLeader}
+	r.ControlField("001", strconv.Itoa(b.ID))
+	r.ControlField("008", fixedData(b))
+	r.DataField("020", ' ', ' ', "a", b.ISBN)
+	r.DataField("100", '0', ' ', "a", b.Author)
+	r.DataField("245", '1', '0', "a", b.Title)
+	if b.PublishedYear != 0 {
+		r.DataField("264", ' ', '1', "c", strconv.Itoa(b.PublishedYear))
+	}
+	r.DataField("520", ' ', ' ', "a", b.Description)
+	r.DataField("655", ' ', '4', "a", b.Genre)
+	for _, a := range b.Authors {
+		r.DataField("700", '0', ' ', "a", a.Name, "e", a.Role)
+	}
+	return r
+}
+
+// fixedData renders the 40 characters of field 008 for a book, leaving the
+// positions the catalog knows nothing about blank.
+func fixedData(b book.Book) string {
+	f := []byte(strings.Repeat(" ", 40))
+	copy(f[0:6], b.CreatedAt.UTC().Format("060102"))
+	if b.PublishedYear != 0 {
+		f[6] = 's'
+		copy(f[7:11], fmt.Sprintf("%04d", b.PublishedYear))
+	} else {
+		f[6] = 'n'
+		copy(f[7:11], "uuuu")
+	}
+	copy(f[15:18], "xx ")
+	lang := b.Language
+	if code, ok := marcLanguages[lang]; ok {
+		lang = code
+	}
+	if len(lang) == 3 {
+		copy(f[35:38], lang)
+	}
+	f[39] = 'd'
+	return string(f)
+}
+
+// ToBook maps a record to the request creating its book, reading the fields
+// FromBook writes except 700: a book links authors by id, which a record
+// does not carry, so linked authors are dropped and only the byline in 100
+// survives a round trip. 260 is read like 264, and 110 or 111 like 100 when
+// there is no personal name. Punctuation ending a value is trimmed and
+// inverted names such as "Herbert, Frank" are turned around. The fields
+// and subfields with no place in a book are returned as e.g. "650" or
+// "245$c", each once.
+func ToBook(r Record) (book.CreateOrUpdateBookRequest, []string) {
+	req := book.CreateOrUpdateBookRequest{}
+	dropped := []string{}
+	seen := map[string]bool{}
+	drop := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			dropped = append(dropped, name)
+		}
+	}
+	// keep takes the subfields with the given codes and drops the rest.
+	keep := func(f Field, codes string) {
+		for _, sf := range f.Subfields {
+			if !strings.ContainsRune(codes, rune(sf.Code)) {
+				drop(f.Tag + "$" + string(sf.Code))
+			}
+		}
+	}
+	// 008 is the more reliable source of the year, so other dates only fill in.
+	year008 := 0
+	yearOther := 0
+	for _, f := range r.Fields {
+		switch {
+		case f.Tag == "008":
+			if len(f.Value) >= 11 {
+				if y, err := strconv.Atoi(f.Value[7:11]); err == nil && y > 0 {
+					year008 = y
+				}
+			}
+			if len(f.Value) >= 38 {
+				req.Language = bookLanguage(f.Value[35:38])
+			}
+		case f.Tag == "020" && req.ISBN == "":
+			// $a may be followed by a qualifier, as in "0441013597 (pbk.)".
+			if fields := strings.Fields(f.Subfield('a')); len(fields) > 0 {
+				req.ISBN = fields[0]
+			}
+			keep(f, "a")
+		case (f.Tag == "100" || f.Tag == "110" || f.Tag == "111") && req.Author == "":
+			req.Author = trimPunctuation(f.Subfield('a'))
+			if f.Tag == "100" && f.Ind1 == '1' {
+				req.Author = directOrder(req.Author)
+			}
+			keep(f, "a")
+		case f.Tag == "245" && req.Title == "":
+			req.Title = trimPunctuation(f.Subfield('a'))
+			if sub := trimPunctuation(f.Subfield('b')); sub != "" {
+				req.Title += ": " + sub
+			}
+			keep(f, "ab")
+		case (f.Tag == "264" && f.Ind2 == '1' || f.Tag == "260") && yearOther == 0:
+			if m := yearPattern.FindString(f.Subfield('c')); m != "" {
+				yearOther, _ = strconv.Atoi(m)
+			}
+			keep(f, "c")
+		case f.Tag == "520" && req.Description == "":
+			req.Description = strings.TrimSpace(f.Subfield('a'))
+			keep(f, "a")
+		case f.Tag == "655" && req.Genre == "":
+			req.Genre = trimPunctuation(f.Subfield('a'))
+			keep(f, "a")
+		default:
+			drop(f.Tag)
+		}
+	}
+	req.PublishedYear = year008
+	if req.PublishedYear == 0 {
+		req.PublishedYear = yearOther
+	}
+	return req, dropped
+}
+
+// bookLanguage turns a MARC language code into the code a book keeps:
+// ISO 639-1 where there is one, the MARC code otherwise, or "" for none.
+func bookLanguage(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if len(code) != 3 || code == "und" || code == "zxx" || code == "mul" || strings.Trim(code, "|") == "" {
+		return ""
+	}
+	if iso, ok := isoLanguages[code]; ok {
+		return iso
+	}
+	return code
+}
+
+// trimPunctuation removes the punctuation cataloguers end a value with to
+// separate it from the next one. A final full stop stays when it ends an
+// initial, as in "Tolkien, J. R. R.".
+func trimPunctuation(s string) string {
+	s = strings.TrimRight(strings.TrimSpace(s), " /:;,=")
+	if strings.HasSuffix(s, ".") {
+		word := s[:len(s)-1]
+		word = word[strings.LastIndexAny(word, " .,")+1:]
+		if utf8.RuneCountInString(word) > 1 {
+			s = s[:len(s)-1]
+		}
+	}
+	return strings.TrimSpace(s)
+}
+
+// directOrder turns an inverted name such as "Herbert, Frank" around.
+func directOrder(name string) string {
+	surname, forenames, ok := strings.Cut(name, ", ")
+	if !ok {
+		return name
+	}
+	return strings.TrimSpace(forenames) + " " + surname
+}
@@ -0,0 +1,76 @@
+package marc_test
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/marc"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromBook(t *testing.T) {
+	r := marc.FromBook(book.Book{
+		ID: 12, Title: "Dune", Author: "Frank Herbert", Description: "Arrakis.", ISBN: "9780441013593",
+		Genre: "science fiction", Language: "en", PublishedYear: 1965,
+		CreatedAt: time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC),
+		Authors:   []book.BookAuthor{{Name: "Frank Herbert", Role: "author"}, {Name: "Brian Herbert", Role: "editor"}},
+	})
+	require.Equal(t, []marc.Field{
+		{Tag: "001", Value: "12"},
+		{Tag: "008", Value: "261001s1965    xx " + strings.Repeat(" ", 17) + "eng d"},
+		{Tag: "020", Ind1: ' ', Ind2: ' ', Subfields: []marc.Subfield{{Code: 'a', Value: "9780441013593"}}},
+		{Tag: "100", Ind1: '0', Ind2: ' ', Subfields: []marc.Subfield{{Code: 'a', Value: "Frank Herbert"}}},
+		{Tag: "245", Ind1: '1', Ind2: '0', Subfields: []marc.Subfield{{Code: 'a', Value: "Dune"}}},
+		{Tag: "264", Ind1: ' ', Ind2: '1', Subfields: []marc.Subfield{{Code: 'c', Value: "1965"}}},
+		{Tag: "520", Ind1: ' ', Ind2: ' ', Subfields: []marc.Subfield{{Code: 'a', Value: "Arrakis."}}},
+		{Tag: "655", Ind1: ' ', Ind2: '4', Subfields: []marc.Subfield{{Code: 'a', Value: "science fiction"}}},
+		{Tag: "700", Ind1: '0', Ind2: ' ', Subfields: []marc.Subfield{{Code: 'a', Value: "Frank Herbert"}, {Code: 'e', Value: "author"}}},
+		{Tag: "700", Ind1: '0', Ind2: ' ', Subfields: []marc.Subfield{{Code: 'a', Value: "Brian Herbert"}, {Code: 'e', Value: "editor"}}},
+	}, r.Fields)
+}
+
+func TestFromBook_ShouldLeaveOutWhatTheBookLacks(t *testing.T) {
+	r := marc.FromBook(book.Book{ID: 13, Title: "Emma", Author: "Jane Austen", CreatedAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)})
+	tags := []string{}
+	for _, f := range r.Fields {
+		tags = append(tags, f.Tag)
+	}
+	require.Equal(t, []string{"001", "008", "100", "245"}, tags)
+	require.Equal(t, "261001nuuuu", r.Fields[1].Value[:11])
+}
+
+func TestToBook_ShouldMapACatalogedRecordAndReportWhatWasDropped(t *testing.T) {
+	r := marc.Record{}
+	r.ControlField("001", "ocm12345")
+	r.ControlField("008", "650101s1965    nyu           000 1 eng d")
+	r.DataField("020", ' ', ' ', "a", "0441013597 (pbk.)", "c", "$9.99")
+	r.DataField("020", ' ', ' ', "a", "9780441013593")
+	r.DataField("100", '1', ' ', "a", "Herbert, Frank,", "d", "1920-1986.")
+	r.DataField("245", '1', '0', "a", "Dune /", "b", "a novel :", "c", "Frank Herbert.")
+	r.DataField("264", ' ', '1', "a", "New York :", "b", "Ace,", "c", "c1990.")
+	r.DataField("520", ' ', ' ', "a", "Set on the desert planet Arrakis.")
+	r.DataField("650", ' ', '0', "a", "Science fiction.")
+	r.DataField("655", ' ', '7', "a", "Science fiction.", "2", "lcgft")
+
+	req, dropped := marc.ToBook(r)
+	require.Equal(t, book.CreateOrUpdateBookRequest{
+		Title: "Dune: a novel", Author: "Frank Herbert", Description: "Set on the desert planet Arrakis.",
+		ISBN: "0441013597", Genre: "Science fiction", Language: "en", PublishedYear: 1965,
+	}, req)
+	require.Equal(t, []string{"001", "020$c", "020", "100$d", "245$c", "264$a", "264$b", "650", "655$2"}, dropped)
+}
+
+func TestToBook_ShouldReadWhatFromBookWrote(t *testing.T) {
+	b := book.Book{
+		ID: 12, Title: "The Lord of the Rings", Author: "J. R. R. Tolkien", Description: "One ring.", ISBN: "9780261103252",
+		Genre: "fantasy", Language: "de", PublishedYear: 1954, CreatedAt: time.Now(),
+		Authors: []book.BookAuthor{{AuthorID: 3, Name: "J. R. R. Tolkien", Role: "author"}},
+	}
+	req, dropped := marc.ToBook(marc.FromBook(b))
+	require.Equal(t, book.CreateOrUpdateBookRequest{
+		Title: b.Title, Author: b.Author, Description: b.Description, ISBN: b.ISBN, Genre: b.Genre, Language: b.Language, PublishedYear: b.PublishedYear,
+	}, req)
+	require.Equal(t, []string{"001", "700"}, dropped)
+}
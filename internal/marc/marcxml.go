@@ -0,0 +1,177 @@
+package marc
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Namespace is the namespace of MARCXML documents.
+const Namespace = "http://www.loc.gov/MARC21/slim"
+
+type xmlRecord struct {
+	XMLName       xml.Name          `xml:"record"`
+	Xmlns         string            `xml:"xmlns,attr,omitempty"`
+	Leader        string            `xml:"leader"`
+	ControlFields []xmlControlField `xml:"controlfield"`
+	DataFields    []xmlDataField    `xml:"datafield"`
+}
+
+type xmlControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlDataField struct {
+	Tag       string        `xml:"tag,attr"`
+	Ind1      string        `xml:"ind1,attr"`
+	Ind2      string        `xml:"ind2,attr"`
+	Subfields []xmlSubfield `xml:"subfield"`
+}
+
+type xmlSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// toXML arranges the record for MARCXML, where the control fields come
+// before the data fields.
+func toXML(r Record) xmlRecord {
+	x := xmlRecord{Leader: r.leader()}
+	for _, f := range r.Fields {
+		if f.IsControl() {
+			x.ControlFields = append(x.ControlFields, xmlControlField{Tag: f.Tag, Value: f.Value})
+			continue
+		}
+		df := xmlDataField{Tag: f.Tag, Ind1: string(indicator(f.Ind1)), Ind2: string(indicator(f.Ind2))}
+		for _, sf := range f.Subfields {
+			df.Subfields = append(df.Subfields, xmlSubfield{Code: string(sf.Code), Value: sf.Value})
+		}
+		x.DataFields = append(x.DataFields, df)
+	}
+	return x
+}
+
+func (x xmlRecord) record() (Record, error) {
+	invalid := func(format string, args ...any) (Record, error) {
+		return Record{}, fmt.Errorf("%w: %s", ErrInvalidRecord, fmt.Sprintf(format, args...))
+	}
+	r := Record{Leader: x.Leader}
+	if len(r.Leader) != leaderLength {
+		r.Leader = defaultLeader
+	}
+	for _, cf := range x.ControlFields {
+		if !validTag(cf.Tag) {
+			return invalid("tag %q", cf.Tag)
+		}
+		r.ControlField(cf.Tag, cf.Value)
+	}
+	for _, df := range x.DataFields {
+		if !validTag(df.Tag) {
+			return invalid("tag %q", df.Tag)
+		}
+		f := Field{Tag: df.Tag, Ind1: ' ', Ind2: ' '}
+		if len(df.Ind1) > 1 || len(df.Ind2) > 1 {
+			return invalid("field %s has an indicator longer than one character", df.Tag)
+		}
+		if df.Ind1 != "" {
+			f.Ind1 = df.Ind1[0]
+		}
+		if df.Ind2 != "" {
+			f.Ind2 = df.Ind2[0]
+		}
+		for _, sf := range df.Subfields {
+			if len(sf.Code) != 1 {
+				return invalid("field %s has a subfield code %q", df.Tag, sf.Code)
+			}
+			f.Subfields = append(f.Subfields, Subfield{Code: sf.Code[0], Value: sf.Value})
+		}
+		r.Fields = append(r.Fields, f)
+	}
+	return r, nil
+}
+
+// MarshalXML encodes a record as a standalone MARCXML document.
+func MarshalXML(r Record) ([]byte, error) {
+	x := toXML(r)
+	x.Xmlns = Namespace
+	out, err := xml.MarshalIndent(x, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// XMLWriter writes records as a MARCXML collection. The document is only
+// complete once the writer is closed.
+type XMLWriter struct {
+	w       io.Writer
+	enc     *xml.Encoder
+	started bool
+}
+
+func NewXMLWriter(w io.Writer) *XMLWriter {
+	return &XMLWriter{w: w, enc: xml.NewEncoder(w)}
+}
+
+func (x *XMLWriter) start() error {
+	if x.started {
+		return nil
+	}
+	x.started = true
+	_, err := io.WriteString(x.w, xml.Header+`<collection xmlns="`+Namespace+`">`+"\n")
+	return err
+}
+
+func (x *XMLWriter) Write(r Record) error {
+	if err := x.start(); err != nil {
+		return err
+	}
+	if err := x.enc.Encode(toXML(r)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(x.w, "\n")
+	return err
+}
+
+// Close ends the collection, which is empty if no record was written.
+func (x *XMLWriter) Close() error {
+	if err := x.start(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(x.w, "</collection>\n")
+	return err
+}
+
+// XMLReader reads the records of a MARCXML document, which may be a
+// collection or a single record, one at a time.
+type XMLReader struct {
+	dec *xml.Decoder
+}
+
+func NewXMLReader(r io.Reader) *XMLReader {
+	return &XMLReader{dec: xml.NewDecoder(r)}
+}
+
+// Read returns the next record, or io.EOF after the last one. Records are
+// found by name whatever their namespace prefix.
+func (x *XMLReader) Read() (Record, error) {
+	for {
+		tok, err := x.dec.Token()
+		if err == io.EOF {
+			return Record{}, io.EOF
+		}
+		if err != nil {
+			return Record{}, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+		var xr xmlRecord
+		if err := x.dec.DecodeElement(&xr, &start); err != nil {
+			return Record{}, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+		}
+		return xr.record()
+	}
+}
@@ -0,0 +1,78 @@
+package marc_test
+
+import (
+	"book-store/internal/marc"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalXML(t *testing.T) {
+	r := marc.Record{}
+	r.ControlField("001", "12")
+	r.DataField("245", '1', '0', "a", "Pride & Prejudice")
+
+	out, err := marc.MarshalXML(r)
+	require.NoError(t, err)
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<record xmlns="http://www.loc.gov/MARC21/slim">
+  <leader>00000nam a2200000   4500</leader>
+  <controlfield tag="001">12</controlfield>
+  <datafield tag="245" ind1="1" ind2="0">
+    <subfield code="a">Pride &amp; Prejudice</subfield>
+  </datafield>
+</record>`, string(out))
+}
+
+func TestXMLWriter_ShouldWriteACollectionTheReaderReadsBack(t *testing.T) {
+	var buf bytes.Buffer
+	w := marc.NewXMLWriter(&buf)
+	for _, title := range []string{"Dune", "Emma"} {
+		r := marc.Record{}
+		r.ControlField("008", "261001s1965    xx            000 0 eng d")
+		r.DataField("245", '1', '0', "a", title)
+		require.NoError(t, w.Write(r))
+	}
+	require.NoError(t, w.Close())
+	require.True(t, strings.HasSuffix(buf.String(), "</collection>\n"))
+
+	reader := marc.NewXMLReader(&buf)
+	for _, title := range []string{"Dune", "Emma"} {
+		r, err := reader.Read()
+		require.NoError(t, err)
+		require.Equal(t, []marc.Field{
+			{Tag: "008", Value: "261001s1965    xx            000 0 eng d"},
+			{Tag: "245", Ind1: '1', Ind2: '0', Subfields: []marc.Subfield{{Code: 'a', Value: title}}},
+		}, r.Fields)
+	}
+	_, err := reader.Read()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestXMLReader_ShouldReadPrefixedRecords(t *testing.T) {
+	doc := `<marc:collection xmlns:marc="http://www.loc.gov/MARC21/slim">
+  <marc:record>
+    <marc:leader>01142cam  2200301 a 4500</marc:leader>
+    <marc:datafield tag="245" ind1="1" ind2="0"><marc:subfield code="a">Dune</marc:subfield></marc:datafield>
+  </marc:record>
+</marc:collection>`
+	r, err := marc.NewXMLReader(strings.NewReader(doc)).Read()
+	require.NoError(t, err)
+	require.Equal(t, "01142cam  2200301 a 4500", r.Leader)
+	require.Equal(t, "Dune", r.Fields[0].Subfield('a'))
+}
+
+func TestXMLReader_ShouldRejectInvalidRecords(t *testing.T) {
+	for _, doc := range []string{
+		`<record><datafield tag="24" ind1="1" ind2="0"><subfield code="a">Dune</subfield></datafield></record>`,
+		`<record><datafield tag="245" ind1="10" ind2="0"><subfield code="a">Dune</subfield></datafield></record>`,
+		`<record><datafield tag="245" ind1="1" ind2="0"><subfield code="ab">Dune</subfield></datafield></record>`,
+		`<record><datafield tag="245"`,
+	} {
+		_, err := marc.NewXMLReader(strings.NewReader(doc)).Read()
+		require.ErrorIs(t, err, marc.ErrInvalidRecord, doc)
+	}
+}
@@ -0,0 +1,95 @@
+package marc
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidRecord means a record does not follow the MARC 21 structure.
+var ErrInvalidRecord = errors.New("invalid MARC record")
+
+// Record is a MARC 21 bibliographic record: the leader and its fields in
+// the order they are stored.
+type Record struct {
+	Leader string
+	Fields []Field
+}
+
+// Field is a control field (tags 001 to 009), which only has a Value, or a
+// data field, which has two indicators and its subfields.
+type Field struct {
+	Tag       string
+	Value     string
+	Ind1      byte
+	Ind2      byte
+	Subfields []Subfield
+}
+
+type Subfield struct {
+	Code  byte
+	Value string
+}
+
+// defaultLeader describes a new record of a printed monograph in UTF-8. The
+// record length and base address are filled in when it is encoded.
+const defaultLeader = "00000nam a2200000   4500"
+
+const leaderLength = 24
+
+// IsControl reports whether the field is a control field.
+func (f Field) IsControl() bool {
+	return strings.HasPrefix(f.Tag, "00")
+}
+
+// Subfield returns the value of the first subfield with the code, or "".
+func (f Field) Subfield(code byte) string {
+	for _, sf := range f.Subfields {
+		if sf.Code == code {
+			return sf.Value
+		}
+	}
+	return ""
+}
+
+// ControlField adds a control field to the record.
+func (r *Record) ControlField(tag, value string) {
+	r.Fields = append(r.Fields, Field{Tag: tag, Value: value})
+}
+
+// DataField adds a data field to the record. Subfields are given as code and
+// value pairs; those with an empty value are left out, and so is the field
+// when none remain.
+func (r *Record) DataField(tag string, ind1, ind2 byte, pairs ...string) {
+	f := Field{Tag: tag, Ind1: ind1, Ind2: ind2}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			f.Subfields = append(f.Subfields, Subfield{Code: pairs[i][0], Value: pairs[i+1]})
+		}
+	}
+	if len(f.Subfields) > 0 {
+		r.Fields = append(r.Fields, f)
+	}
+}
+
+// leader returns the record's leader, or the default one when it has none.
+func (r Record) leader() string {
+	if len(r.Leader) == leaderLength {
+		return r.Leader
+	}
+	return defaultLeader
+}
+
+// validTag reports whether a tag is three alphanumeric characters, as MARC
+// 21 requires.
+func validTag(tag string) bool {
+	if len(tag) != 3 {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		c := tag[i]
+		if !(c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package marc
+
+import (
+	"book-store/internal/book"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// ImportResult is the outcome of importing one record. Status is the one
+// POST /books would have answered, or 200 for a record that passes a dry
+// run. Dropped lists the fields and subfields the book had no place for.
+type ImportResult struct {
+	Status  int
+	ID      int64
+	Title   string
+	Dropped []string
+	Err     *book.ErrorResponse
+}
+
+type MARCService interface {
+	// Get returns the record of a book.
+	Get(ctx context.Context, id int) (Record, *book.ErrorResponse)
+	// Export calls fn with the records of every book in the catalog, a batch
+	// at a time. An error returned by fn stops the export.
+	Export(ctx context.Context, fn func([]Record) error) *book.ErrorResponse
+	// Import creates a book from every record, or in a dry run only checks
+	// that it could, and returns the result of each in order.
+	Import(ctx context.Context, records []Record, dryRun bool) []ImportResult
+}
+
+type marcService struct {
+	books book.BookService
+	val   *validator.Validate
+}
+
+func NewMARCService(books book.BookService) MARCService {
+	return &marcService{books: books, val: book.NewValidator()}
+}
+
+func (s *marcService) Get(ctx context.Context, id int) (Record, *book.ErrorResponse) {
+	b, errResp := s.books.Get(ctx, id)
+	if errResp != nil {
+		return Record{}, errResp
+	}
+	return FromBook(b), nil
+}
+
+func (s *marcService) Export(ctx context.Context, fn func([]Record) error) *book.ErrorResponse {
+	return s.books.Export(ctx, book.BookFilter{}, nil, func(books []book.Book) error {
+		records := make([]Record, len(books))
+		for i, b := range books {
+			records[i] = FromBook(b)
+		}
+		return fn(records)
+	})
+}
+
+func (s *marcService) Import(ctx context.Context, records []Record, dryRun bool) []ImportResult {
+	results := make([]ImportResult, len(records))
+	// isbns maps the isbn of every record imported so far to its index.
+	isbns := map[string]int{}
+	for i, rec := range records {
+		req, dropped := ToBook(rec)
+		res := ImportResult{Title: req.Title, Dropped: dropped}
+		res.Status, res.ID, res.Err = s.importRecord(ctx, req, dryRun, isbns, i)
+		results[i] = res
+	}
+	return results
+}
+
+func (s *marcService) importRecord(ctx context.Context, req book.CreateOrUpdateBookRequest, dryRun bool, isbns map[string]int, index int) (int, int64, *book.ErrorResponse) {
+	if err := s.val.Struct(&req); err != nil {
+		logrus.Error("invalid MARC record ", index, ": ", err)
+		return http.StatusBadRequest, 0, book.GetErrorResponse(book.BadRequest, book.ValidationMessage(err), http.StatusBadRequest)
+	}
+	// The validator has accepted the isbn, so it normalizes.
+	isbn, _ := book.NormalizeISBN(req.ISBN)
+	if first, ok := isbns[isbn]; ok && isbn != "" {
+		return http.StatusConflict, 0, book.GetErrorResponse(book.IsbnAlreadyExists, fmt.Sprintf("same isbn as record %d", first), http.StatusConflict)
+	}
+	var id int64
+	status := http.StatusOK
+	if dryRun {
+		if isbn != "" {
+			_, errResp := s.books.GetByISBN(ctx, isbn)
+			if errResp == nil {
+				return http.StatusConflict, 0, book.GetErrorResponseByCode(book.IsbnAlreadyExists)
+			}
+			if errResp.ErrorCode != book.BookNotFound {
+				return errResp.HttpStatusCode, 0, errResp
+			}
+		}
+	} else {
+		var errResp *book.ErrorResponse
+		if id, errResp = s.books.Create(ctx, req); errResp != nil {
+			return errResp.HttpStatusCode, 0, errResp
+		}
+		status = http.StatusCreated
+	}
+	if isbn != "" {
+		isbns[isbn] = index
+	}
+	return status, id, nil
+}
@@ -0,0 +1,106 @@
+package marc_test
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/marc"
+	mock_book "book-store/internal/mocks"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MARCServiceTestSuite struct {
+	suite.Suite
+	marcService     marc.MARCService
+	mockBookService *mock_book.MockBookService
+	ctrl            *gomock.Controller
+}
+
+func TestMARCServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(MARCServiceTestSuite))
+}
+
+func (m *MARCServiceTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockBookService = mock_book.NewMockBookService(m.ctrl)
+	m.marcService = marc.NewMARCService(m.mockBookService)
+}
+
+func (m *MARCServiceTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func record(title, author, isbn string) marc.Record {
+	r := marc.Record{}
+	r.ControlField("001", "ocm1")
+	r.DataField("020", ' ', ' ', "a", isbn)
+	r.DataField("100", '1', ' ', "a", author)
+	r.DataField("245", '1', '0', "a", title, "c", "by "+author)
+	return r
+}
+
+func (m *MARCServiceTestSuite) TestImport_ShouldCreateABookPerValidRecord() {
+	m.mockBookService.EXPECT().Create(context.Background(), book.CreateOrUpdateBookRequest{
+		Title: "Dune", Author: "Frank Herbert", ISBN: "9780441013593",
+	}).Return(int64(12), nil)
+	m.mockBookService.EXPECT().Create(context.Background(), book.CreateOrUpdateBookRequest{
+		Title: "Emma", Author: "Jane Austen", ISBN: "9780141439587",
+	}).Return(int64(0), book.GetErrorResponseByCode(book.IsbnAlreadyExists))
+
+	results := m.marcService.Import(context.Background(), []marc.Record{
+		record("Dune", "Herbert, Frank", "9780441013593"),
+		record("", "Nobody", ""),
+		record("Dune", "Herbert, Frank", "0441013597"),
+		record("Emma", "Austen, Jane", "9780141439587"),
+	}, false)
+	m.Suite.Equal([]marc.ImportResult{
+		{Status: http.StatusCreated, ID: 12, Title: "Dune", Dropped: []string{"001", "245$c"}},
+		{Status: http.StatusBadRequest, Dropped: []string{"001", "245$c"},
+			Err: book.GetErrorResponse(book.BadRequest, "Title failed on 'required'", http.StatusBadRequest)},
+		{Status: http.StatusConflict, Title: "Dune", Dropped: []string{"001", "245$c"},
+			Err: book.GetErrorResponse(book.IsbnAlreadyExists, "same isbn as record 0", http.StatusConflict)},
+		{Status: http.StatusConflict, Title: "Emma", Dropped: []string{"001", "245$c"},
+			Err: book.GetErrorResponseByCode(book.IsbnAlreadyExists)},
+	}, results)
+}
+
+func (m *MARCServiceTestSuite) TestImport_ShouldOnlyCheckForDuplicatesInADryRun() {
+	m.mockBookService.EXPECT().GetByISBN(context.Background(), "9780441013593").Return(book.Book{ID: 5}, nil)
+	m.mockBookService.EXPECT().GetByISBN(context.Background(), "9780141439587").Return(book.Book{}, book.GetErrorResponseByCode(book.BookNotFound))
+
+	results := m.marcService.Import(context.Background(), []marc.Record{
+		record("Dune", "Herbert, Frank", "9780441013593"),
+		record("Emma", "Austen, Jane", "9780141439587"),
+	}, true)
+	m.Suite.Equal(http.StatusConflict, results[0].Status)
+	m.Suite.Equal(http.StatusOK, results[1].Status)
+	m.Suite.Nil(results[1].Err)
+}
+
+func (m *MARCServiceTestSuite) TestExport_ShouldMapEveryBookToARecord() {
+	m.mockBookService.EXPECT().Export(context.Background(), book.BookFilter{}, nil, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ book.BookFilter, _ []book.SortField, fn func([]book.Book) error) *book.ErrorResponse {
+			m.Suite.Nil(fn([]book.Book{{ID: 12, Title: "Dune"}, {ID: 13, Title: "Emma"}}))
+			return nil
+		})
+
+	var ids []string
+	errResp := m.marcService.Export(context.Background(), func(records []marc.Record) error {
+		for _, r := range records {
+			ids = append(ids, r.Fields[0].Value)
+		}
+		return nil
+	})
+	m.Suite.Nil(errResp)
+	m.Suite.Equal([]string{"12", "13"}, ids)
+}
+
+func (m *MARCServiceTestSuite) TestGet_ShouldReturnBookNotFound() {
+	m.mockBookService.EXPECT().Get(context.Background(), 12).Return(book.Book{}, book.GetErrorResponseByCode(book.BookNotFound))
+
+	_, errResp := m.marcService.Get(context.Background(), 12)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.BookNotFound), errResp)
+}
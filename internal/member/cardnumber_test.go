@@ -0,0 +1,26 @@
+package member_test
+
+import (
+	"book-store/internal/member"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateCardNumber(t *testing.T) {
+	for range 50 {
+		card, err := member.GenerateCardNumber()
+		require.NoError(t, err)
+		require.Len(t, card, 14)
+		require.True(t, strings.HasPrefix(card, "29"))
+		require.True(t, member.ValidCardNumber(card), card)
+	}
+}
+
+func TestValidCardNumber(t *testing.T) {
+	require.True(t, member.ValidCardNumber("79927398713"))
+	require.False(t, member.ValidCardNumber("79927398710"))
+	require.False(t, member.ValidCardNumber("7992739871a"))
+	require.False(t, member.ValidCardNumber("7"))
+}
@@ -0,0 +1,39 @@
+package member
+
+type CreateOrUpdateMemberRequest struct {
+	FirstName      string `json:"firstName" validate:"required,min=1,max=100" example:"Hermione"`
+	LastName       string `json:"lastName" validate:"required,min=1,max=100" example:"Granger"`
+	Email          string `json:"email" validate:"required,email,max=255" example:"hermione@example.com"`
+	Phone          string `json:"phone" validate:"omitempty,max=32" example:"+44 20 7946 0958"`
+	Address        string `json:"address" validate:"omitempty,max=500" example:"12 Grimmauld Place, London"`
+	MembershipType string `json:"membershipType" validate:"omitempty,oneof=standard student senior staff" example:"student"`
+	ExpiresOn      string `json:"expiresOn" validate:"omitempty,datetime=2006-01-02" example:"2027-09-01"`
+}
+
+type SuspendMemberRequest struct {
+	Reason string `json:"reason" validate:"required,min=1,max=500" example:"Repeatedly damaged borrowed books"`
+}
+
+type MemberResponse struct {
+	ID               int    `json:"id" example:"1"`
+	CardNumber       string `json:"cardNumber" example:"29000012345674"`
+	FirstName        string `json:"firstName" example:"Hermione"`
+	LastName         string `json:"lastName" example:"Granger"`
+	Email            string `json:"email" example:"hermione@example.com"`
+	Phone            string `json:"phone" example:"+44 20 7946 0958"`
+	Address          string `json:"address" example:"12 Grimmauld Place, London"`
+	MembershipType   string `json:"membershipType" example:"student"`
+	MemberSince      string `json:"memberSince" example:"2026-09-01"`
+	ExpiresOn        string `json:"expiresOn" example:"2027-09-01"`
+	Status           string `json:"status" example:"active"`
+	SuspendedAt      string `json:"suspendedAt,omitempty" example:"2026-10-01T09:30:00Z"`
+	SuspensionReason string `json:"suspensionReason,omitempty" example:"Repeatedly damaged borrowed books"`
+}
+
+type PaginatedMemberListResponse struct {
+	Page       int              `json:"page" example:"1"`
+	Limit      int              `json:"limit" example:"10"`
+	Total      int              `json:"total" example:"42"`
+	TotalPages int              `json:"totalPages" example:"5"`
+	Data       []MemberResponse `json:"data"`
+}
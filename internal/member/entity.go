@@ -0,0 +1,44 @@
+package member
+
+import "time"
+
+type Member struct {
+	ID             int       `sql:"id"`
+	CardNumber     string    `sql:"card_number"`
+	FirstName      string    `sql:"first_name"`
+	LastName       string    `sql:"last_name"`
+	Email          string    `sql:"email"`
+	Phone          string    `sql:"phone"`
+	Address        string    `sql:"address"`
+	MembershipType string    `sql:"membership_type"`
+	MemberSince    time.Time `sql:"member_since"`
+	ExpiresOn      time.Time `sql:"expires_on"`
+	// SuspendedAt is nil while the member is in good standing.
+	SuspendedAt      *time.Time `sql:"suspended_at"`
+	SuspensionReason string     `sql:"suspension_reason"`
+}
+
+const (
+	MembershipStandard = "standard"
+	MembershipStudent  = "student"
+	MembershipSenior   = "senior"
+	MembershipStaff    = "staff"
+)
+
+const (
+	StatusActive    = "active"
+	StatusExpired   = "expired"
+	StatusSuspended = "suspended"
+)
+
+// Status reports whether the member may use the library on the given day.
+// A suspension wins over an expired membership.
+func (m Member) Status(now time.Time) string {
+	switch {
+	case m.SuspendedAt != nil:
+		return StatusSuspended
+	case m.ExpiresOn.Before(now.Truncate(24 * time.Hour)):
+		return StatusExpired
+	}
+	return StatusActive
+}
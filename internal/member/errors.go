@@ -0,0 +1,39 @@
+package member
+
+import (
+	"book-store/internal/book"
+	"net/http"
+)
+
+const (
+	MemberNotFound         book.ErrorCode = "MEMBER_NOT_FOUND"
+	EmailAlreadyRegistered book.ErrorCode = "EMAIL_ALREADY_REGISTERED"
+	MemberHasLoans         book.ErrorCode = "MEMBER_HAS_LOANS"
+)
+
+var errorResponseMap = map[book.ErrorCode]*book.ErrorResponse{
+	MemberNotFound: {
+		HttpStatusCode: http.StatusNotFound,
+		ErrorCode:      MemberNotFound,
+		ErrorMessage:   "member not found",
+	},
+	EmailAlreadyRegistered: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      EmailAlreadyRegistered,
+		ErrorMessage:   "a member with this email is already registered",
+	},
+	MemberHasLoans: {
+		HttpStatusCode: http.StatusConflict,
+		ErrorCode:      MemberHasLoans,
+		ErrorMessage:   "member has a loan or fine history and cannot be deleted",
+	},
+}
+
+// GetErrorResponseByCode resolves member specific codes and falls back to the
+// codes shared with the book package.
+func GetErrorResponseByCode(errCode book.ErrorCode) *book.ErrorResponse {
+	if errResponse, ok := errorResponseMap[errCode]; ok {
+		return errResponse
+	}
+	return book.GetErrorResponseByCode(errCode)
+}
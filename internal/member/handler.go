@@ -0,0 +1,308 @@
+package member
+
+import (
+	"book-store/internal/book"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+var maxLimit int = 100
+
+type MemberHandler struct {
+	svc MemberService
+	val validator.Validate
+}
+
+func NewMemberHandler(s MemberService) *MemberHandler {
+	return &MemberHandler{svc: s, val: *validator.New()}
+}
+
+// List godoc
+// @Summary      Search members with pagination
+// @Description  Returns a paginated list of members ordered by name. q matches part of the name or email, or the exact card number
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Param        q               query     string  false  "Part of the name or email, or a card number"
+// @Param        membershipType  query     string  false  "Only members of this membership type"  Enums(standard, student, senior, staff)
+// @Param        suspended       query     bool    false  "Only suspended (true) or not suspended (false) members"
+// @Param        page            query     int     false  "Page number (default 1)"    default(1)
+// @Param        limit           query     int     false  "Page size (1–100, default 10)" default(10)
+// @Success      200    {object}  PaginatedMemberListResponse
+// @Failure      400    {object}  book.ErrorResponse
+// @Router       /members [get]
+func (h *MemberHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, limit := 1, 10
+	if v := q.Get("page"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil {
+			logrus.Error("invalid page number provided ", v)
+			sendError(w, *GetErrorResponseByCode(book.BadRequest))
+			return
+		}
+		page = max(p, 1)
+	}
+	if v := q.Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil {
+			logrus.Error("invalid limit number provided ", v)
+			sendError(w, *GetErrorResponseByCode(book.BadRequest))
+			return
+		}
+		if l >= 1 {
+			limit = min(l, maxLimit)
+		}
+	}
+	f := MemberFilter{Query: strings.TrimSpace(q.Get("q")), MembershipType: q.Get("membershipType")}
+	if err := h.val.Var(f.MembershipType, "omitempty,oneof=standard student senior staff"); err != nil {
+		logrus.Error("invalid membership type provided ", f.MembershipType)
+		sendError(w, *book.GetErrorResponse(book.BadRequest, "membershipType failed on 'oneof'", http.StatusBadRequest))
+		return
+	}
+	if v := q.Get("suspended"); v != "" {
+		suspended, err := strconv.ParseBool(v)
+		if err != nil {
+			logrus.Error("invalid suspended flag provided ", v)
+			sendError(w, *GetErrorResponseByCode(book.BadRequest))
+			return
+		}
+		f.Suspended = &suspended
+	}
+	offset := (page - 1) * limit
+
+	members, totalCount, err := h.svc.List(r.Context(), f, limit, offset)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	now := time.Now()
+	out := make([]MemberResponse, len(members))
+	for i, m := range members {
+		out[i] = toMemberResponse(m, now)
+	}
+	json.NewEncoder(w).Encode(PaginatedMemberListResponse{
+		Page:       page,
+		Limit:      limit,
+		Total:      totalCount,
+		TotalPages: int(math.Ceil(float64(totalCount) / float64(limit))),
+		Data:       out,
+	})
+}
+
+// Get godoc
+// @Summary      Get member by ID
+// @Description  Retrieve a single member by its ID
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int   true   "Member ID"
+// @Success      200    {object}  MemberResponse
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Router       /members/{id} [get]
+func (h *MemberHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := memberID(w, r)
+	if !ok {
+		return
+	}
+	m, err := h.svc.Get(r.Context(), id)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	json.NewEncoder(w).Encode(toMemberResponse(m, time.Now()))
+}
+
+// Register godoc
+// @Summary      Register a new member
+// @Description  Register a patron and issue a library card. Membership type defaults to standard and the membership runs for a year unless expiresOn is given
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Param        member  body      CreateOrUpdateMemberRequest  true  "Member data"
+// @Success      201    {object}  nil
+// @Header       201    {string}  Location  "URL of registered member"
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      409    {object}  book.ErrorResponse
+// @Router       /members [post]
+func (h *MemberHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req CreateOrUpdateMemberRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+	mId, err := h.svc.Register(r.Context(), req)
+	if err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.Header().Set("location", fmt.Sprintf("%s/%d", "/members", mId))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// Update godoc
+// @Summary      Update member by ID
+// @Description  Replace the contact details and membership of a member. An empty membershipType or expiresOn keeps the stored value
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                          true  "Member ID"
+// @Param        member  body      CreateOrUpdateMemberRequest  true  "Member data"
+// @Success      204    {object}  nil
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Failure      409    {object}  book.ErrorResponse
+// @Router       /members/{id} [put]
+func (h *MemberHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := memberID(w, r)
+	if !ok {
+		return
+	}
+	var req CreateOrUpdateMemberRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+	if err := h.svc.Update(r.Context(), id, req); err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Suspend godoc
+// @Summary      Suspend a member
+// @Description  Suspend the member's borrowing privileges. Suspending an already suspended member only updates the reason
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int                   true  "Member ID"
+// @Param        suspension  body      SuspendMemberRequest  true  "Suspension reason"
+// @Success      204    {object}  nil
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Router       /members/{id}/suspend [post]
+func (h *MemberHandler) Suspend(w http.ResponseWriter, r *http.Request) {
+	id, ok := memberID(w, r)
+	if !ok {
+		return
+	}
+	var req SuspendMemberRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+	if err := h.svc.Suspend(r.Context(), id, req.Reason); err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Reinstate godoc
+// @Summary      Lift a member's suspension
+// @Description  Restore the borrowing privileges of a suspended member
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int   true   "Member ID"
+// @Success      204    {object}  nil
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Router       /members/{id}/reinstate [post]
+func (h *MemberHandler) Reinstate(w http.ResponseWriter, r *http.Request) {
+	id, ok := memberID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.Reinstate(r.Context(), id); err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Delete godoc
+// @Summary      Delete member by ID
+// @Description  Remove a member record. Members who have borrowed anything cannot be deleted
+// @Tags         members
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int   true   "Member ID"
+// @Success      204    {object}  nil
+// @Failure      400    {object}  book.ErrorResponse
+// @Failure      404    {object}  book.ErrorResponse
+// @Failure      409    {object}  book.ErrorResponse
+// @Router       /members/{id} [delete]
+func (h *MemberHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := memberID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(r.Context(), id); err != nil {
+		sendError(w, *err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *MemberHandler) decode(w http.ResponseWriter, r *http.Request, req any) bool {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		sendError(w, *GetErrorResponseByCode(book.BadRequest))
+		return false
+	}
+	if err := h.val.Struct(req); err != nil {
+		var errs []string
+		for _, fe := range err.(validator.ValidationErrors) {
+			errs = append(errs, fmt.Sprintf("%s failed on '%s'", fe.Field(), fe.Tag()))
+		}
+		logrus.Error("error while validating the request. error is ", errs)
+		sendError(w, *book.GetErrorResponse(book.BadRequest, strings.Join(errs, "; "), http.StatusBadRequest))
+		return false
+	}
+	return true
+}
+
+func memberID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		logrus.Error("invalid member id provided ", mux.Vars(r)["id"])
+		sendError(w, *GetErrorResponseByCode(book.BadRequest))
+		return 0, false
+	}
+	return id, true
+}
+
+func toMemberResponse(m Member, now time.Time) MemberResponse {
+	resp := MemberResponse{
+		ID:               m.ID,
+		CardNumber:       m.CardNumber,
+		FirstName:        m.FirstName,
+		LastName:         m.LastName,
+		Email:            m.Email,
+		Phone:            m.Phone,
+		Address:          m.Address,
+		MembershipType:   m.MembershipType,
+		MemberSince:      m.MemberSince.Format(time.DateOnly),
+		ExpiresOn:        m.ExpiresOn.Format(time.DateOnly),
+		Status:           m.Status(now),
+		SuspensionReason: m.SuspensionReason,
+	}
+	if m.SuspendedAt != nil {
+		resp.SuspendedAt = m.SuspendedAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+func sendError(w http.ResponseWriter, errResponse book.ErrorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errResponse.HttpStatusCode)
+	json.NewEncoder(w).Encode(errResponse)
+}
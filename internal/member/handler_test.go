@@ -0,0 +1,110 @@
+package member_test
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/member"
+	mock_book "book-store/internal/mocks"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type MemberHandlerTestSuite struct {
+	suite.Suite
+	memberHandler *member.MemberHandler
+	mockService   *mock_book.MockMemberService
+	ctrl          *gomock.Controller
+}
+
+func TestMemberHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(MemberHandlerTestSuite))
+}
+
+func (m *MemberHandlerTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockService = mock_book.NewMockMemberService(m.ctrl)
+	m.memberHandler = member.NewMemberHandler(m.mockService)
+}
+
+func (m *MemberHandlerTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func (m *MemberHandlerTestSuite) TestList_ShouldPassFiltersAndReportStatus() {
+	r, _ := http.NewRequest("GET", "/members?q=granger&membershipType=student&suspended=true&limit=5", nil)
+	w := httptest.NewRecorder()
+	suspended := true
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, -1, 0)
+	suspendedAt := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	m.mockService.EXPECT().List(r.Context(), member.MemberFilter{Query: "granger", MembershipType: "student", Suspended: &suspended}, 5, 0).
+		Return([]member.Member{{ID: 3, CardNumber: "29000012345674", FirstName: "Hermione", LastName: "Granger",
+			MembershipType: "student", MemberSince: since, ExpiresOn: since.AddDate(1, 0, 0),
+			SuspendedAt: &suspendedAt, SuspensionReason: "late returns"}}, 1, nil)
+
+	m.memberHandler.List(w, r)
+	m.Suite.Equal(200, w.Result().StatusCode)
+
+	var body member.PaginatedMemberListResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&body))
+	m.Suite.Equal(1, body.TotalPages)
+	m.Suite.Equal(member.StatusSuspended, body.Data[0].Status)
+	m.Suite.Equal("2026-10-01T09:30:00Z", body.Data[0].SuspendedAt)
+}
+
+func (m *MemberHandlerTestSuite) TestList_ShouldReturnBadRequestForUnknownMembershipType() {
+	r, _ := http.NewRequest("GET", "/members?membershipType=gold", nil)
+	w := httptest.NewRecorder()
+
+	m.memberHandler.List(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+}
+
+func (m *MemberHandlerTestSuite) TestRegister_ShouldReturnLocationOfRegisteredMember() {
+	req := member.CreateOrUpdateMemberRequest{FirstName: "Hermione", LastName: "Granger", Email: "hermione@example.com"}
+	b, _ := json.Marshal(req)
+	r, _ := http.NewRequest("POST", "/members", bytes.NewReader(b))
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Register(r.Context(), req).Return(int64(3), nil)
+
+	m.memberHandler.Register(w, r)
+	m.Suite.Equal(201, w.Result().StatusCode)
+	m.Suite.Equal("/members/3", w.Result().Header.Get("Location"))
+}
+
+func (m *MemberHandlerTestSuite) TestRegister_ShouldReturnBadRequestForInvalidEmail() {
+	r, _ := http.NewRequest("POST", "/members", bytes.NewReader([]byte(`{"firstName": "Hermione", "lastName": "Granger", "email": "not-an-email"}`)))
+	w := httptest.NewRecorder()
+
+	m.memberHandler.Register(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+
+	var actualErr book.ErrorResponse
+	m.Suite.Nil(json.NewDecoder(w.Result().Body).Decode(&actualErr))
+	m.Suite.Equal("Email failed on 'email'", actualErr.Error())
+}
+
+func (m *MemberHandlerTestSuite) TestSuspend_ShouldRequireReason() {
+	r, _ := http.NewRequest("POST", "/members/3/suspend", bytes.NewReader([]byte(`{}`)))
+	r = mux.SetURLVars(r, map[string]string{"id": "3"})
+	w := httptest.NewRecorder()
+
+	m.memberHandler.Suspend(w, r)
+	m.Suite.Equal(400, w.Result().StatusCode)
+}
+
+func (m *MemberHandlerTestSuite) TestGet_ShouldReturnNotFoundWhenServiceReturnsNotFound() {
+	r, _ := http.NewRequest("GET", "/members/3", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "3"})
+	w := httptest.NewRecorder()
+	m.mockService.EXPECT().Get(r.Context(), 3).Return(member.Member{}, member.GetErrorResponseByCode(member.MemberNotFound))
+
+	m.memberHandler.Get(w, r)
+	m.Suite.Equal(404, w.Result().StatusCode)
+}
@@ -0,0 +1,189 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrNotFound            = errors.New("member not found")
+	ErrDuplicateEmail      = errors.New("email already registered")
+	ErrDuplicateCardNumber = errors.New("card number already issued")
+	ErrInUse               = errors.New("member has loans")
+)
+
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+// MemberFilter narrows List. Query matches the name, email or card number;
+// empty fields and a nil Suspended do not filter.
+type MemberFilter struct {
+	Query          string
+	MembershipType string
+	Suspended      *bool
+}
+
+type MemberRepository interface {
+	Create(ctx context.Context, m Member) (int64, error)
+	GetByID(ctx context.Context, id int) (Member, error)
+	List(ctx context.Context, f MemberFilter, limit, offset int) ([]Member, int, error)
+	Update(ctx context.Context, m Member) error
+	Suspend(ctx context.Context, id int, reason string) error
+	Reinstate(ctx context.Context, id int) error
+	Delete(ctx context.Context, id int) error
+}
+
+type sqlMemberRepo struct {
+	db *sql.DB
+}
+
+func NewMemberRepository(db *sql.DB) MemberRepository {
+	return &sqlMemberRepo{db: db}
+}
+
+const memberColumns = `id, card_number, first_name, last_name, email, phone, address,
+               membership_type, member_since, expires_on, suspended_at, suspension_reason`
+
+func (r *sqlMemberRepo) Create(ctx context.Context, m Member) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx, `
+        INSERT INTO members (card_number, first_name, last_name, email, phone, address, membership_type, member_since, expires_on)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		m.CardNumber, m.FirstName, m.LastName, m.Email, m.Phone, m.Address, m.MembershipType, m.MemberSince, m.ExpiresOn).Scan(&id)
+	if err != nil {
+		return 0, translateErr(err)
+	}
+	return id, nil
+}
+
+func (r *sqlMemberRepo) GetByID(ctx context.Context, id int) (Member, error) {
+	m, err := scanMember(r.db.QueryRowContext(ctx, `SELECT `+memberColumns+` FROM members WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return Member{}, ErrNotFound
+	}
+	return m, err
+}
+
+// List pages through members ordered by last and first name.
+func (r *sqlMemberRepo) List(ctx context.Context, f MemberFilter, limit, offset int) ([]Member, int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT `+memberColumns+`,
+               COUNT(*) OVER() AS total_count
+        FROM members
+        WHERE ($1 = '' OR first_name || ' ' || last_name ILIKE '%' || $1 || '%'
+                       OR email ILIKE '%' || $1 || '%'
+                       OR card_number = $1)
+          AND ($2 = '' OR membership_type = $2)
+          AND ($3::boolean IS NULL OR (suspended_at IS NOT NULL) = $3)
+        ORDER BY last_name, first_name, id
+        LIMIT $4 OFFSET $5`, f.Query, f.MembershipType, f.Suspended, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	var members []Member
+	var total int
+	for rows.Next() {
+		m := Member{}
+		var suspendedAt sql.NullTime
+		if err := rows.Scan(&m.ID, &m.CardNumber, &m.FirstName, &m.LastName, &m.Email, &m.Phone, &m.Address,
+			&m.MembershipType, &m.MemberSince, &m.ExpiresOn, &suspendedAt, &m.SuspensionReason, &total); err != nil {
+			return nil, 0, err
+		}
+		if suspendedAt.Valid {
+			m.SuspendedAt = &suspendedAt.Time
+		}
+		members = append(members, m)
+	}
+	return members, total, rows.Err()
+}
+
+// Update rewrites the contact details and membership of the member. The card
+// number and suspension state are left alone.
+func (r *sqlMemberRepo) Update(ctx context.Context, m Member) error {
+	res, err := r.db.ExecContext(ctx, `
+        UPDATE members SET first_name=$1, last_name=$2, email=$3, phone=$4, address=$5, membership_type=$6, expires_on=$7
+        WHERE id=$8`,
+		m.FirstName, m.LastName, m.Email, m.Phone, m.Address, m.MembershipType, m.ExpiresOn, m.ID)
+	if err != nil {
+		return translateErr(err)
+	}
+	return expectOneRow(res)
+}
+
+func (r *sqlMemberRepo) Suspend(ctx context.Context, id int, reason string) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE members SET suspended_at=COALESCE(suspended_at, $1), suspension_reason=$2 WHERE id=$3`,
+		time.Now().UTC(), reason, id)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res)
+}
+
+func (r *sqlMemberRepo) Reinstate(ctx context.Context, id int) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE members SET suspended_at=NULL, suspension_reason='' WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res)
+}
+
+func (r *sqlMemberRepo) Delete(ctx context.Context, id int) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM members WHERE id=$1`, id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return ErrInUse
+		}
+		return err
+	}
+	return expectOneRow(res)
+}
+
+func scanMember(row *sql.Row) (Member, error) {
+	m := Member{}
+	var suspendedAt sql.NullTime
+	err := row.Scan(&m.ID, &m.CardNumber, &m.FirstName, &m.LastName, &m.Email, &m.Phone, &m.Address,
+		&m.MembershipType, &m.MemberSince, &m.ExpiresOn, &suspendedAt, &m.SuspensionReason)
+	if err != nil {
+		return Member{}, err
+	}
+	if suspendedAt.Valid {
+		m.SuspendedAt = &suspendedAt.Time
+	}
+	return m, nil
+}
+
+func expectOneRow(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// translateErr maps driver errors the service cares about to repository errors.
+func translateErr(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != uniqueViolation {
+		return err
+	}
+	switch pqErr.Constraint {
+	case "members_email_key":
+		return ErrDuplicateEmail
+	case "members_card_number_key":
+		return ErrDuplicateCardNumber
+	}
+	return err
+}
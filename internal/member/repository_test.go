@@ -0,0 +1,97 @@
+package member_test
+
+import (
+	"book-store/internal/member"
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+)
+
+type MemberRepositoryTestSuite struct {
+	suite.Suite
+	memberRepository member.MemberRepository
+	sqlMock          sqlmock.Sqlmock
+	db               *sql.DB
+}
+
+func TestMemberRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MemberRepositoryTestSuite))
+}
+
+func (m *MemberRepositoryTestSuite) SetupTest() {
+	m.db, m.sqlMock, _ = sqlmock.New()
+	m.memberRepository = member.NewMemberRepository(m.db)
+}
+
+var memberRowColumns = []string{"id", "card_number", "first_name", "last_name", "email", "phone", "address",
+	"membership_type", "member_since", "expires_on", "suspended_at", "suspension_reason"}
+
+func (m *MemberRepositoryTestSuite) TestCreate_ShouldReturnDuplicateEmailErrorOnUniqueViolation() {
+	m.sqlMock.ExpectQuery("INSERT INTO members").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "members_email_key"})
+	_, err := m.memberRepository.Create(context.Background(), member.Member{Email: "hermione@example.com"})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(member.ErrDuplicateEmail, err)
+}
+
+func (m *MemberRepositoryTestSuite) TestCreate_ShouldReturnDuplicateCardNumberErrorOnUniqueViolation() {
+	m.sqlMock.ExpectQuery("INSERT INTO members").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "members_card_number_key"})
+	_, err := m.memberRepository.Create(context.Background(), member.Member{CardNumber: "29000000000000"})
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(member.ErrDuplicateCardNumber, err)
+}
+
+func (m *MemberRepositoryTestSuite) TestGetById_ShouldReturnMemberWithSuspension() {
+	since := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	suspended := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM members WHERE id = $1")).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows(memberRowColumns).
+			AddRow(3, "29000012345674", "Hermione", "Granger", "hermione@example.com", "", "", "student", since, since.AddDate(1, 0, 0), suspended, "late returns"))
+	got, err := m.memberRepository.GetByID(context.Background(), 3)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(member.Member{
+		ID: 3, CardNumber: "29000012345674", FirstName: "Hermione", LastName: "Granger", Email: "hermione@example.com",
+		MembershipType: "student", MemberSince: since, ExpiresOn: since.AddDate(1, 0, 0),
+		SuspendedAt: &suspended, SuspensionReason: "late returns",
+	}, got)
+}
+
+func (m *MemberRepositoryTestSuite) TestGetById_ShouldReturnNotFoundErrorIfNoMemberPresentForGivenId() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("FROM members WHERE id = $1")).WithArgs(3).
+		WillReturnError(sql.ErrNoRows)
+	_, err := m.memberRepository.GetByID(context.Background(), 3)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(member.ErrNotFound, err)
+}
+
+func (m *MemberRepositoryTestSuite) TestList_ShouldPassFiltersToQuery() {
+	since := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	suspended := false
+	m.sqlMock.ExpectQuery("FROM members").
+		WithArgs("granger", "student", &suspended, 10, 0).
+		WillReturnRows(sqlmock.NewRows(append(memberRowColumns, "total_count")).
+			AddRow(3, "29000012345674", "Hermione", "Granger", "hermione@example.com", "", "", "student", since, since.AddDate(1, 0, 0), nil, "", 1))
+	members, total, err := m.memberRepository.List(context.Background(),
+		member.MemberFilter{Query: "granger", MembershipType: "student", Suspended: &suspended}, 10, 0)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Nil(err)
+	m.Suite.Equal(1, total)
+	m.Suite.Len(members, 1)
+	m.Suite.Nil(members[0].SuspendedAt)
+}
+
+func (m *MemberRepositoryTestSuite) TestReinstate_ShouldReturnNotFoundErrorWhenNothingWasUpdated() {
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE members SET suspended_at=NULL")).WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err := m.memberRepository.Reinstate(context.Background(), 3)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+	m.Suite.Equal(member.ErrNotFound, err)
+}
@@ -0,0 +1,158 @@
+package member
+
+import (
+	"book-store/internal/book"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// membershipTermYears is how long a membership runs when the request does not
+// give an expiry date.
+const membershipTermYears = 1
+
+// cardNumberAttempts bounds the retries when a generated card number collides
+// with one already issued.
+const cardNumberAttempts = 3
+
+type MemberService interface {
+	Register(ctx context.Context, req CreateOrUpdateMemberRequest) (int64, *book.ErrorResponse)
+	Get(ctx context.Context, id int) (Member, *book.ErrorResponse)
+	List(ctx context.Context, f MemberFilter, limit, offset int) ([]Member, int, *book.ErrorResponse)
+	Update(ctx context.Context, id int, req CreateOrUpdateMemberRequest) *book.ErrorResponse
+	Suspend(ctx context.Context, id int, reason string) *book.ErrorResponse
+	Reinstate(ctx context.Context, id int) *book.ErrorResponse
+	Delete(ctx context.Context, id int) *book.ErrorResponse
+}
+
+type memberService struct {
+	repository MemberRepository
+}
+
+func NewMemberService(r MemberRepository) MemberService {
+	return &memberService{repository: r}
+}
+
+func (s *memberService) Register(ctx context.Context, req CreateOrUpdateMemberRequest) (int64, *book.ErrorResponse) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	m := Member{
+		FirstName:      req.FirstName,
+		LastName:       req.LastName,
+		Email:          req.Email,
+		Phone:          req.Phone,
+		Address:        req.Address,
+		MembershipType: req.MembershipType,
+		MemberSince:    today,
+		ExpiresOn:      today.AddDate(membershipTermYears, 0, 0),
+	}
+	if m.MembershipType == "" {
+		m.MembershipType = MembershipStandard
+	}
+	if req.ExpiresOn != "" {
+		m.ExpiresOn, _ = time.Parse(time.DateOnly, req.ExpiresOn)
+	}
+	for attempt := 1; ; attempt++ {
+		card, err := GenerateCardNumber()
+		if err != nil {
+			logrus.Error("error while generating card number. error is ", err)
+			return 0, GetErrorResponseByCode(book.InternalServerError)
+		}
+		m.CardNumber = card
+		id, err := s.repository.Create(ctx, m)
+		switch {
+		case err == nil:
+			return id, nil
+		case err == ErrDuplicateCardNumber && attempt < cardNumberAttempts:
+			continue
+		case err == ErrDuplicateEmail:
+			logrus.Error("member with email ", req.Email, " already exists")
+			return 0, GetErrorResponseByCode(EmailAlreadyRegistered)
+		}
+		logrus.Error("error while registering member. error is ", err)
+		return 0, GetErrorResponseByCode(book.InternalServerError)
+	}
+}
+
+func (s *memberService) Get(ctx context.Context, id int) (Member, *book.ErrorResponse) {
+	m, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		if err == ErrNotFound {
+			logrus.Error("no member found for given id ", id)
+			return Member{}, GetErrorResponseByCode(MemberNotFound)
+		}
+		logrus.Error("error while fetching the member for id ", id, " error is ", err)
+		return Member{}, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return m, nil
+}
+
+func (s *memberService) List(ctx context.Context, f MemberFilter, limit, offset int) ([]Member, int, *book.ErrorResponse) {
+	members, totalCount, err := s.repository.List(ctx, f, limit, offset)
+	if err != nil {
+		logrus.Error("error while fetching the members. error is ", err)
+		return nil, 0, GetErrorResponseByCode(book.InternalServerError)
+	}
+	return members, totalCount, nil
+}
+
+// Update replaces the contact details and membership type. The expiry date is
+// kept when the request leaves it empty.
+func (s *memberService) Update(ctx context.Context, id int, req CreateOrUpdateMemberRequest) *book.ErrorResponse {
+	m, errResp := s.Get(ctx, id)
+	if errResp != nil {
+		return errResp
+	}
+	m.FirstName = req.FirstName
+	m.LastName = req.LastName
+	m.Email = req.Email
+	m.Phone = req.Phone
+	m.Address = req.Address
+	if req.MembershipType != "" {
+		m.MembershipType = req.MembershipType
+	}
+	if req.ExpiresOn != "" {
+		m.ExpiresOn, _ = time.Parse(time.DateOnly, req.ExpiresOn)
+	}
+	if err := s.repository.Update(ctx, m); err != nil {
+		return writeErrorResponse(id, err)
+	}
+	return nil
+}
+
+func (s *memberService) Suspend(ctx context.Context, id int, reason string) *book.ErrorResponse {
+	if err := s.repository.Suspend(ctx, id, reason); err != nil {
+		return writeErrorResponse(id, err)
+	}
+	return nil
+}
+
+func (s *memberService) Reinstate(ctx context.Context, id int) *book.ErrorResponse {
+	if err := s.repository.Reinstate(ctx, id); err != nil {
+		return writeErrorResponse(id, err)
+	}
+	return nil
+}
+
+func (s *memberService) Delete(ctx context.Context, id int) *book.ErrorResponse {
+	if err := s.repository.Delete(ctx, id); err != nil {
+		return writeErrorResponse(id, err)
+	}
+	return nil
+}
+
+func writeErrorResponse(id int, err error) *book.ErrorResponse {
+	switch err {
+	case ErrNotFound:
+		logrus.Error("no member found for given id ", id)
+		return GetErrorResponseByCode(MemberNotFound)
+	case ErrDuplicateEmail:
+		logrus.Error("another member already uses this email")
+		return GetErrorResponseByCode(EmailAlreadyRegistered)
+	case ErrInUse:
+		logrus.Error("member ", id, " has loans")
+		return GetErrorResponseByCode(MemberHasLoans)
+	}
+	logrus.Error("error while writing member ", id, " error is ", err)
+	return GetErrorResponseByCode(book.InternalServerError)
+}
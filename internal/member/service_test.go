@@ -0,0 +1,104 @@
+package member_test
+
+import (
+	"book-store/internal/book"
+	"book-store/internal/member"
+	mock_book "book-store/internal/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MemberServiceTestSuite struct {
+	suite.Suite
+	memberService member.MemberService
+	mockRepo      *mock_book.MockMemberRepository
+	ctrl          *gomock.Controller
+}
+
+func TestMemberServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(MemberServiceTestSuite))
+}
+
+func (m *MemberServiceTestSuite) SetupTest() {
+	m.ctrl = gomock.NewController(m.Suite.T())
+	m.mockRepo = mock_book.NewMockMemberRepository(m.ctrl)
+	m.memberService = member.NewMemberService(m.mockRepo)
+}
+
+func (m *MemberServiceTestSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func (m *MemberServiceTestSuite) TestRegister_ShouldIssueCardAndDefaultMembership() {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	m.mockRepo.EXPECT().Create(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, mem member.Member) (int64, error) {
+		m.Suite.True(member.ValidCardNumber(mem.CardNumber))
+		m.Suite.Equal(member.MembershipStandard, mem.MembershipType)
+		m.Suite.Equal(today, mem.MemberSince)
+		m.Suite.Equal(today.AddDate(1, 0, 0), mem.ExpiresOn)
+		return 3, nil
+	})
+	id, err := m.memberService.Register(context.Background(), member.CreateOrUpdateMemberRequest{
+		FirstName: "Hermione", LastName: "Granger", Email: "hermione@example.com",
+	})
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(3), id)
+}
+
+func (m *MemberServiceTestSuite) TestRegister_ShouldRetryWhenCardNumberCollides() {
+	var cards []string
+	m.mockRepo.EXPECT().Create(context.Background(), gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, mem member.Member) (int64, error) {
+		cards = append(cards, mem.CardNumber)
+		if len(cards) == 1 {
+			return 0, member.ErrDuplicateCardNumber
+		}
+		return 3, nil
+	})
+	id, err := m.memberService.Register(context.Background(), member.CreateOrUpdateMemberRequest{Email: "hermione@example.com"})
+	m.Suite.Nil(err)
+	m.Suite.Equal(int64(3), id)
+}
+
+func (m *MemberServiceTestSuite) TestRegister_ShouldGiveUpAfterRepeatedCardNumberCollisions() {
+	m.mockRepo.EXPECT().Create(context.Background(), gomock.Any()).Times(3).Return(int64(0), member.ErrDuplicateCardNumber)
+	_, err := m.memberService.Register(context.Background(), member.CreateOrUpdateMemberRequest{Email: "hermione@example.com"})
+	m.Suite.Equal(book.GetErrorResponseByCode(book.InternalServerError), err)
+}
+
+func (m *MemberServiceTestSuite) TestRegister_ShouldReturnConflictWhenEmailIsTaken() {
+	m.mockRepo.EXPECT().Create(context.Background(), gomock.Any()).Return(int64(0), member.ErrDuplicateEmail)
+	_, err := m.memberService.Register(context.Background(), member.CreateOrUpdateMemberRequest{Email: "hermione@example.com"})
+	m.Suite.Equal(member.GetErrorResponseByCode(member.EmailAlreadyRegistered), err)
+}
+
+func (m *MemberServiceTestSuite) TestUpdate_ShouldKeepExpiryAndTypeWhenNotGiven() {
+	since := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	stored := member.Member{ID: 3, CardNumber: "29000012345674", FirstName: "Hermione", LastName: "Granger",
+		Email: "hermione@example.com", MembershipType: "student", MemberSince: since, ExpiresOn: since.AddDate(1, 0, 0)}
+	m.mockRepo.EXPECT().GetByID(context.Background(), 3).Return(stored, nil)
+	updated := stored
+	updated.Email = "h.granger@example.com"
+	updated.Phone = "+44 20 7946 0958"
+	m.mockRepo.EXPECT().Update(context.Background(), updated).Return(nil)
+	err := m.memberService.Update(context.Background(), 3, member.CreateOrUpdateMemberRequest{
+		FirstName: "Hermione", LastName: "Granger", Email: "h.granger@example.com", Phone: "+44 20 7946 0958",
+	})
+	m.Suite.Nil(err)
+}
+
+func (m *MemberServiceTestSuite) TestSuspend_ShouldReturnNotFoundIfMemberDoesNotExist() {
+	m.mockRepo.EXPECT().Suspend(context.Background(), 3, "late returns").Return(member.ErrNotFound)
+	err := m.memberService.Suspend(context.Background(), 3, "late returns")
+	m.Suite.Equal(member.GetErrorResponseByCode(member.MemberNotFound), err)
+}
+
+func (m *MemberServiceTestSuite) TestList_ShouldReturnInternalServerErrorIfRepositoryFails() {
+	m.mockRepo.EXPECT().List(context.Background(), member.MemberFilter{}, 10, 0).Return(nil, 0, errors.New("unable to connect"))
+	_, _, err := m.memberService.List(context.Background(), member.MemberFilter{}, 10, 0)
+	m.Suite.Equal(book.GetErrorResponseByCode(book.InternalServerError), err)
+}
@@ -0,0 +1,136 @@
+package migration_test
+
+import (
+	"book-store/internal/migration"
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MigratorTestSuite struct {
+	suite.Suite
+	db       *sql.DB
+	sqlMock  sqlmock.Sqlmock
+	migrator *migration.Migrator
+}
+
+func TestMigratorTestSuite(t *testing.T) {
+	suite.Run(t, new(MigratorTestSuite))
+}
+
+var testMigrations = fstest.MapFS{
+	"0001_create_books.up.sql":   {Data: []byte("CREATE TABLE books (id INT)")},
+	"0001_create_books.down.sql": {Data: []byte("DROP TABLE books")},
+	"0002_add_isbn.up.sql":       {Data: []byte("ALTER TABLE books ADD COLUMN isbn TEXT")},
+	"0002_add_isbn.down.sql":     {Data: []byte("ALTER TABLE books DROP COLUMN isbn")},
+}
+
+func (m *MigratorTestSuite) SetupTest() {
+	var err error
+	m.db, m.sqlMock, err = sqlmock.New()
+	m.Suite.Nil(err)
+	m.migrator, err = migration.NewMigratorFromFS(m.db, testMigrations)
+	m.Suite.Nil(err)
+}
+
+func (m *MigratorTestSuite) TearDownTest() {
+	m.db.Close()
+}
+
+func (m *MigratorTestSuite) expectLockAndTable() {
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (m *MigratorTestSuite) expectUnlock() {
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (m *MigratorTestSuite) TestUp_ShouldApplyOnlyPendingMigrationsInOrder() {
+	m.expectLockAndTable()
+	m.sqlMock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()))
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec("ALTER TABLE books ADD COLUMN isbn TEXT").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectExec("INSERT INTO schema_migrations").WithArgs(int64(2), "add_isbn").WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	m.expectUnlock()
+
+	applied, err := m.migrator.Up(context.Background())
+	m.Suite.Nil(err)
+	m.Suite.Len(applied, 1)
+	m.Suite.Equal(int64(2), applied[0].Version)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+}
+
+func (m *MigratorTestSuite) TestUp_ShouldRollbackAndStopWhenAMigrationFails() {
+	m.expectLockAndTable()
+	m.sqlMock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}))
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec("CREATE TABLE books").WillReturnError(errors.New("syntax error"))
+	m.sqlMock.ExpectRollback()
+	m.expectUnlock()
+
+	applied, err := m.migrator.Up(context.Background())
+	m.Suite.EqualError(err, "migration 1_create_books: syntax error")
+	m.Suite.Empty(applied)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+}
+
+func (m *MigratorTestSuite) TestDown_ShouldRollBackLatestAppliedMigration() {
+	m.expectLockAndTable()
+	m.sqlMock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()).AddRow(2, time.Now()))
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec("ALTER TABLE books DROP COLUMN isbn").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectExec("DELETE FROM schema_migrations").WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	m.sqlMock.ExpectCommit()
+	m.expectUnlock()
+
+	mig, err := m.migrator.Down(context.Background())
+	m.Suite.Nil(err)
+	m.Suite.Equal("add_isbn", mig.Name)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+}
+
+func (m *MigratorTestSuite) TestDown_ShouldFailWhenNothingIsApplied() {
+	m.expectLockAndTable()
+	m.sqlMock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}))
+	m.expectUnlock()
+
+	_, err := m.migrator.Down(context.Background())
+	m.Suite.ErrorIs(err, migration.ErrNothingToRollback)
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+}
+
+func (m *MigratorTestSuite) TestVerify_ShouldReportPendingMigrations() {
+	m.sqlMock.ExpectQuery("SELECT version FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	m.Suite.ErrorIs(m.migrator.Verify(context.Background()), migration.ErrPending)
+
+	m.sqlMock.ExpectQuery("SELECT version FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1).AddRow(2))
+	m.Suite.Nil(m.migrator.Verify(context.Background()))
+	m.Suite.Nil(m.sqlMock.ExpectationsWereMet())
+}
+
+func (m *MigratorTestSuite) TestNewMigratorFromFS_ShouldRejectMigrationWithoutDownFile() {
+	_, err := migration.NewMigratorFromFS(m.db, fstest.MapFS{
+		"0001_create_books.up.sql": {Data: []byte("CREATE TABLE books (id INT)")},
+	})
+	m.Suite.EqualError(err, "migration 1_create_books must have both up and down files")
+}
+
+func (m *MigratorTestSuite) TestNewMigrator_ShouldLoadEmbeddedMigrations() {
+	_, err := migration.NewMigrator(m.db)
+	m.Suite.Nil(err)
+}
@@ -1,7 +1,6 @@
-\c book_store
-CREATE TABLE books (
+CREATE TABLE IF NOT EXISTS books (
   id          INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
   title       VARCHAR(255) NOT NULL,
   author      VARCHAR(255) NOT NULL,
   description TEXT NOT NULL
-);
\ No newline at end of file
+);
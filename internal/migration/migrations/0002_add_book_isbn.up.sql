@@ -0,0 +1,2 @@
+ALTER TABLE books ADD COLUMN isbn CHAR(13);
+CREATE UNIQUE INDEX books_isbn_key ON books (isbn);
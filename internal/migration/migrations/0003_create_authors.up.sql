@@ -0,0 +1,30 @@
+CREATE TABLE authors (
+  id         INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+  name       VARCHAR(255) NOT NULL,
+  bio        TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX authors_name_idx ON authors (lower(name));
+
+CREATE TABLE book_authors (
+  book_id   INT NOT NULL,
+  author_id INT NOT NULL,
+  role      VARCHAR(20) NOT NULL DEFAULT 'author',
+  position  SMALLINT NOT NULL,
+  CONSTRAINT book_authors_pkey PRIMARY KEY (book_id, author_id, role),
+  CONSTRAINT book_authors_position_key UNIQUE (book_id, position),
+  CONSTRAINT book_authors_book_id_fkey FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+  CONSTRAINT book_authors_author_id_fkey FOREIGN KEY (author_id) REFERENCES authors (id) ON DELETE RESTRICT,
+  CONSTRAINT book_authors_role_check CHECK (role IN ('author', 'editor', 'translator', 'illustrator'))
+);
+CREATE INDEX book_authors_author_id_idx ON book_authors (author_id);
+
+-- Turn the free-text author of every existing book into an author record.
+-- Identical names (ignoring surrounding whitespace) become a single author.
+INSERT INTO authors (name)
+SELECT DISTINCT btrim(author) FROM books WHERE btrim(author) <> '';
+
+INSERT INTO book_authors (book_id, author_id, role, position)
+SELECT b.id, a.id, 'author', 1
+FROM books b
+JOIN authors a ON a.name = btrim(b.author);
@@ -0,0 +1,14 @@
+CREATE TABLE copies (
+  id          INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+  book_id     INT NOT NULL,
+  barcode     VARCHAR(64) NOT NULL,
+  acquired_on DATE NOT NULL DEFAULT CURRENT_DATE,
+  condition   VARCHAR(20) NOT NULL DEFAULT 'good',
+  status      VARCHAR(20) NOT NULL DEFAULT 'available',
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT copies_barcode_key UNIQUE (barcode),
+  CONSTRAINT copies_book_id_fkey FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+  CONSTRAINT copies_condition_check CHECK (condition IN ('new', 'good', 'fair', 'poor', 'damaged')),
+  CONSTRAINT copies_status_check CHECK (status IN ('available', 'on_loan', 'lost', 'in_repair'))
+);
+CREATE INDEX copies_book_id_status_idx ON copies (book_id, status);
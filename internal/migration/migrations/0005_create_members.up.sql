@@ -0,0 +1,19 @@
+CREATE TABLE members (
+  id                INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+  card_number       VARCHAR(20) NOT NULL,
+  first_name        VARCHAR(100) NOT NULL,
+  last_name         VARCHAR(100) NOT NULL,
+  email             VARCHAR(255) NOT NULL,
+  phone             VARCHAR(32) NOT NULL DEFAULT '',
+  address           VARCHAR(500) NOT NULL DEFAULT '',
+  membership_type   VARCHAR(20) NOT NULL DEFAULT 'standard',
+  member_since      DATE NOT NULL DEFAULT CURRENT_DATE,
+  expires_on        DATE NOT NULL,
+  suspended_at      TIMESTAMPTZ,
+  suspension_reason VARCHAR(500) NOT NULL DEFAULT '',
+  created_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT members_card_number_key UNIQUE (card_number),
+  CONSTRAINT members_membership_type_check CHECK (membership_type IN ('standard', 'student', 'senior', 'staff'))
+);
+CREATE UNIQUE INDEX members_email_key ON members (lower(email));
+CREATE INDEX members_name_idx ON members (last_name, first_name);
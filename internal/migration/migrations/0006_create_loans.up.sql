@@ -0,0 +1,15 @@
+CREATE TABLE loans (
+  id          INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+  copy_id     INT NOT NULL,
+  member_id   INT NOT NULL,
+  loaned_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+  due_on      DATE NOT NULL,
+  returned_at TIMESTAMPTZ,
+  renewals    INT NOT NULL DEFAULT 0,
+  CONSTRAINT loans_copy_id_fkey FOREIGN KEY (copy_id) REFERENCES copies (id) ON DELETE CASCADE,
+  CONSTRAINT loans_member_id_fkey FOREIGN KEY (member_id) REFERENCES members (id),
+  CONSTRAINT loans_renewals_check CHECK (renewals >= 0)
+);
+-- A copy can be out on at most one loan at a time.
+CREATE UNIQUE INDEX loans_copy_id_open_key ON loans (copy_id) WHERE returned_at IS NULL;
+CREATE INDEX loans_member_id_open_idx ON loans (member_id) WHERE returned_at IS NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS holds;
+UPDATE copies SET status = 'available' WHERE status = 'on_hold';
+ALTER TABLE copies DROP CONSTRAINT copies_status_check;
+ALTER TABLE copies ADD CONSTRAINT copies_status_check
+  CHECK (status IN ('available', 'on_loan', 'lost', 'in_repair'));
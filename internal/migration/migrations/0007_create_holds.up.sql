@@ -0,0 +1,23 @@
+ALTER TABLE copies DROP CONSTRAINT copies_status_check;
+ALTER TABLE copies ADD CONSTRAINT copies_status_check
+  CHECK (status IN ('available', 'on_loan', 'on_hold', 'lost', 'in_repair'));
+
+CREATE TABLE holds (
+  id        INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+  book_id   INT NOT NULL,
+  member_id INT NOT NULL,
+  status    VARCHAR(20) NOT NULL DEFAULT 'waiting',
+  placed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  copy_id   INT,
+  ready_at  TIMESTAMPTZ,
+  pickup_by DATE,
+  closed_at TIMESTAMPTZ,
+  CONSTRAINT holds_book_id_fkey FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+  CONSTRAINT holds_member_id_fkey FOREIGN KEY (member_id) REFERENCES members (id),
+  CONSTRAINT holds_copy_id_fkey FOREIGN KEY (copy_id) REFERENCES copies (id) ON DELETE SET NULL,
+  CONSTRAINT holds_status_check CHECK (status IN ('waiting', 'ready', 'fulfilled', 'cancelled', 'expired'))
+);
+-- A member queues at most once per title.
+CREATE UNIQUE INDEX holds_member_book_open_key ON holds (member_id, book_id) WHERE status IN ('waiting', 'ready');
+CREATE INDEX holds_book_queue_idx ON holds (book_id, placed_at, id) WHERE status = 'waiting';
+CREATE INDEX holds_pickup_by_idx ON holds (pickup_by) WHERE status = 'ready';
@@ -0,0 +1,16 @@
+CREATE TABLE ledger_entries (
+  id           INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+  member_id    INT NOT NULL,
+  kind         VARCHAR(10) NOT NULL,
+  amount_cents BIGINT NOT NULL,
+  loan_id      INT,
+  note         VARCHAR(500) NOT NULL DEFAULT '',
+  created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT ledger_entries_member_id_fkey FOREIGN KEY (member_id) REFERENCES members (id),
+  CONSTRAINT ledger_entries_loan_id_fkey FOREIGN KEY (loan_id) REFERENCES loans (id) ON DELETE SET NULL,
+  CONSTRAINT ledger_entries_kind_check CHECK (kind IN ('charge', 'payment', 'waiver')),
+  CONSTRAINT ledger_entries_amount_cents_check CHECK (amount_cents > 0)
+);
+CREATE INDEX ledger_entries_member_id_idx ON ledger_entries (member_id, id);
+-- A loan is fined at most once.
+CREATE UNIQUE INDEX ledger_entries_loan_id_charge_key ON ledger_entries (loan_id) WHERE kind = 'charge';
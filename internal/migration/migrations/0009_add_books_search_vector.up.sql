@@ -0,0 +1,7 @@
+-- Title matches weigh most, then the byline, then the description.
+ALTER TABLE books ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+  setweight(to_tsvector('english', title), 'A') ||
+  setweight(to_tsvector('english', author), 'B') ||
+  setweight(to_tsvector('english', description), 'C')
+) STORED;
+CREATE INDEX books_search_vector_idx ON books USING GIN (search_vector);
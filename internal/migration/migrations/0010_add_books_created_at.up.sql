@@ -0,0 +1,2 @@
+ALTER TABLE books ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+CREATE INDEX books_created_at_idx ON books (created_at);
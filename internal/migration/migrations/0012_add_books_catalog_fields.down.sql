@@ -0,0 +1,3 @@
+ALTER TABLE books DROP COLUMN IF EXISTS published_year;
+ALTER TABLE books DROP COLUMN IF EXISTS language;
+ALTER TABLE books DROP COLUMN IF EXISTS genre;
@@ -0,0 +1,9 @@
+-- Catalog fields the list and search endpoints facet on. They are optional,
+-- so books catalogued before them stay valid.
+ALTER TABLE books ADD COLUMN genre VARCHAR(64);
+ALTER TABLE books ADD COLUMN language VARCHAR(3);
+ALTER TABLE books ADD COLUMN published_year SMALLINT
+  CONSTRAINT books_published_year_check CHECK (published_year BETWEEN 1 AND 9999);
+CREATE INDEX books_genre_idx ON books (genre);
+CREATE INDEX books_language_idx ON books (language);
+CREATE INDEX books_published_year_idx ON books (published_year);
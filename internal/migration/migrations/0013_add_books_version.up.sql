@@ -0,0 +1,2 @@
+-- Every update bumps the version, which the API exposes as the ETag of a book.
+ALTER TABLE books ADD COLUMN version INT NOT NULL DEFAULT 1;
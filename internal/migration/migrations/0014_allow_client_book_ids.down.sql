@@ -0,0 +1 @@
+ALTER TABLE books ALTER COLUMN id SET GENERATED ALWAYS;
@@ -0,0 +1,3 @@
+-- PUT /books/{id} creates books at the id the client asks for, so the id may
+-- be given explicitly; the sequence still numbers books created by POST.
+ALTER TABLE books ALTER COLUMN id SET GENERATED BY DEFAULT;
@@ -0,0 +1,6 @@
+-- Without the column the trash cannot be told apart, so it is emptied first.
+DELETE FROM books WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS books_isbn_key;
+CREATE UNIQUE INDEX books_isbn_key ON books (isbn);
+DROP INDEX IF EXISTS books_deleted_at_idx;
+ALTER TABLE books DROP COLUMN IF EXISTS deleted_at;
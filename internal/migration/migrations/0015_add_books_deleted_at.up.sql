@@ -0,0 +1,7 @@
+-- Deleting a book moves it to the trash by setting deleted_at; the purge job
+-- removes it for good once the retention period has passed.
+ALTER TABLE books ADD COLUMN deleted_at TIMESTAMPTZ;
+CREATE INDEX books_deleted_at_idx ON books (deleted_at) WHERE deleted_at IS NOT NULL;
+-- A trashed book gives up its isbn, so the book can be catalogued again.
+DROP INDEX books_isbn_key;
+CREATE UNIQUE INDEX books_isbn_key ON books (isbn) WHERE deleted_at IS NULL;
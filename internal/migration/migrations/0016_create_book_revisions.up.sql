@@ -0,0 +1,25 @@
+-- A snapshot of a book after every change, numbered by the version the change
+-- produced. The snapshot has the shape of a PUT body so it can be reverted to.
+CREATE TABLE book_revisions (
+  book_id    INT NOT NULL,
+  revision   INT NOT NULL,
+  action     VARCHAR(16) NOT NULL,
+  snapshot   JSONB NOT NULL,
+  changed_by VARCHAR(100),
+  changed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (book_id, revision),
+  CONSTRAINT book_revisions_book_id_fkey FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+  CONSTRAINT book_revisions_action_check CHECK (action IN ('create', 'update', 'delete', 'restore'))
+);
+
+-- Books catalogued before revisions were kept start with their current state.
+INSERT INTO book_revisions (book_id, revision, action, snapshot)
+SELECT b.id, b.version,
+       CASE WHEN b.deleted_at IS NOT NULL THEN 'delete' WHEN b.version = 1 THEN 'create' ELSE 'update' END,
+       jsonb_build_object(
+         'title', b.title, 'author', b.author, 'description', b.description, 'isbn', COALESCE(b.isbn, ''),
+         'genre', COALESCE(b.genre, ''), 'language', COALESCE(b.language, ''),
+         'publishedYear', COALESCE(b.published_year, 0),
+         'authors', COALESCE((SELECT jsonb_agg(jsonb_build_object('authorId', ba.author_id, 'role', ba.role) ORDER BY ba.position)
+                              FROM book_authors ba WHERE ba.book_id = b.id), '[]'::jsonb))
+FROM books b;
@@ -0,0 +1,28 @@
+CREATE TABLE import_jobs (
+  id             INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+  status         VARCHAR(16) NOT NULL DEFAULT 'running',
+  dry_run        BOOLEAN NOT NULL DEFAULT false,
+  file_name      VARCHAR(255) NOT NULL DEFAULT '',
+  file_size      BIGINT NOT NULL DEFAULT 0,
+  bytes_read     BIGINT NOT NULL DEFAULT 0,
+  rows_read      INT NOT NULL DEFAULT 0,
+  rows_imported  INT NOT NULL DEFAULT 0,
+  rows_duplicate INT NOT NULL DEFAULT 0,
+  rows_failed    INT NOT NULL DEFAULT 0,
+  error          TEXT NOT NULL DEFAULT '',
+  created_by     VARCHAR(100),
+  created_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+  finished_at    TIMESTAMPTZ,
+  CONSTRAINT import_jobs_status_check CHECK (status IN ('running', 'succeeded', 'failed'))
+);
+
+-- The rows of an import that were not imported, and why.
+CREATE TABLE import_job_errors (
+  job_id     INT NOT NULL,
+  row_number INT NOT NULL,
+  reason     VARCHAR(16) NOT NULL,
+  message    TEXT NOT NULL,
+  PRIMARY KEY (job_id, row_number),
+  CONSTRAINT import_job_errors_job_id_fkey FOREIGN KEY (job_id) REFERENCES import_jobs (id) ON DELETE CASCADE,
+  CONSTRAINT import_job_errors_reason_check CHECK (reason IN ('invalid', 'duplicate', 'failed'))
+);
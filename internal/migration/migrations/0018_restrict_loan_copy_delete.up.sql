@@ -0,0 +1,5 @@
+-- Loans are the circulation history of a copy and fines refer to them, so a
+-- copy that was ever lent, and the book it belongs to, can no longer be
+-- deleted from under them.
+ALTER TABLE loans DROP CONSTRAINT loans_copy_id_fkey;
+ALTER TABLE loans ADD CONSTRAINT loans_copy_id_fkey FOREIGN KEY (copy_id) REFERENCES copies (id) ON DELETE RESTRICT;
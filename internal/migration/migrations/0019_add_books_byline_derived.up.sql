@@ -0,0 +1,3 @@
+-- A byline left out of a book is made of the names of its linked authors and
+-- must follow them when they are renamed, unlike one typed in.
+ALTER TABLE books ADD COLUMN byline_derived BOOLEAN NOT NULL DEFAULT false;
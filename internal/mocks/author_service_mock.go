@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/author/service.go
+
+// Package mock_book is a generated GoMock package.
+package mock_book
+
+import (
+	author "book-store/internal/author"
+	book "book-store/internal/book"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuthorService is a mock of AuthorService interface.
+type MockAuthorService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorServiceMockRecorder
+}
+
+// MockAuthorServiceMockRecorder is the mock recorder for MockAuthorService.
+type MockAuthorServiceMockRecorder struct {
+	mock *MockAuthorService
+}
+
+// NewMockAuthorService creates a new mock instance.
+func NewMockAuthorService(ctrl *gomock.Controller) *MockAuthorService {
+	mock := &MockAuthorService{ctrl: ctrl}
+	mock.recorder = &MockAuthorServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorService) EXPECT() *MockAuthorServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAuthorService) Create(ctx context.Context, req author.CreateOrUpdateAuthorRequest) (int64, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuthorServiceMockRecorder) Create(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuthorService)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockAuthorService) Delete(ctx context.Context, id int) *book.ErrorResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(*book.ErrorResponse)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAuthorServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAuthorService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockAuthorService) Get(ctx context.Context, id int) (author.Author, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(author.Author)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAuthorServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAuthorService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockAuthorService) List(ctx context.Context, name string, limit, offset int) ([]author.Author, int, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, name, limit, offset)
+	ret0, _ := ret[0].([]author.Author)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(*book.ErrorResponse)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockAuthorServiceMockRecorder) List(ctx, name, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuthorService)(nil).List), ctx, name, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockAuthorService) Update(ctx context.Context, id int, req author.CreateOrUpdateAuthorRequest) *book.ErrorResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, req)
+	ret0, _ := ret[0].(*book.ErrorResponse)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAuthorServiceMockRecorder) Update(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAuthorService)(nil).Update), ctx, id, req)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/book/copy_repository.go
+
+// Package mock_book is a generated GoMock package.
+package mock_book
+
+import (
+	book "book-store/internal/book"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCopyRepository is a mock of CopyRepository interface.
+type MockCopyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCopyRepositoryMockRecorder
+}
+
+// MockCopyRepositoryMockRecorder is the mock recorder for MockCopyRepository.
+type MockCopyRepositoryMockRecorder struct {
+	mock *MockCopyRepository
+}
+
+// NewMockCopyRepository creates a new mock instance.
+func NewMockCopyRepository(ctrl *gomock.Controller) *MockCopyRepository {
+	mock := &MockCopyRepository{ctrl: ctrl}
+	mock.recorder = &MockCopyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCopyRepository) EXPECT() *MockCopyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCopyRepository) Create(ctx context.Context, c book.Copy) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, c)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCopyRepositoryMockRecorder) Create(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCopyRepository)(nil).Create), ctx, c)
+}
+
+// Delete mocks base method.
+func (m *MockCopyRepository) Delete(ctx context.Context, bookID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, bookID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCopyRepositoryMockRecorder) Delete(ctx, bookID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCopyRepository)(nil).Delete), ctx, bookID, id)
+}
+
+// GetByID mocks base method.
+func (m *MockCopyRepository) GetByID(ctx context.Context, bookID, id int) (book.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, bookID, id)
+	ret0, _ := ret[0].(book.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCopyRepositoryMockRecorder) GetByID(ctx, bookID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCopyRepository)(nil).GetByID), ctx, bookID, id)
+}
+
+// ListByBook mocks base method.
+func (m *MockCopyRepository) ListByBook(ctx context.Context, bookID int) ([]book.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByBook", ctx, bookID)
+	ret0, _ := ret[0].([]book.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByBook indicates an expected call of ListByBook.
+func (mr *MockCopyRepositoryMockRecorder) ListByBook(ctx, bookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByBook", reflect.TypeOf((*MockCopyRepository)(nil).ListByBook), ctx, bookID)
+}
+
+// Update mocks base method.
+func (m *MockCopyRepository) Update(ctx context.Context, c book.Copy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCopyRepositoryMockRecorder) Update(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCopyRepository)(nil).Update), ctx, c)
+}
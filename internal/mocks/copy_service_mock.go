@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/book/copy_service.go
+
+// Package mock_book is a generated GoMock package.
+package mock_book
+
+import (
+	book "book-store/internal/book"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCopyService is a mock of CopyService interface.
+type MockCopyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCopyServiceMockRecorder
+}
+
+// MockCopyServiceMockRecorder is the mock recorder for MockCopyService.
+type MockCopyServiceMockRecorder struct {
+	mock *MockCopyService
+}
+
+// NewMockCopyService creates a new mock instance.
+func NewMockCopyService(ctrl *gomock.Controller) *MockCopyService {
+	mock := &MockCopyService{ctrl: ctrl}
+	mock.recorder = &MockCopyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCopyService) EXPECT() *MockCopyServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCopyService) Create(ctx context.Context, bookID int, req book.CreateOrUpdateCopyRequest) (int64, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, bookID, req)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCopyServiceMockRecorder) Create(ctx, bookID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCopyService)(nil).Create), ctx, bookID, req)
+}
+
+// Delete mocks base method.
+func (m *MockCopyService) Delete(ctx context.Context, bookID, id int) *book.ErrorResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, bookID, id)
+	ret0, _ := ret[0].(*book.ErrorResponse)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCopyServiceMockRecorder) Delete(ctx, bookID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCopyService)(nil).Delete), ctx, bookID, id)
+}
+
+// Get mocks base method.
+func (m *MockCopyService) Get(ctx context.Context, bookID, id int) (book.Copy, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, bookID, id)
+	ret0, _ := ret[0].(book.Copy)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCopyServiceMockRecorder) Get(ctx, bookID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCopyService)(nil).Get), ctx, bookID, id)
+}
+
+// List mocks base method.
+func (m *MockCopyService) List(ctx context.Context, bookID int) ([]book.Copy, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, bookID)
+	ret0, _ := ret[0].([]book.Copy)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCopyServiceMockRecorder) List(ctx, bookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCopyService)(nil).List), ctx, bookID)
+}
+
+// Update mocks base method.
+func (m *MockCopyService) Update(ctx context.Context, bookID, id int, req book.CreateOrUpdateCopyRequest) *book.ErrorResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, bookID, id, req)
+	ret0, _ := ret[0].(*book.ErrorResponse)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCopyServiceMockRecorder) Update(ctx, bookID, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCopyService)(nil).Update), ctx, bookID, id, req)
+}
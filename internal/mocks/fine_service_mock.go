@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/fine/service.go
+
+// Package mock_book is a generated GoMock package.
+package mock_book
+
+import (
+	book "book-store/internal/book"
+	fine "book-store/internal/fine"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFineService is a mock of FineService interface.
+type MockFineService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFineServiceMockRecorder
+}
+
+// MockFineServiceMockRecorder is the mock recorder for MockFineService.
+type MockFineServiceMockRecorder struct {
+	mock *MockFineService
+}
+
+// NewMockFineService creates a new mock instance.
+func NewMockFineService(ctrl *gomock.Controller) *MockFineService {
+	mock := &MockFineService{ctrl: ctrl}
+	mock.recorder = &MockFineServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFineService) EXPECT() *MockFineServiceMockRecorder {
+	return m.recorder
+}
+
+// Ledger mocks base method.
+func (m *MockFineService) Ledger(ctx context.Context, memberID, limit, offset int) ([]fine.Entry, int, int64, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ledger", ctx, memberID, limit, offset)
+	ret0, _ := ret[0].([]fine.Entry)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(int64)
+	ret3, _ := ret[3].(*book.ErrorResponse)
+	return ret0, ret1, ret2, ret3
+}
+
+// Ledger indicates an expected call of Ledger.
+func (mr *MockFineServiceMockRecorder) Ledger(ctx, memberID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ledger", reflect.TypeOf((*MockFineService)(nil).Ledger), ctx, memberID, limit, offset)
+}
+
+// Pay mocks base method.
+func (m *MockFineService) Pay(ctx context.Context, memberID int, req fine.PaymentRequest) (fine.Entry, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", ctx, memberID, req)
+	ret0, _ := ret[0].(fine.Entry)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockFineServiceMockRecorder) Pay(ctx, memberID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockFineService)(nil).Pay), ctx, memberID, req)
+}
+
+// Waive mocks base method.
+func (m *MockFineService) Waive(ctx context.Context, memberID int, req fine.WaiverRequest) (fine.Entry, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Waive", ctx, memberID, req)
+	ret0, _ := ret[0].(fine.Entry)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Waive indicates an expected call of Waive.
+func (mr *MockFineServiceMockRecorder) Waive(ctx, memberID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Waive", reflect.TypeOf((*MockFineService)(nil).Waive), ctx, memberID, req)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/loan/hold_service.go
+
+// Package mock_book is a generated GoMock package.
+package mock_book
+
+import (
+	book "book-store/internal/book"
+	loan "book-store/internal/loan"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHoldService is a mock of HoldService interface.
+type MockHoldService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldServiceMockRecorder
+}
+
+// MockHoldServiceMockRecorder is the mock recorder for MockHoldService.
+type MockHoldServiceMockRecorder struct {
+	mock *MockHoldService
+}
+
+// NewMockHoldService creates a new mock instance.
+func NewMockHoldService(ctrl *gomock.Controller) *MockHoldService {
+	mock := &MockHoldService{ctrl: ctrl}
+	mock.recorder = &MockHoldServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldService) EXPECT() *MockHoldServiceMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockHoldService) Cancel(ctx context.Context, id int) *book.ErrorResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id)
+	ret0, _ := ret[0].(*book.ErrorResponse)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockHoldServiceMockRecorder) Cancel(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockHoldService)(nil).Cancel), ctx, id)
+}
+
+// ExpireUncollected mocks base method.
+func (m *MockHoldService) ExpireUncollected(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireUncollected", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireUncollected indicates an expected call of ExpireUncollected.
+func (mr *MockHoldServiceMockRecorder) ExpireUncollected(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireUncollected", reflect.TypeOf((*MockHoldService)(nil).ExpireUncollected), ctx)
+}
+
+// Get mocks base method.
+func (m *MockHoldService) Get(ctx context.Context, id int) (loan.Hold, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(loan.Hold)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockHoldServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHoldService)(nil).Get), ctx, id)
+}
+
+// ListByMember mocks base method.
+func (m *MockHoldService) ListByMember(ctx context.Context, memberID int) ([]loan.Hold, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByMember", ctx, memberID)
+	ret0, _ := ret[0].([]loan.Hold)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// ListByMember indicates an expected call of ListByMember.
+func (mr *MockHoldServiceMockRecorder) ListByMember(ctx, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByMember", reflect.TypeOf((*MockHoldService)(nil).ListByMember), ctx, memberID)
+}
+
+// Place mocks base method.
+func (m *MockHoldService) Place(ctx context.Context, bookID int, req loan.PlaceHoldRequest) (loan.Hold, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Place", ctx, bookID, req)
+	ret0, _ := ret[0].(loan.Hold)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Place indicates an expected call of Place.
+func (mr *MockHoldServiceMockRecorder) Place(ctx, bookID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Place", reflect.TypeOf((*MockHoldService)(nil).Place), ctx, bookID, req)
+}
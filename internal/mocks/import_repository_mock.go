@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/book/import_repository.go
+
+// Package mock_book is a generated GoMock package.
+package mock_book
+
+import (
+	book "book-store/internal/book"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockImportRepository is a mock of ImportRepository interface.
+type MockImportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportRepositoryMockRecorder
+}
+
+// MockImportRepositoryMockRecorder is the mock recorder for MockImportRepository.
+type MockImportRepositoryMockRecorder struct {
+	mock *MockImportRepository
+}
+
+// NewMockImportRepository creates a new mock instance.
+func NewMockImportRepository(ctrl *gomock.Controller) *MockImportRepository {
+	mock := &MockImportRepository{ctrl: ctrl}
+	mock.recorder = &MockImportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportRepository) EXPECT() *MockImportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockImportRepository) Create(ctx context.Context, job book.ImportJob) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, job)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImportRepositoryMockRecorder) Create(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImportRepository)(nil).Create), ctx, job)
+}
+
+// EachError mocks base method.
+func (m *MockImportRepository) EachError(ctx context.Context, jobID int, fn func(book.ImportError) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachError", ctx, jobID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachError indicates an expected call of EachError.
+func (mr *MockImportRepositoryMockRecorder) EachError(ctx, jobID, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachError", reflect.TypeOf((*MockImportRepository)(nil).EachError), ctx, jobID, fn)
+}
+
+// FailRunning mocks base method.
+func (m *MockImportRepository) FailRunning(ctx context.Context, reason string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailRunning", ctx, reason)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailRunning indicates an expected call of FailRunning.
+func (mr *MockImportRepositoryMockRecorder) FailRunning(ctx, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailRunning", reflect.TypeOf((*MockImportRepository)(nil).FailRunning), ctx, reason)
+}
+
+// FindDuplicate mocks base method.
+func (m *MockImportRepository) FindDuplicate(ctx context.Context, isbn, title, author string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicate", ctx, isbn, title, author)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicate indicates an expected call of FindDuplicate.
+func (mr *MockImportRepositoryMockRecorder) FindDuplicate(ctx, isbn, title, author interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicate", reflect.TypeOf((*MockImportRepository)(nil).FindDuplicate), ctx, isbn, title, author)
+}
+
+// Finish mocks base method.
+func (m *MockImportRepository) Finish(ctx context.Context, job book.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockImportRepositoryMockRecorder) Finish(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockImportRepository)(nil).Finish), ctx, job)
+}
+
+// GetByID mocks base method.
+func (m *MockImportRepository) GetByID(ctx context.Context, id int) (book.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(book.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockImportRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockImportRepository)(nil).GetByID), ctx, id)
+}
+
+// SaveProgress mocks base method.
+func (m *MockImportRepository) SaveProgress(ctx context.Context, job book.ImportJob, rejected []book.ImportError) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProgress", ctx, job, rejected)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProgress indicates an expected call of SaveProgress.
+func (mr *MockImportRepositoryMockRecorder) SaveProgress(ctx, job, rejected interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProgress", reflect.TypeOf((*MockImportRepository)(nil).SaveProgress), ctx, job, rejected)
+}
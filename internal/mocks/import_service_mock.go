@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/book/import_service.go
+
+// Package mock_book is a generated GoMock package.
+package mock_book
+
+import (
+	book "book-store/internal/book"
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockImportService is a mock of ImportService interface.
+type MockImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportServiceMockRecorder
+}
+
+// MockImportServiceMockRecorder is the mock recorder for MockImportService.
+type MockImportServiceMockRecorder struct {
+	mock *MockImportService
+}
+
+// NewMockImportService creates a new mock instance.
+func NewMockImportService(ctrl *gomock.Controller) *MockImportService {
+	mock := &MockImportService{ctrl: ctrl}
+	mock.recorder = &MockImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportService) EXPECT() *MockImportServiceMockRecorder {
+	return m.recorder
+}
+
+// FailInterrupted mocks base method.
+func (m *MockImportService) FailInterrupted(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailInterrupted", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailInterrupted indicates an expected call of FailInterrupted.
+func (mr *MockImportServiceMockRecorder) FailInterrupted(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailInterrupted", reflect.TypeOf((*MockImportService)(nil).FailInterrupted), ctx)
+}
+
+// Get mocks base method.
+func (m *MockImportService) Get(ctx context.Context, id int) (book.ImportJob, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(book.ImportJob)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockImportServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockImportService)(nil).Get), ctx, id)
+}
+
+// Start mocks base method.
+func (m *MockImportService) Start(ctx context.Context, upload book.ImportUpload) (book.ImportJob, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, upload)
+	ret0, _ := ret[0].(book.ImportJob)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockImportServiceMockRecorder) Start(ctx, upload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockImportService)(nil).Start), ctx, upload)
+}
+
+// WriteErrorReport mocks base method.
+func (m *MockImportService) WriteErrorReport(ctx context.Context, id int, w io.Writer) *book.ErrorResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteErrorReport", ctx, id, w)
+	ret0, _ := ret[0].(*book.ErrorResponse)
+	return ret0
+}
+
+// WriteErrorReport indicates an expected call of WriteErrorReport.
+func (mr *MockImportServiceMockRecorder) WriteErrorReport(ctx, id, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteErrorReport", reflect.TypeOf((*MockImportService)(nil).WriteErrorReport), ctx, id, w)
+}
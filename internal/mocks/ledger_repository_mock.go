@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/fine/repository.go
+
+// Package mock_book is a generated GoMock package.
+package mock_book
+
+import (
+	fine "book-store/internal/fine"
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLedgerRepository is a mock of LedgerRepository interface.
+type MockLedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerRepositoryMockRecorder
+}
+
+// MockLedgerRepositoryMockRecorder is the mock recorder for MockLedgerRepository.
+type MockLedgerRepositoryMockRecorder struct {
+	mock *MockLedgerRepository
+}
+
+// NewMockLedgerRepository creates a new mock instance.
+func NewMockLedgerRepository(ctrl *gomock.Controller) *MockLedgerRepository {
+	mock := &MockLedgerRepository{ctrl: ctrl}
+	mock.recorder = &MockLedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerRepository) EXPECT() *MockLedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Balance mocks base method.
+func (m *MockLedgerRepository) Balance(ctx context.Context, memberID int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balance", ctx, memberID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balance indicates an expected call of Balance.
+func (mr *MockLedgerRepositoryMockRecorder) Balance(ctx, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockLedgerRepository)(nil).Balance), ctx, memberID)
+}
+
+// Credit mocks base method.
+func (m *MockLedgerRepository) Credit(ctx context.Context, memberID int, kind string, amount int64, note string) (fine.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Credit", ctx, memberID, kind, amount, note)
+	ret0, _ := ret[0].(fine.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Credit indicates an expected call of Credit.
+func (mr *MockLedgerRepositoryMockRecorder) Credit(ctx, memberID, kind, amount, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Credit", reflect.TypeOf((*MockLedgerRepository)(nil).Credit), ctx, memberID, kind, amount, note)
+}
+
+// List mocks base method.
+func (m *MockLedgerRepository) List(ctx context.Context, memberID, limit, offset int) ([]fine.Entry, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, memberID, limit, offset)
+	ret0, _ := ret[0].([]fine.Entry)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockLedgerRepositoryMockRecorder) List(ctx, memberID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLedgerRepository)(nil).List), ctx, memberID, limit, offset)
+}
+
+// MockqueryRower is a mock of queryRower interface.
+type MockqueryRower struct {
+	ctrl     *gomock.Controller
+	recorder *MockqueryRowerMockRecorder
+}
+
+// MockqueryRowerMockRecorder is the mock recorder for MockqueryRower.
+type MockqueryRowerMockRecorder struct {
+	mock *MockqueryRower
+}
+
+// NewMockqueryRower creates a new mock instance.
+func NewMockqueryRower(ctrl *gomock.Controller) *MockqueryRower {
+	mock := &MockqueryRower{ctrl: ctrl}
+	mock.recorder = &MockqueryRowerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockqueryRower) EXPECT() *MockqueryRowerMockRecorder {
+	return m.recorder
+}
+
+// QueryRowContext mocks base method.
+func (m *MockqueryRower) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRowContext", varargs...)
+	ret0, _ := ret[0].(*sql.Row)
+	return ret0
+}
+
+// QueryRowContext indicates an expected call of QueryRowContext.
+func (mr *MockqueryRowerMockRecorder) QueryRowContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRowContext", reflect.TypeOf((*MockqueryRower)(nil).QueryRowContext), varargs...)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/loan/repository.go
+
+// Package mock_book is a generated GoMock package.
+package mock_book
+
+import (
+	loan "book-store/internal/loan"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoanRepository is a mock of LoanRepository interface.
+type MockLoanRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanRepositoryMockRecorder
+}
+
+// MockLoanRepositoryMockRecorder is the mock recorder for MockLoanRepository.
+type MockLoanRepositoryMockRecorder struct {
+	mock *MockLoanRepository
+}
+
+// NewMockLoanRepository creates a new mock instance.
+func NewMockLoanRepository(ctrl *gomock.Controller) *MockLoanRepository {
+	mock := &MockLoanRepository{ctrl: ctrl}
+	mock.recorder = &MockLoanRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanRepository) EXPECT() *MockLoanRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Checkout mocks base method.
+func (m *MockLoanRepository) Checkout(ctx context.Context, memberID int, barcode string, dueOn time.Time, maxLoans int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", ctx, memberID, barcode, dueOn, maxLoans)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockLoanRepositoryMockRecorder) Checkout(ctx, memberID, barcode, dueOn, maxLoans interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockLoanRepository)(nil).Checkout), ctx, memberID, barcode, dueOn, maxLoans)
+}
+
+// GetByID mocks base method.
+func (m *MockLoanRepository) GetByID(ctx context.Context, id int) (loan.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(loan.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockLoanRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockLoanRepository)(nil).GetByID), ctx, id)
+}
+
+// Renew mocks base method.
+func (m *MockLoanRepository) Renew(ctx context.Context, id, renewals int, dueOn time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", ctx, id, renewals, dueOn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockLoanRepositoryMockRecorder) Renew(ctx, id, renewals, dueOn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockLoanRepository)(nil).Renew), ctx, id, renewals, dueOn)
+}
+
+// Return mocks base method.
+func (m *MockLoanRepository) Return(ctx context.Context, id int, at, dueOn time.Time, fine int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Return", ctx, id, at, dueOn, fine)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Return indicates an expected call of Return.
+func (mr *MockLoanRepositoryMockRecorder) Return(ctx, id, at, dueOn, fine interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Return", reflect.TypeOf((*MockLoanRepository)(nil).Return), ctx, id, at, dueOn, fine)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/loan/service.go
+
+// Package mock_book is a generated GoMock package.
+package mock_book
+
+import (
+	book "book-store/internal/book"
+	loan "book-store/internal/loan"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoanService is a mock of LoanService interface.
+type MockLoanService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanServiceMockRecorder
+}
+
+// MockLoanServiceMockRecorder is the mock recorder for MockLoanService.
+type MockLoanServiceMockRecorder struct {
+	mock *MockLoanService
+}
+
+// NewMockLoanService creates a new mock instance.
+func NewMockLoanService(ctrl *gomock.Controller) *MockLoanService {
+	mock := &MockLoanService{ctrl: ctrl}
+	mock.recorder = &MockLoanServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanService) EXPECT() *MockLoanServiceMockRecorder {
+	return m.recorder
+}
+
+// Checkout mocks base method.
+func (m *MockLoanService) Checkout(ctx context.Context, req loan.CheckoutRequest) (loan.Loan, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", ctx, req)
+	ret0, _ := ret[0].(loan.Loan)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockLoanServiceMockRecorder) Checkout(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockLoanService)(nil).Checkout), ctx, req)
+}
+
+// Get mocks base method.
+func (m *MockLoanService) Get(ctx context.Context, id int) (loan.Loan, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(loan.Loan)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLoanServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLoanService)(nil).Get), ctx, id)
+}
+
+// Renew mocks base method.
+func (m *MockLoanService) Renew(ctx context.Context, id int) (loan.Loan, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", ctx, id)
+	ret0, _ := ret[0].(loan.Loan)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockLoanServiceMockRecorder) Renew(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockLoanService)(nil).Renew), ctx, id)
+}
+
+// Return mocks base method.
+func (m *MockLoanService) Return(ctx context.Context, id int) (loan.Loan, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Return", ctx, id)
+	ret0, _ := ret[0].(loan.Loan)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Return indicates an expected call of Return.
+func (mr *MockLoanServiceMockRecorder) Return(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Return", reflect.TypeOf((*MockLoanService)(nil).Return), ctx, id)
+}
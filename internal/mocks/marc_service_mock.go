@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/marc/service.go
+
+// Package mock_book is a generated GoMock package.
+package mock_book
+
+import (
+	book "book-store/internal/book"
+	marc "book-store/internal/marc"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMARCService is a mock of MARCService interface.
+type MockMARCService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMARCServiceMockRecorder
+}
+
+// MockMARCServiceMockRecorder is the mock recorder for MockMARCService.
+type MockMARCServiceMockRecorder struct {
+	mock *MockMARCService
+}
+
+// NewMockMARCService creates a new mock instance.
+func NewMockMARCService(ctrl *gomock.Controller) *MockMARCService {
+	mock := &MockMARCService{ctrl: ctrl}
+	mock.recorder = &MockMARCServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMARCService) EXPECT() *MockMARCServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockMARCService) Export(ctx context.Context, fn func([]marc.Record) error) *book.ErrorResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, fn)
+	ret0, _ := ret[0].(*book.ErrorResponse)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockMARCServiceMockRecorder) Export(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockMARCService)(nil).Export), ctx, fn)
+}
+
+// Get mocks base method.
+func (m *MockMARCService) Get(ctx context.Context, id int) (marc.Record, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(marc.Record)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMARCServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMARCService)(nil).Get), ctx, id)
+}
+
+// Import mocks base method.
+func (m *MockMARCService) Import(ctx context.Context, records []marc.Record, dryRun bool) []marc.ImportResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, records, dryRun)
+	ret0, _ := ret[0].([]marc.ImportResult)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockMARCServiceMockRecorder) Import(ctx, records, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockMARCService)(nil).Import), ctx, records, dryRun)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/member/service.go
+
+// Package mock_book is a generated GoMock package.
+package mock_book
+
+import (
+	book "book-store/internal/book"
+	member "book-store/internal/member"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMemberService is a mock of MemberService interface.
+type MockMemberService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberServiceMockRecorder
+}
+
+// MockMemberServiceMockRecorder is the mock recorder for MockMemberService.
+type MockMemberServiceMockRecorder struct {
+	mock *MockMemberService
+}
+
+// NewMockMemberService creates a new mock instance.
+func NewMockMemberService(ctrl *gomock.Controller) *MockMemberService {
+	mock := &MockMemberService{ctrl: ctrl}
+	mock.recorder = &MockMemberServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMemberService) EXPECT() *MockMemberServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMemberService) Delete(ctx context.Context, id int) *book.ErrorResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(*book.ErrorResponse)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMemberServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMemberService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockMemberService) Get(ctx context.Context, id int) (member.Member, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(member.Member)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMemberServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMemberService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockMemberService) List(ctx context.Context, f member.MemberFilter, limit, offset int) ([]member.Member, int, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, f, limit, offset)
+	ret0, _ := ret[0].([]member.Member)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(*book.ErrorResponse)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockMemberServiceMockRecorder) List(ctx, f, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMemberService)(nil).List), ctx, f, limit, offset)
+}
+
+// Register mocks base method.
+func (m *MockMemberService) Register(ctx context.Context, req member.CreateOrUpdateMemberRequest) (int64, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, req)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockMemberServiceMockRecorder) Register(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockMemberService)(nil).Register), ctx, req)
+}
+
+// Reinstate mocks base method.
+func (m *MockMemberService) Reinstate(ctx context.Context, id int) *book.ErrorResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reinstate", ctx, id)
+	ret0, _ := ret[0].(*book.ErrorResponse)
+	return ret0
+}
+
+// Reinstate indicates an expected call of Reinstate.
+func (mr *MockMemberServiceMockRecorder) Reinstate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reinstate", reflect.TypeOf((*MockMemberService)(nil).Reinstate), ctx, id)
+}
+
+// Suspend mocks base method.
+func (m *MockMemberService) Suspend(ctx context.Context, id int, reason string) *book.ErrorResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspend", ctx, id, reason)
+	ret0, _ := ret[0].(*book.ErrorResponse)
+	return ret0
+}
+
+// Suspend indicates an expected call of Suspend.
+func (mr *MockMemberServiceMockRecorder) Suspend(ctx, id, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspend", reflect.TypeOf((*MockMemberService)(nil).Suspend), ctx, id, reason)
+}
+
+// Update mocks base method.
+func (m *MockMemberService) Update(ctx context.Context, id int, req member.CreateOrUpdateMemberRequest) *book.ErrorResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, req)
+	ret0, _ := ret[0].(*book.ErrorResponse)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMemberServiceMockRecorder) Update(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMemberService)(nil).Update), ctx, id, req)
+}
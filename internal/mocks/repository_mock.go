@@ -7,7 +7,9 @@ package mock_book
 import (
 	book "book-store/internal/book"
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,6 +37,36 @@ func (m *MockBookRepository) EXPECT() *MockBookRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ApplyBatch mocks base method.
+func (m *MockBookRepository) ApplyBatch(ctx context.Context, writes []book.BatchWrite, atomic bool) ([]book.BatchOutcome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyBatch", ctx, writes, atomic)
+	ret0, _ := ret[0].([]book.BatchOutcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyBatch indicates an expected call of ApplyBatch.
+func (mr *MockBookRepositoryMockRecorder) ApplyBatch(ctx, writes, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyBatch", reflect.TypeOf((*MockBookRepository)(nil).ApplyBatch), ctx, writes, atomic)
+}
+
+// Count mocks base method.
+func (m *MockBookRepository) Count(ctx context.Context, f book.BookFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, f)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockBookRepositoryMockRecorder) Count(ctx, f interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockBookRepository)(nil).Count), ctx, f)
+}
+
 // Create mocks base method.
 func (m *MockBookRepository) Create(ctx context.Context, b book.Book) (int64, error) {
 	m.ctrl.T.Helper()
@@ -51,17 +83,46 @@ func (mr *MockBookRepositoryMockRecorder) Create(ctx, b interface{}) *gomock.Cal
 }
 
 // Delete mocks base method.
-func (m *MockBookRepository) Delete(ctx context.Context, id int) error {
+func (m *MockBookRepository) Delete(ctx context.Context, id, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockBookRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockBookRepositoryMockRecorder) Delete(ctx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBookRepository)(nil).Delete), ctx, id, version)
+}
+
+// Export mocks base method.
+func (m *MockBookRepository) Export(ctx context.Context, f book.BookFilter, sort []book.SortField, fn func([]book.Book) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, f, sort, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockBookRepositoryMockRecorder) Export(ctx, f, sort, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockBookRepository)(nil).Export), ctx, f, sort, fn)
+}
+
+// Facets mocks base method.
+func (m *MockBookRepository) Facets(ctx context.Context, f book.BookFilter, tsquery string) (book.Facets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Facets", ctx, f, tsquery)
+	ret0, _ := ret[0].(book.Facets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Facets indicates an expected call of Facets.
+func (mr *MockBookRepositoryMockRecorder) Facets(ctx, f, tsquery interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBookRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Facets", reflect.TypeOf((*MockBookRepository)(nil).Facets), ctx, f, tsquery)
 }
 
 // GetByID mocks base method.
@@ -79,10 +140,55 @@ func (mr *MockBookRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBookRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByISBN mocks base method.
+func (m *MockBookRepository) GetByISBN(ctx context.Context, isbn string) (book.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByISBN", ctx, isbn)
+	ret0, _ := ret[0].(book.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByISBN indicates an expected call of GetByISBN.
+func (mr *MockBookRepositoryMockRecorder) GetByISBN(ctx, isbn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByISBN", reflect.TypeOf((*MockBookRepository)(nil).GetByISBN), ctx, isbn)
+}
+
+// GetRevision mocks base method.
+func (m *MockBookRepository) GetRevision(ctx context.Context, bookID, revision int) (book.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, bookID, revision)
+	ret0, _ := ret[0].(book.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockBookRepositoryMockRecorder) GetRevision(ctx, bookID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockBookRepository)(nil).GetRevision), ctx, bookID, revision)
+}
+
+// InTrash mocks base method.
+func (m *MockBookRepository) InTrash(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTrash", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InTrash indicates an expected call of InTrash.
+func (mr *MockBookRepositoryMockRecorder) InTrash(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTrash", reflect.TypeOf((*MockBookRepository)(nil).InTrash), ctx, id)
+}
+
 // List mocks base method.
-func (m *MockBookRepository) List(ctx context.Context, limit, offset int) ([]book.Book, int, error) {
+func (m *MockBookRepository) List(ctx context.Context, f book.BookFilter, sort []book.SortField, limit, offset int) ([]book.Book, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret := m.ctrl.Call(m, "List", ctx, f, sort, limit, offset)
 	ret0, _ := ret[0].([]book.Book)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
@@ -90,17 +196,126 @@ func (m *MockBookRepository) List(ctx context.Context, limit, offset int) ([]boo
 }
 
 // List indicates an expected call of List.
-func (mr *MockBookRepositoryMockRecorder) List(ctx, limit, offset interface{}) *gomock.Call {
+func (mr *MockBookRepositoryMockRecorder) List(ctx, f, sort, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBookRepository)(nil).List), ctx, f, sort, limit, offset)
+}
+
+// ListAfter mocks base method.
+func (m *MockBookRepository) ListAfter(ctx context.Context, f book.BookFilter, sort []book.SortField, after []string, limit int) ([]book.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAfter", ctx, f, sort, after, limit)
+	ret0, _ := ret[0].([]book.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAfter indicates an expected call of ListAfter.
+func (mr *MockBookRepositoryMockRecorder) ListAfter(ctx, f, sort, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAfter", reflect.TypeOf((*MockBookRepository)(nil).ListAfter), ctx, f, sort, after, limit)
+}
+
+// ListRevisions mocks base method.
+func (m *MockBookRepository) ListRevisions(ctx context.Context, bookID, limit, offset int) ([]book.Revision, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, bookID, limit, offset)
+	ret0, _ := ret[0].([]book.Revision)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockBookRepositoryMockRecorder) ListRevisions(ctx, bookID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockBookRepository)(nil).ListRevisions), ctx, bookID, limit, offset)
+}
+
+// ListTrash mocks base method.
+func (m *MockBookRepository) ListTrash(ctx context.Context, limit, offset int) ([]book.Book, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, limit, offset)
+	ret0, _ := ret[0].([]book.Book)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockBookRepositoryMockRecorder) ListTrash(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockBookRepository)(nil).ListTrash), ctx, limit, offset)
+}
+
+// Purge mocks base method.
+func (m *MockBookRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockBookRepositoryMockRecorder) Purge(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockBookRepository)(nil).Purge), ctx, before)
+}
+
+// Restore mocks base method.
+func (m *MockBookRepository) Restore(ctx context.Context, id int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBookRepositoryMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBookRepository)(nil).Restore), ctx, id)
+}
+
+// Search mocks base method.
+func (m *MockBookRepository) Search(ctx context.Context, tsquery string, f book.BookFilter, limit, offset int) ([]book.SearchResult, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, tsquery, f, limit, offset)
+	ret0, _ := ret[0].([]book.SearchResult)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockBookRepositoryMockRecorder) Search(ctx, tsquery, f, limit, offset interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBookRepository)(nil).List), ctx, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockBookRepository)(nil).Search), ctx, tsquery, f, limit, offset)
+}
+
+// Suggest mocks base method.
+func (m *MockBookRepository) Suggest(ctx context.Context, q string, limit int) ([]book.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, q, limit)
+	ret0, _ := ret[0].([]book.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockBookRepositoryMockRecorder) Suggest(ctx, q, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockBookRepository)(nil).Suggest), ctx, q, limit)
 }
 
 // Update mocks base method.
-func (m *MockBookRepository) Update(ctx context.Context, b book.Book) error {
+func (m *MockBookRepository) Update(ctx context.Context, b book.Book) (int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", ctx, b)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
@@ -108,3 +323,101 @@ func (mr *MockBookRepositoryMockRecorder) Update(ctx, b interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookRepository)(nil).Update), ctx, b)
 }
+
+// Upsert mocks base method.
+func (m *MockBookRepository) Upsert(ctx context.Context, b book.Book) (bool, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, b)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockBookRepositoryMockRecorder) Upsert(ctx, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockBookRepository)(nil).Upsert), ctx, b)
+}
+
+// Mockqueryer is a mock of queryer interface.
+type Mockqueryer struct {
+	ctrl     *gomock.Controller
+	recorder *MockqueryerMockRecorder
+}
+
+// MockqueryerMockRecorder is the mock recorder for Mockqueryer.
+type MockqueryerMockRecorder struct {
+	mock *Mockqueryer
+}
+
+// NewMockqueryer creates a new mock instance.
+func NewMockqueryer(ctrl *gomock.Controller) *Mockqueryer {
+	mock := &Mockqueryer{ctrl: ctrl}
+	mock.recorder = &MockqueryerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockqueryer) EXPECT() *MockqueryerMockRecorder {
+	return m.recorder
+}
+
+// ExecContext mocks base method.
+func (m *Mockqueryer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExecContext", varargs...)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecContext indicates an expected call of ExecContext.
+func (mr *MockqueryerMockRecorder) ExecContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecContext", reflect.TypeOf((*Mockqueryer)(nil).ExecContext), varargs...)
+}
+
+// QueryContext mocks base method.
+func (m *Mockqueryer) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryContext", varargs...)
+	ret0, _ := ret[0].(*sql.Rows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryContext indicates an expected call of QueryContext.
+func (mr *MockqueryerMockRecorder) QueryContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryContext", reflect.TypeOf((*Mockqueryer)(nil).QueryContext), varargs...)
+}
+
+// QueryRowContext mocks base method.
+func (m *Mockqueryer) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRowContext", varargs...)
+	ret0, _ := ret[0].(*sql.Row)
+	return ret0
+}
+
+// QueryRowContext indicates an expected call of QueryRowContext.
+func (mr *MockqueryerMockRecorder) QueryRowContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRowContext", reflect.TypeOf((*Mockqueryer)(nil).QueryRowContext), varargs...)
+}
@@ -8,6 +8,7 @@ import (
 	book "book-store/internal/book"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,6 +36,20 @@ func (m *MockBookService) EXPECT() *MockBookServiceMockRecorder {
 	return m.recorder
 }
 
+// Batch mocks base method.
+func (m *MockBookService) Batch(ctx context.Context, ops []book.BatchOperation, atomic bool) []book.BatchResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", ctx, ops, atomic)
+	ret0, _ := ret[0].([]book.BatchResult)
+	return ret0
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockBookServiceMockRecorder) Batch(ctx, ops, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockBookService)(nil).Batch), ctx, ops, atomic)
+}
+
 // Create mocks base method.
 func (m *MockBookService) Create(ctx context.Context, req book.CreateOrUpdateBookRequest) (int64, *book.ErrorResponse) {
 	m.ctrl.T.Helper()
@@ -51,32 +66,77 @@ func (mr *MockBookServiceMockRecorder) Create(ctx, req interface{}) *gomock.Call
 }
 
 // CreateOrUpdate mocks base method.
-func (m *MockBookService) CreateOrUpdate(ctx context.Context, id int, req book.CreateOrUpdateBookRequest) (int64, *book.ErrorResponse) {
+func (m *MockBookService) CreateOrUpdate(ctx context.Context, id int, req book.CreateOrUpdateBookRequest, ifMatch *book.Precondition) (book.Book, bool, *book.ErrorResponse) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrUpdate", ctx, id, req)
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(*book.ErrorResponse)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "CreateOrUpdate", ctx, id, req, ifMatch)
+	ret0, _ := ret[0].(book.Book)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(*book.ErrorResponse)
+	return ret0, ret1, ret2
 }
 
 // CreateOrUpdate indicates an expected call of CreateOrUpdate.
-func (mr *MockBookServiceMockRecorder) CreateOrUpdate(ctx, id, req interface{}) *gomock.Call {
+func (mr *MockBookServiceMockRecorder) CreateOrUpdate(ctx, id, req, ifMatch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdate", reflect.TypeOf((*MockBookService)(nil).CreateOrUpdate), ctx, id, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdate", reflect.TypeOf((*MockBookService)(nil).CreateOrUpdate), ctx, id, req, ifMatch)
 }
 
 // Delete mocks base method.
-func (m *MockBookService) Delete(ctx context.Context, id int) *book.ErrorResponse {
+func (m *MockBookService) Delete(ctx context.Context, id int, ifMatch *book.Precondition) *book.ErrorResponse {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, ifMatch)
 	ret0, _ := ret[0].(*book.ErrorResponse)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockBookServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockBookServiceMockRecorder) Delete(ctx, id, ifMatch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBookService)(nil).Delete), ctx, id, ifMatch)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockBookService) DiffRevisions(ctx context.Context, id, from, to int) ([]book.FieldChange, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", ctx, id, from, to)
+	ret0, _ := ret[0].([]book.FieldChange)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockBookServiceMockRecorder) DiffRevisions(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockBookService)(nil).DiffRevisions), ctx, id, from, to)
+}
+
+// Export mocks base method.
+func (m *MockBookService) Export(ctx context.Context, f book.BookFilter, sort []book.SortField, fn func([]book.Book) error) *book.ErrorResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, f, sort, fn)
+	ret0, _ := ret[0].(*book.ErrorResponse)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockBookServiceMockRecorder) Export(ctx, f, sort, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockBookService)(nil).Export), ctx, f, sort, fn)
+}
+
+// Facets mocks base method.
+func (m *MockBookService) Facets(ctx context.Context, f book.BookFilter, q string) (book.Facets, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Facets", ctx, f, q)
+	ret0, _ := ret[0].(book.Facets)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Facets indicates an expected call of Facets.
+func (mr *MockBookServiceMockRecorder) Facets(ctx, f, q interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBookService)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Facets", reflect.TypeOf((*MockBookService)(nil).Facets), ctx, f, q)
 }
 
 // Get mocks base method.
@@ -94,10 +154,55 @@ func (mr *MockBookServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBookService)(nil).Get), ctx, id)
 }
 
+// GetByISBN mocks base method.
+func (m *MockBookService) GetByISBN(ctx context.Context, isbn string) (book.Book, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByISBN", ctx, isbn)
+	ret0, _ := ret[0].(book.Book)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// GetByISBN indicates an expected call of GetByISBN.
+func (mr *MockBookServiceMockRecorder) GetByISBN(ctx, isbn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByISBN", reflect.TypeOf((*MockBookService)(nil).GetByISBN), ctx, isbn)
+}
+
+// GetMany mocks base method.
+func (m *MockBookService) GetMany(ctx context.Context, ids []int) ([]book.Book, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, ids)
+	ret0, _ := ret[0].([]book.Book)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockBookServiceMockRecorder) GetMany(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockBookService)(nil).GetMany), ctx, ids)
+}
+
+// GetRevision mocks base method.
+func (m *MockBookService) GetRevision(ctx context.Context, id, rev int) (book.Revision, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, id, rev)
+	ret0, _ := ret[0].(book.Revision)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockBookServiceMockRecorder) GetRevision(ctx, id, rev interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockBookService)(nil).GetRevision), ctx, id, rev)
+}
+
 // List mocks base method.
-func (m *MockBookService) List(ctx context.Context, limit, offset int) ([]book.Book, int, *book.ErrorResponse) {
+func (m *MockBookService) List(ctx context.Context, f book.BookFilter, sort []book.SortField, limit, offset int) ([]book.Book, int, *book.ErrorResponse) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret := m.ctrl.Call(m, "List", ctx, f, sort, limit, offset)
 	ret0, _ := ret[0].([]book.Book)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(*book.ErrorResponse)
@@ -105,7 +210,145 @@ func (m *MockBookService) List(ctx context.Context, limit, offset int) ([]book.B
 }
 
 // List indicates an expected call of List.
-func (mr *MockBookServiceMockRecorder) List(ctx, limit, offset interface{}) *gomock.Call {
+func (mr *MockBookServiceMockRecorder) List(ctx, f, sort, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBookService)(nil).List), ctx, f, sort, limit, offset)
+}
+
+// ListRevisions mocks base method.
+func (m *MockBookService) ListRevisions(ctx context.Context, id, limit, offset int) ([]book.Revision, int, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, id, limit, offset)
+	ret0, _ := ret[0].([]book.Revision)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(*book.ErrorResponse)
+	return ret0, ret1, ret2
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockBookServiceMockRecorder) ListRevisions(ctx, id, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockBookService)(nil).ListRevisions), ctx, id, limit, offset)
+}
+
+// ListTrash mocks base method.
+func (m *MockBookService) ListTrash(ctx context.Context, limit, offset int) ([]book.Book, int, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, limit, offset)
+	ret0, _ := ret[0].([]book.Book)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(*book.ErrorResponse)
+	return ret0, ret1, ret2
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockBookServiceMockRecorder) ListTrash(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockBookService)(nil).ListTrash), ctx, limit, offset)
+}
+
+// Patch mocks base method.
+func (m *MockBookService) Patch(ctx context.Context, id int, contentType string, patch []byte, ifMatch *book.Precondition) (int, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, contentType, patch, ifMatch)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockBookServiceMockRecorder) Patch(ctx, id, contentType, patch, ifMatch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockBookService)(nil).Patch), ctx, id, contentType, patch, ifMatch)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockBookService) PurgeTrash(ctx context.Context, retention time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", ctx, retention)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockBookServiceMockRecorder) PurgeTrash(ctx, retention interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockBookService)(nil).PurgeTrash), ctx, retention)
+}
+
+// Restore mocks base method.
+func (m *MockBookService) Restore(ctx context.Context, id int) (book.Book, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(book.Book)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBookServiceMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBookService)(nil).Restore), ctx, id)
+}
+
+// Revert mocks base method.
+func (m *MockBookService) Revert(ctx context.Context, id, rev int, ifMatch *book.Precondition) (int, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", ctx, id, rev, ifMatch)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockBookServiceMockRecorder) Revert(ctx, id, rev, ifMatch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockBookService)(nil).Revert), ctx, id, rev, ifMatch)
+}
+
+// Scroll mocks base method.
+func (m *MockBookService) Scroll(ctx context.Context, f book.BookFilter, sort []book.SortField, cursor string, limit int, withTotal bool) (book.BookScroll, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scroll", ctx, f, sort, cursor, limit, withTotal)
+	ret0, _ := ret[0].(book.BookScroll)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Scroll indicates an expected call of Scroll.
+func (mr *MockBookServiceMockRecorder) Scroll(ctx, f, sort, cursor, limit, withTotal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scroll", reflect.TypeOf((*MockBookService)(nil).Scroll), ctx, f, sort, cursor, limit, withTotal)
+}
+
+// Search mocks base method.
+func (m *MockBookService) Search(ctx context.Context, q string, f book.BookFilter, limit, offset int) ([]book.SearchResult, int, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, q, f, limit, offset)
+	ret0, _ := ret[0].([]book.SearchResult)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(*book.ErrorResponse)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockBookServiceMockRecorder) Search(ctx, q, f, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockBookService)(nil).Search), ctx, q, f, limit, offset)
+}
+
+// Suggest mocks base method.
+func (m *MockBookService) Suggest(ctx context.Context, q string, limit int) ([]book.Suggestion, *book.ErrorResponse) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, q, limit)
+	ret0, _ := ret[0].([]book.Suggestion)
+	ret1, _ := ret[1].(*book.ErrorResponse)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockBookServiceMockRecorder) Suggest(ctx, q, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBookService)(nil).List), ctx, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockBookService)(nil).Suggest), ctx, q, limit)
 }
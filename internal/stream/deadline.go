@@ -0,0 +1,20 @@
+// Package stream helps handlers that write a response in batches, such as
+// the exports, which may well outlast the server's write timeout.
+package stream
+
+import (
+	"net/http"
+	"time"
+)
+
+// ExtendWriteDeadline moves the write deadline of a streamed response to the
+// server's write timeout from now. Called before the first batch and after
+// each flush, it gives every batch a timeout of its own, which lets a long
+// response through while still cutting off a client that stops reading.
+func ExtendWriteDeadline(r *http.Request, rc *http.ResponseController) {
+	srv, _ := r.Context().Value(http.ServerContextKey).(*http.Server)
+	if srv == nil || srv.WriteTimeout <= 0 {
+		return
+	}
+	rc.SetWriteDeadline(time.Now().Add(srv.WriteTimeout))
+}